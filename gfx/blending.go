@@ -73,6 +73,32 @@ var DefaultBlendState = BlendState{
 	AlphaEq:  BAdd,
 }
 
+// StraightBlendState is a blend state for traditional non-premultiplied (i.e.
+// straight) alpha blending, where the source color is weighted by it's alpha
+// component.
+var StraightBlendState = BlendState{
+	Color:    Color{0, 0, 0, 0},
+	SrcRGB:   BSrcAlpha,
+	SrcAlpha: BOne,
+	DstRGB:   BOneMinusSrcAlpha,
+	DstAlpha: BOneMinusSrcAlpha,
+	RGBEq:    BAdd,
+	AlphaEq:  BAdd,
+}
+
+// AdditiveBlendState is a blend state for additive blending (e.g. for
+// particles, glow effects, etc), where the (non-premultiplied) source color
+// weighted by it's alpha component is added to the destination color.
+var AdditiveBlendState = BlendState{
+	Color:    Color{0, 0, 0, 0},
+	SrcRGB:   BSrcAlpha,
+	SrcAlpha: BZero,
+	DstRGB:   BOne,
+	DstAlpha: BOne,
+	RGBEq:    BAdd,
+	AlphaEq:  BAdd,
+}
+
 // BlendOp represents a single blend operand, e.g. BOne, BOneMinusSrcAlpha.
 type BlendOp uint8
 
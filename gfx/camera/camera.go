@@ -15,10 +15,14 @@
 //
 // The type of camera can be switched at runtime by changing mycam.Ortho = true
 // as needed, and then calling Update.
+//
+// First-person and orbit (turntable) camera controls are provided by the
+// FPSController and OrbitController types.
 package camera // import "github.com/qmcloud/engine/gfx/camera"
 
 import (
 	"image"
+	"math"
 	"sync"
 
 	"github.com/qmcloud/engine/gfx"
@@ -75,21 +79,129 @@ func (c *Camera) Projection() gfx.Mat4 {
 	return c.P
 }
 
-// Project returns a 2D point in normalized device space coordinates given a 3D
-// point in the world.
+// viewProjection returns the combined view and projection matrix of the
+// camera, which transforms world space into clip space.
+func (c *Camera) viewProjection() lmath.Mat4 {
+	cameraInv, _ := c.Object.Transform.Mat4().Inverse()
+	cameraInv = cameraInv.Mul(zUpRightToYUpRight)
+	return cameraInv.Mul(c.P.Mat4())
+}
+
+// ProjectNDC returns a 2D point in normalized device space coordinates given a
+// 3D point in the world.
 //
 // If ok=false is returned then the point is outside of the camera's view and
 // the returned point may not be meaningful.
-func (c *Camera) Project(p3 lmath.Vec3) (p2 lmath.Vec2, ok bool) {
-	cameraInv, _ := c.Object.Transform.Mat4().Inverse()
-	cameraInv = cameraInv.Mul(zUpRightToYUpRight)
+func (c *Camera) ProjectNDC(p3 lmath.Vec3) (p2 lmath.Vec2, ok bool) {
+	return c.viewProjection().Project(p3)
+}
 
-	vp := cameraInv.Mul(c.P.Mat4())
+// Project returns the screen space point of the given 3D point in the world,
+// using the camera's view and projection matrices.
+//
+// The X and Y components of the returned point are pixel coordinates within
+// the given viewport rectangle (with the origin at the top-left, as in the
+// image package), and the Z component is the depth of the point in the range
+// of zero (the near plane) to one (the far plane).
+//
+// Points behind the camera produce meaningless results, which can be detected
+// as their depth is outside of the [0, 1] range.
+func (c *Camera) Project(world lmath.Vec3, viewport image.Rectangle) lmath.Vec3 {
+	p := lmath.Vec4{world.X, world.Y, world.Z, 1}
+	p = p.Transform(c.viewProjection())
+	if p.W == 0 {
+		return lmath.Vec3Zero
+	}
+	ndc := lmath.Vec3{p.X / p.W, p.Y / p.W, p.Z / p.W}
+	return lmath.Vec3{
+		X: float64(viewport.Min.X) + (ndc.X+1)/2*float64(viewport.Dx()),
+		Y: float64(viewport.Min.Y) + (1-ndc.Y)/2*float64(viewport.Dy()),
+		Z: (ndc.Z + 1) / 2,
+	}
+}
 
-	p2, ok = vp.Project(p3)
+// Unproject is the inverse of Project, it returns the 3D point in the world
+// given a screen space point, whose X and Y components are pixel coordinates
+// within the given viewport rectangle and whose Z component is the depth in
+// the range of zero (the near plane) to one (the far plane).
+func (c *Camera) Unproject(screen lmath.Vec3, viewport image.Rectangle) lmath.Vec3 {
+	inv, ok := c.viewProjection().Inverse()
+	if !ok || viewport.Empty() {
+		return lmath.Vec3Zero
+	}
+	p := lmath.Vec4{
+		X: (screen.X-float64(viewport.Min.X))/float64(viewport.Dx())*2 - 1,
+		Y: 1 - (screen.Y-float64(viewport.Min.Y))/float64(viewport.Dy())*2,
+		Z: screen.Z*2 - 1,
+		W: 1,
+	}
+	p = p.Transform(inv)
+	if p.W == 0 {
+		return lmath.Vec3Zero
+	}
+	return lmath.Vec3{p.X / p.W, p.Y / p.W, p.Z / p.W}
+}
+
+// ScreenToRay returns a ray in world space passing through the given pixel of
+// the viewport, e.g. for picking objects under the mouse cursor. The origin of
+// the ray lies on the camera's near plane and dir is normalized.
+func (c *Camera) ScreenToRay(x, y int, viewport image.Rectangle) (origin, dir lmath.Vec3) {
+	// Use the center of the pixel.
+	sx, sy := float64(x)+0.5, float64(y)+0.5
+	origin = c.Unproject(lmath.Vec3{sx, sy, 0}, viewport)
+	far := c.Unproject(lmath.Vec3{sx, sy, 1}, viewport)
+	dir, _ = far.Sub(origin).Normalized()
 	return
 }
 
+// LookAt sets the camera's transform such that it is positioned at eye and
+// looks at the target point, with the given up vector (e.g. lmath.Vec3{0, 0, 1}
+// in the right-handed Z-up coordinate system) pointing towards the top of the
+// camera's view. All of the vectors are in the camera's parent space.
+//
+// If eye and target are equal then only the position is changed. If the
+// direction to the target is parallel to up then the +Y axis is used as the up
+// vector instead.
+func (c *Camera) LookAt(eye, target, up lmath.Vec3) {
+	c.Object.Transform.SetPos(eye)
+	forward, ok := target.Sub(eye).Normalized()
+	if !ok {
+		return
+	}
+	right, ok := forward.Cross(up).Normalized()
+	if !ok {
+		right, ok = forward.Cross(lmath.Vec3{0, 1, 0}).Normalized()
+		if !ok {
+			return
+		}
+	}
+	up = right.Cross(forward)
+
+	// The camera looks down it's local +Y axis with +Z up, so the rows of the
+	// rotation matrix are the right, forward, and up vectors.
+	m := lmath.Matrix3(
+		right.X, right.Y, right.Z,
+		forward.X, forward.Y, forward.Z,
+		up.X, up.Y, up.Z,
+	)
+	c.Object.Transform.SetQuat(lmath.QuatFromMat3(m))
+}
+
+// Orbit positions the camera dist units away from the target point and looks
+// at it, as is typical of orbit cameras. Yaw is the rotation in degrees about
+// the +Z axis and pitch is the elevation in degrees above the target; when
+// both are zero the camera lies on the -Y side of the target looking down the
+// +Y axis. The target is in the camera's parent space.
+func (c *Camera) Orbit(target lmath.Vec3, yaw, pitch, dist float64) {
+	yaw, pitch = lmath.Radians(yaw), lmath.Radians(pitch)
+	offset := lmath.Vec3{
+		X: math.Sin(yaw) * math.Cos(pitch),
+		Y: -math.Cos(yaw) * math.Cos(pitch),
+		Z: math.Sin(pitch),
+	}
+	c.LookAt(target.Add(offset.MulScalar(dist)), target, lmath.Vec3{0, 0, 1})
+}
+
 // Copy returns a new copy of this Camera.
 func (c *Camera) Copy() *Camera {
 	cpy := *c
@@ -6,9 +6,82 @@ package camera
 
 import (
 	"image"
+	"testing"
 
 	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
 )
 
 // A check for whether or not *camera.Camera implements gfx.Camera properly.
 var _ gfx.Camera = New(image.Rectangle{})
+
+func TestProjectUnproject(t *testing.T) {
+	view := image.Rect(0, 0, 640, 480)
+	for _, c := range []*Camera{New(view), NewOrtho(view)} {
+		c.Object.Transform.SetPos(lmath.Vec3{10, -5, 2})
+
+		world := lmath.Vec3{12, 20, 3}
+		screen := c.Project(world, view)
+		if screen.Z < 0 || screen.Z > 1 {
+			t.Fatalf("ortho=%v: Project depth %v out of range", c.Ortho, screen.Z)
+		}
+		if got := c.Unproject(screen, view); !got.AlmostEquals(world, 1e-6) {
+			t.Fatalf("ortho=%v: Unproject(Project(%v)) = %v", c.Ortho, world, got)
+		}
+	}
+
+	// A perspective camera looks down the +Y axis, so a point straight ahead
+	// lies in the center of the viewport.
+	c := New(view)
+	screen := c.Project(lmath.Vec3{0, 10, 0}, view)
+	if !screen.AlmostEquals(lmath.Vec3{320, 240, screen.Z}, 1e-6) {
+		t.Fatalf("Project center = %v, want (320, 240)", screen)
+	}
+	origin, dir := c.ScreenToRay(319, 239, view)
+	if !dir.AlmostEquals(lmath.Vec3{0, 1, 0}, 1e-2) {
+		t.Fatalf("ScreenToRay dir = %v, want (0, 1, 0)", dir)
+	}
+	if !lmath.AlmostEqual(origin.Y, c.Near, 1e-6) {
+		t.Fatalf("ScreenToRay origin = %v, want on the near plane", origin)
+	}
+}
+
+func TestLookAtOrbit(t *testing.T) {
+	view := image.Rect(0, 0, 640, 480)
+	c := New(view)
+	center := lmath.Vec3{320, 240, 0}
+	for _, tst := range []struct {
+		eye, target lmath.Vec3
+	}{
+		{lmath.Vec3{0, 0, 0}, lmath.Vec3{0, 10, 0}},
+		{lmath.Vec3{5, -3, 2}, lmath.Vec3{-4, 8, 1}},
+		{lmath.Vec3{0, 0, 10}, lmath.Vec3{0, 0, 0}},
+	} {
+		c.LookAt(tst.eye, tst.target, lmath.Vec3{0, 0, 1})
+		screen := c.Project(tst.target, view)
+		if !screen.AlmostEquals(lmath.Vec3{center.X, center.Y, screen.Z}, 1e-6) {
+			t.Fatalf("LookAt(%v, %v): Project(target) = %v, want (320, 240)", tst.eye, tst.target, screen)
+		}
+		if screen.Z < 0 || screen.Z > 1 {
+			t.Fatalf("LookAt(%v, %v): target is behind the camera", tst.eye, tst.target)
+		}
+	}
+
+	// A point above the target should appear above the center of the view.
+	c.LookAt(lmath.Vec3{5, -3, 2}, lmath.Vec3{-4, 8, 1}, lmath.Vec3{0, 0, 1})
+	if screen := c.Project(lmath.Vec3{-4, 8, 2}, view); screen.Y >= center.Y {
+		t.Fatalf("LookAt: point above the target projected to %v, want above center", screen)
+	}
+
+	target := lmath.Vec3{1, 2, 3}
+	c.Orbit(target, 30, 45, 10)
+	if d := c.Object.Transform.Pos().Sub(target).Length(); !lmath.AlmostEqual(d, 10, 1e-9) {
+		t.Fatalf("Orbit: distance = %v, want 10", d)
+	}
+	if pos := c.Object.Transform.Pos(); pos.Z <= target.Z {
+		t.Fatalf("Orbit: position %v, want above the target", pos)
+	}
+	if screen := c.Project(target, view); !screen.AlmostEquals(lmath.Vec3{center.X, center.Y, screen.Z}, 1e-6) {
+		t.Fatalf("Orbit: Project(target) = %v, want (320, 240)", screen)
+	}
+}
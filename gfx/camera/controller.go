@@ -0,0 +1,265 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package camera
+
+import (
+	"math"
+
+	"github.com/qmcloud/engine/keyboard"
+	"github.com/qmcloud/engine/lmath"
+	"github.com/qmcloud/engine/mouse"
+)
+
+// cursor tracks the cursor position in order to produce relative movement
+// from both grabbed (delta) and non-grabbed (absolute) cursor events.
+type cursor struct {
+	x, y    float64
+	tracked bool
+}
+
+// move returns the relative movement of the cursor given the values of a
+// window.CursorMoved event.
+func (c *cursor) move(x, y float64, delta bool) (dx, dy float64) {
+	if delta {
+		c.tracked = false
+		return x, y
+	}
+	if c.tracked {
+		dx, dy = x-c.x, y-c.y
+	}
+	c.x, c.y, c.tracked = x, y, true
+	return
+}
+
+// FPSController is a first-person camera controller, it moves the camera
+// using the keyboard (WASD by default) and rotates it as the mouse moves:
+//
+//	ctrl := camera.NewFPSController(cam, w.Keyboard())
+//
+//	// For each window.CursorMoved event (the window's cursor should be
+//	// grabbed, see window.Props.SetCursorGrabbed):
+//	ctrl.CursorMoved(ev.X, ev.Y, ev.Delta)
+//
+//	// Each frame:
+//	clock.Tick()
+//	ctrl.Update(clock.Dt())
+//
+// A controller and it's methods are not safe for access from multiple
+// goroutines concurrently.
+type FPSController struct {
+	// The camera to control.
+	Camera *Camera
+
+	// The keyboard watcher whose state is used to move the camera, or nil if
+	// the camera should not be moved.
+	Keyboard *keyboard.Watcher
+
+	// The keys that move the camera forward, back, left, right, up and down,
+	// respectively. The camera moves forward in the direction it is looking
+	// and up along the +Z axis.
+	Forward, Back, Left, Right, Up, Down keyboard.Key
+
+	// Speed is the movement speed of the camera in units per second.
+	Speed float64
+
+	// Sensitivity is the rotation of the camera in degrees per unit of cursor
+	// movement (i.e. per pixel).
+	Sensitivity float64
+
+	// Yaw is the rotation of the camera about the +Z axis and Pitch is the
+	// rotation above (positive) or below (negative) the horizon, both in
+	// degrees. Pitch is clamped to the range of [-MaxPitch, MaxPitch].
+	Yaw, Pitch, MaxPitch float64
+
+	cursor cursor
+}
+
+// CursorMoved rotates the camera given the values of a window.CursorMoved
+// event. Both grabbed (delta) and non-grabbed cursor movement is supported.
+func (f *FPSController) CursorMoved(x, y float64, delta bool) {
+	dx, dy := f.cursor.move(x, y, delta)
+	f.Yaw -= dx * f.Sensitivity
+	f.Pitch = clamp(f.Pitch-dy*f.Sensitivity, -f.MaxPitch, f.MaxPitch)
+}
+
+// Update moves the camera according to the keyboard state and updates it's
+// rotation, given the time in seconds since the last update (e.g. the value of
+// clock.Dt) such that movement is independent of the frame rate.
+func (f *FPSController) Update(dt float64) {
+	t := f.Camera.Object.Transform
+	pos := t.Pos()
+	forward := direction(f.Yaw, f.Pitch)
+	if f.Keyboard != nil {
+		right := lmath.Vec3{
+			X: math.Cos(lmath.Radians(f.Yaw)),
+			Y: math.Sin(lmath.Radians(f.Yaw)),
+		}
+		var move lmath.Vec3
+		if f.Keyboard.Down(f.Forward) {
+			move = move.Add(forward)
+		}
+		if f.Keyboard.Down(f.Back) {
+			move = move.Sub(forward)
+		}
+		if f.Keyboard.Down(f.Right) {
+			move = move.Add(right)
+		}
+		if f.Keyboard.Down(f.Left) {
+			move = move.Sub(right)
+		}
+		if f.Keyboard.Down(f.Up) {
+			move.Z++
+		}
+		if f.Keyboard.Down(f.Down) {
+			move.Z--
+		}
+		if move, ok := move.Normalized(); ok {
+			pos = pos.Add(move.MulScalar(f.Speed * dt))
+		}
+	}
+	f.Camera.LookAt(pos, pos.Add(forward), lmath.Vec3{0, 0, 1})
+}
+
+// NewFPSController returns a new first-person controller for the given camera
+// using the given keyboard watcher (e.g. the one returned by a window's
+// Keyboard method). The initial yaw and pitch are taken from the camera's
+// current orientation.
+func NewFPSController(cam *Camera, kb *keyboard.Watcher) *FPSController {
+	f := &FPSController{
+		Camera:      cam,
+		Keyboard:    kb,
+		Forward:     keyboard.W,
+		Back:        keyboard.S,
+		Left:        keyboard.A,
+		Right:       keyboard.D,
+		Up:          keyboard.Space,
+		Down:        keyboard.LeftCtrl,
+		Speed:       5,
+		Sensitivity: 0.15,
+		MaxPitch:    89,
+	}
+	forward := cam.Object.Transform.Quat().Forward(lmath.CoordSysZUpRight)
+	if forward, ok := forward.Normalized(); ok {
+		f.Yaw = lmath.Degrees(math.Atan2(-forward.X, forward.Y))
+		f.Pitch = clamp(lmath.Degrees(math.Asin(forward.Z)), -f.MaxPitch, f.MaxPitch)
+	}
+	return f
+}
+
+// OrbitController is a turntable camera controller, it orbits the camera
+// around a target point as the mouse is dragged and zooms in and out using the
+// scroll wheel:
+//
+//	ctrl := camera.NewOrbitController(cam, w.Mouse())
+//
+//	// For each window.CursorMoved event:
+//	ctrl.CursorMoved(ev.X, ev.Y, ev.Delta)
+//
+//	// Each frame:
+//	clock.Tick()
+//	ctrl.Update(clock.Dt())
+//
+// A controller and it's methods are not safe for access from multiple
+// goroutines concurrently.
+type OrbitController struct {
+	// The camera to control.
+	Camera *Camera
+
+	// The mouse watcher whose state is used to orbit and zoom the camera. If
+	// nil, the camera orbits whenever the cursor moves and is never zoomed.
+	//
+	// Note that Update consumes the scroll amount of the watcher via it's
+	// ScrollDelta method.
+	Mouse *mouse.Watcher
+
+	// The mouse button that must be held down to orbit the camera.
+	Button mouse.Button
+
+	// The point the camera orbits around.
+	Target lmath.Vec3
+
+	// Yaw is the rotation of the camera about the target's +Z axis and Pitch
+	// is the elevation of the camera above the target, both in degrees (see
+	// Camera.Orbit). Pitch is clamped to the range of [-MaxPitch, MaxPitch].
+	Yaw, Pitch, MaxPitch float64
+
+	// Dist is the distance of the camera from the target, which is clamped to
+	// the range of [MinDist, MaxDist].
+	Dist, MinDist, MaxDist float64
+
+	// Sensitivity is the rotation of the camera in degrees per unit of cursor
+	// movement (i.e. per pixel).
+	Sensitivity float64
+
+	// ZoomSpeed is the fraction of the distance that the camera zooms per
+	// unit of scrolling.
+	ZoomSpeed float64
+
+	// AutoRotate is the speed in degrees per second at which the camera
+	// rotates about the target on it's own (e.g. for a turntable display), or
+	// zero for none.
+	AutoRotate float64
+
+	cursor cursor
+}
+
+// CursorMoved orbits the camera given the values of a window.CursorMoved
+// event, if the controller's mouse button is held down.
+func (o *OrbitController) CursorMoved(x, y float64, delta bool) {
+	dx, dy := o.cursor.move(x, y, delta)
+	if o.Mouse != nil && !o.Mouse.Down(o.Button) {
+		return
+	}
+	o.Yaw -= dx * o.Sensitivity
+	o.Pitch = clamp(o.Pitch+dy*o.Sensitivity, -o.MaxPitch, o.MaxPitch)
+}
+
+// Update zooms the camera according to the scroll wheel and updates it's
+// position and rotation, given the time in seconds since the last update (e.g.
+// the value of clock.Dt) such that AutoRotate is independent of the frame
+// rate.
+func (o *OrbitController) Update(dt float64) {
+	if o.Mouse != nil {
+		if _, y := o.Mouse.ScrollDelta(); y != 0 {
+			o.Dist *= math.Pow(1-o.ZoomSpeed, y)
+		}
+	}
+	o.Dist = clamp(o.Dist, o.MinDist, o.MaxDist)
+	o.Yaw += o.AutoRotate * dt
+	o.Camera.Orbit(o.Target, o.Yaw, o.Pitch, o.Dist)
+}
+
+// NewOrbitController returns a new orbit controller for the given camera using
+// the given mouse watcher (e.g. the one returned by a window's Mouse method).
+// The camera orbits around the origin while the left mouse button is held
+// down, and the initial distance is the camera's current distance from the
+// origin.
+func NewOrbitController(cam *Camera, m *mouse.Watcher) *OrbitController {
+	return &OrbitController{
+		Camera:      cam,
+		Mouse:       m,
+		Button:      mouse.Left,
+		MaxPitch:    89,
+		Dist:        cam.Object.Transform.Pos().Length(),
+		MinDist:     0.1,
+		MaxDist:     math.Inf(1),
+		Sensitivity: 0.25,
+		ZoomSpeed:   0.1,
+	}
+}
+
+// direction returns the forward vector given the yaw and pitch in degrees.
+func direction(yaw, pitch float64) lmath.Vec3 {
+	yaw, pitch = lmath.Radians(yaw), lmath.Radians(pitch)
+	return lmath.Vec3{
+		X: -math.Sin(yaw) * math.Cos(pitch),
+		Y: math.Cos(yaw) * math.Cos(pitch),
+		Z: math.Sin(pitch),
+	}
+}
+
+func clamp(v, min, max float64) float64 {
+	return math.Max(min, math.Min(max, v))
+}
@@ -0,0 +1,101 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package camera
+
+import (
+	"image"
+	"testing"
+
+	"github.com/qmcloud/engine/keyboard"
+	"github.com/qmcloud/engine/lmath"
+	"github.com/qmcloud/engine/mouse"
+)
+
+func TestFPSController(t *testing.T) {
+	view := image.Rect(0, 0, 640, 480)
+	c := New(view)
+	kb := keyboard.NewWatcher()
+	f := NewFPSController(c, kb)
+	if f.Yaw != 0 || f.Pitch != 0 {
+		t.Fatalf("initial yaw, pitch = %v, %v, want 0, 0", f.Yaw, f.Pitch)
+	}
+
+	// Moving forward for one second moves Speed units down the +Y axis.
+	kb.SetState(keyboard.W, keyboard.Down)
+	f.Update(0.5)
+	f.Update(0.5)
+	if pos := c.Object.Transform.Pos(); !pos.AlmostEquals(lmath.Vec3{0, f.Speed, 0}, 1e-9) {
+		t.Fatalf("forward position = %v, want (0, %v, 0)", pos, f.Speed)
+	}
+	kb.SetState(keyboard.W, keyboard.Up)
+
+	// Looking left (moving the cursor left) turns the camera towards -X.
+	f.CursorMoved(-90/f.Sensitivity, 0, true)
+	if !lmath.AlmostEqual(f.Yaw, 90, 1e-9) {
+		t.Fatalf("yaw = %v, want 90", f.Yaw)
+	}
+	f.Update(0)
+	pos := c.Object.Transform.Pos()
+	ahead := pos.Add(lmath.Vec3{-10, 0, 0})
+	if screen := c.Project(ahead, view); !screen.AlmostEquals(lmath.Vec3{320, 240, screen.Z}, 1e-6) {
+		t.Fatalf("Project(ahead) = %v, want (320, 240)", screen)
+	}
+
+	// Non-delta cursor movement is relative to the previous position, and
+	// pitch is clamped.
+	f.CursorMoved(100, 100, false)
+	f.CursorMoved(100, -1e6, false)
+	if f.Pitch != f.MaxPitch {
+		t.Fatalf("pitch = %v, want %v", f.Pitch, f.MaxPitch)
+	}
+
+	// A new controller picks up the camera's orientation.
+	f.Update(0)
+	f2 := NewFPSController(c, kb)
+	if !lmath.AlmostEqual(f2.Yaw, f.Yaw, 1e-6) || !lmath.AlmostEqual(f2.Pitch, f.Pitch, 1e-6) {
+		t.Fatalf("new controller yaw, pitch = %v, %v, want %v, %v", f2.Yaw, f2.Pitch, f.Yaw, f.Pitch)
+	}
+}
+
+func TestOrbitController(t *testing.T) {
+	view := image.Rect(0, 0, 640, 480)
+	c := New(view)
+	c.Object.Transform.SetPos(lmath.Vec3{0, -10, 0})
+	m := mouse.NewWatcher()
+	o := NewOrbitController(c, m)
+	o.Target = lmath.Vec3{1, 2, 3}
+	if o.Dist != 10 {
+		t.Fatalf("initial distance = %v, want 10", o.Dist)
+	}
+
+	// Cursor movement only orbits while the button is held down.
+	o.CursorMoved(50, 0, true)
+	if o.Yaw != 0 {
+		t.Fatalf("yaw = %v, want 0 with the button up", o.Yaw)
+	}
+	m.SetState(mouse.Left, mouse.Down)
+	o.CursorMoved(50, 40, true)
+	if o.Yaw == 0 || o.Pitch == 0 {
+		t.Fatalf("yaw, pitch = %v, %v, want non-zero", o.Yaw, o.Pitch)
+	}
+
+	// Scrolling up zooms in.
+	m.AddScroll(0, 1)
+	o.AutoRotate = 10
+	yaw := o.Yaw
+	o.Update(2)
+	if o.Dist >= 10 {
+		t.Fatalf("distance = %v, want less than 10", o.Dist)
+	}
+	if !lmath.AlmostEqual(o.Yaw, yaw+20, 1e-9) {
+		t.Fatalf("yaw = %v, want %v", o.Yaw, yaw+20)
+	}
+	if d := c.Object.Transform.Pos().Sub(o.Target).Length(); !lmath.AlmostEqual(d, o.Dist, 1e-9) {
+		t.Fatalf("camera distance = %v, want %v", d, o.Dist)
+	}
+	if screen := c.Project(o.Target, view); !screen.AlmostEquals(lmath.Vec3{320, 240, screen.Z}, 1e-6) {
+		t.Fatalf("Project(target) = %v, want (320, 240)", screen)
+	}
+}
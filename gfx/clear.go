@@ -0,0 +1,30 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import "image"
+
+// ClearRect describes a single clear operation of a call to the ClearRects
+// method of a canvas. Each of the color, depth, and stencil buffers is only
+// cleared if it's respective flag is set.
+type ClearRect struct {
+	// The rectangle of the canvas to clear, if it is empty then the clear
+	// operation is no-op.
+	Rect image.Rectangle
+
+	// Whether or not to clear the color, depth, and stencil buffers,
+	// respectively.
+	ClearColor, ClearDepth, ClearStencil bool
+
+	// The color to clear the color buffer to.
+	Color Color
+
+	// The depth value to clear the depth buffer to (in the range of 0.0 to
+	// 1.0, where 1.0 is furthest away).
+	Depth float64
+
+	// The stencil value to clear the stencil buffer to.
+	Stencil int
+}
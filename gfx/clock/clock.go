@@ -10,16 +10,40 @@ import (
 	"time"
 )
 
+// SmoothingMode specifies the algorithm used to smooth frame times when
+// determining the average frame rate, see the SetSmoothing method of Clock.
+type SmoothingMode uint8
+
+const (
+	// SimpleAverage averages the frame times of the last AvgSamples frames.
+	// It is the default smoothing mode.
+	SimpleAverage SmoothingMode = iota
+
+	// ExponentialAverage computes an exponential moving average of frame
+	// times, where each new frame time is weighted by the smoothing factor:
+	//
+	//  avg = factor*frameTime + (1-factor)*avg
+	//
+	// Smaller factors give smoother (but slower to respond) results. Unlike a
+	// SimpleAverage over a large number of samples, a change in frame rate is
+	// reflected immediately (if only partially).
+	ExponentialAverage
+)
+
 // Clock is a high resolution clock for measuring real-time application
 // statistics.
 type Clock struct {
 	access sync.RWMutex
 
 	delta, maxDelta, fixedDelta, startTime, lastFrameTime, maxFrameRateSleep time.Duration
+	stepAccum                                                                time.Duration
 	frameCount, frameRateFrames                                              uint64
 
 	avgSamples                                                []float64
 	frameRate, maxFrameRate, avgFrameRate, frameRateDeviation float64
+
+	smoothing                     SmoothingMode
+	smoothingFactor, avgFrameTime float64
 }
 
 // FrameRate returns the number of frames per second according to this Clock.
@@ -47,7 +71,8 @@ func (c *Clock) FrameRateDeviation() float64 {
 }
 
 // AvgFrameRate returns the average number of frames per second that have
-// occured over the last AvgSamples frames.
+// occured over the last AvgSamples frames, or the exponential moving average
+// of it if the ExponentialAverage smoothing mode is used (see SetSmoothing).
 func (c *Clock) AvgFrameRate() float64 {
 	c.access.RLock()
 	defer c.access.RUnlock()
@@ -55,6 +80,34 @@ func (c *Clock) AvgFrameRate() float64 {
 	return c.avgFrameRate
 }
 
+// SetSmoothing specifies the algorithm used to smooth frame times when
+// determining the average frame rate (see AvgFrameRate), and the smoothing
+// factor used by the ExponentialAverage mode (ignored by other modes). The
+// average is restarted from the next frame.
+//
+// If mode is ExponentialAverage and factor is not in the range (0, 1], a
+// panic occurs.
+func (c *Clock) SetSmoothing(mode SmoothingMode, factor float64) {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	if mode == ExponentialAverage && (factor <= 0 || factor > 1) {
+		panic("Clock.SetSmoothing(): Smoothing factor must be in the range (0, 1]!")
+	}
+	c.smoothing = mode
+	c.smoothingFactor = factor
+	c.avgFrameTime = 0
+}
+
+// Smoothing returns the smoothing mode and factor of this Clock, as they were
+// set previously by a call to the SetSmoothing method.
+func (c *Clock) Smoothing() (mode SmoothingMode, factor float64) {
+	c.access.RLock()
+	defer c.access.RUnlock()
+
+	return c.smoothing, c.smoothingFactor
+}
+
 // SetAvgSamples specifies the number of previous frames to sample each frame
 // to determine the average frame rate.
 //
@@ -196,6 +249,47 @@ func (c *Clock) Dt() float64 {
 	return float64(c.Delta()) / float64(time.Second)
 }
 
+// Step advances a fixed-timestep accumulator by the current Delta, and returns
+// the number of fixed steps of the given duration that should be simulated
+// this frame along with an interpolation factor alpha in the range [0, 1)
+// describing how far the leftover time is into the next step. For example:
+//
+//  c.Tick()
+//  steps, alpha := c.Step(time.Second / 60)
+//  for i := 0; i < steps; i++ {
+//      update(1.0 / 60.0)
+//  }
+//  render(alpha) // Interpolate between the previous and current state.
+//
+// This decouples the update rate of the simulation from the frame rate, which
+// keeps physics deterministic. Step should be called exactly once per frame,
+// after Tick. Setting a MaxDelta bounds the number of steps after a stall.
+//
+// If fixedDelta is less than or equal to zero, a panic occurs.
+func (c *Clock) Step(fixedDelta time.Duration) (steps int, alpha float64) {
+	if fixedDelta <= 0 {
+		panic("Clock.Step(): Fixed delta must be greater than zero!")
+	}
+	delta := c.Delta()
+
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	c.stepAccum += delta
+	steps = int(c.stepAccum / fixedDelta)
+	c.stepAccum -= time.Duration(steps) * fixedDelta
+	alpha = float64(c.stepAccum) / float64(fixedDelta)
+	return
+}
+
+// ResetStep resets the fixed-timestep accumulator used by the Step method, for
+// instance after loading a level such that no time is carried over.
+func (c *Clock) ResetStep() {
+	c.access.Lock()
+	defer c.access.Unlock()
+	c.stepAccum = 0
+}
+
 // LastFrame returns the time at which the last frame began, in time since the
 // program started.
 func (c *Clock) LastFrame() time.Duration {
@@ -258,26 +352,46 @@ func (c *Clock) Tick() {
 		}
 	}
 
+	c.addSample(c.delta.Seconds())
+
+	c.lastFrameTime = frameStartTime
+
+	if !firstFrame {
+		c.frameCount++
+	}
+}
+
+// addSample adds the given frame time, in seconds, to the average samples and
+// recalculates the average frame rate and it's deviation.
+func (c *Clock) addSample(frameTime float64) {
 	// Update the average samples
 	for i, sample := range c.avgSamples {
 		if i-1 >= 0 {
 			c.avgSamples[i-1] = sample
 		}
 	}
-	c.avgSamples[len(c.avgSamples)-1] = c.delta.Seconds()
+	c.avgSamples[len(c.avgSamples)-1] = frameTime
 
-	// Calculate the average frame rate.
-	c.avgFrameRate = 0
+	// Calculate the average frame time.
+	avgFrameRateDelta := 0.0
 	for _, sample := range c.avgSamples {
-		c.avgFrameRate += sample
+		avgFrameRateDelta += sample
+	}
+	avgFrameRateDelta /= float64(len(c.avgSamples))
+
+	// Convert to frames per second, using the chosen smoothing mode.
+	switch c.smoothing {
+	case ExponentialAverage:
+		if c.avgFrameTime == 0 {
+			// First sample, start the average from it.
+			c.avgFrameTime = frameTime
+		} else {
+			c.avgFrameTime += c.smoothingFactor * (frameTime - c.avgFrameTime)
+		}
+		c.avgFrameRate = 1.0 / c.avgFrameTime
+	default:
+		c.avgFrameRate = 1.0 / avgFrameRateDelta
 	}
-	c.avgFrameRate /= float64(len(c.avgSamples))
-
-	// Store for calculation deviation further down
-	avgFrameRateDelta := c.avgFrameRate
-
-	// Convert to frames per second
-	c.avgFrameRate = 1.0 / c.avgFrameRate
 
 	// Calculate the standard deviation of frame times
 	variance := 0.0
@@ -288,12 +402,24 @@ func (c *Clock) Tick() {
 		}
 	}
 	c.frameRateDeviation = math.Sqrt(variance / float64(len(c.avgSamples)))
+}
 
-	c.lastFrameTime = frameStartTime
-
-	if !firstFrame {
-		c.frameCount++
-	}
+// Now returns the current time of the high resolution timer used by clocks,
+// as the duration of time since the program started. It shares the timebase
+// of clocks (e.g. that of the LastFrame method), so it may be used to profile
+// code relative to the frames of a clock.
+//
+// The returned time is monotonic: it never decreases between calls, and it is
+// not affected by changes to the system's wall clock. It is never less than
+// 100 microseconds.
+//
+// On Windows the timer is QueryPerformanceCounter (validated against the less
+// precise standard time package), elsewhere it is the monotonic clock of the
+// standard time package.
+//
+// Now is safe to call from multiple goroutines concurrently.
+func Now() time.Duration {
+	return getTime()
 }
 
 // Time returns the duration of time that has passed since this clock started
@@ -313,8 +439,9 @@ func (c *Clock) Reset() {
 }
 
 // New initializes and returns a new Clock. The returned clock has it's start
-// time set to the current time, has it's maximum frame rate set to 75, and
-// it's number of average frame rate samples set to 120.
+// time set to the current time, has it's maximum frame rate set to 75, it's
+// number of average frame rate samples set to 120, and uses the SimpleAverage
+// smoothing mode.
 //
 // A maximum frame rate of 75 is a good choice because it is slightly above the
 // refresh rate of most screens, and not all hardware supports high resolution
@@ -32,6 +32,20 @@ func TestHighResolutionTime(t *testing.T) {
 	}
 }
 
+func TestNow(t *testing.T) {
+	last := Now()
+	for i := 0; i < 1000; i++ {
+		now := Now()
+		if now < last {
+			t.Fatalf("%d. Now()=%d went backwards from %d", i, now, last)
+		}
+		last = now
+	}
+	if last < minDelta {
+		t.Fatal("expected Now() >= minDelta, got", last)
+	}
+}
+
 func TestFrameRateLimit(t *testing.T) {
 	c := New()
 	c.SetMaxFrameRate(100)
@@ -72,3 +86,53 @@ func TestFrameRateStall(t *testing.T) {
 		}
 	}
 }
+
+func TestStep(t *testing.T) {
+	c := New()
+	step := 10 * time.Millisecond
+	c.SetFixedDelta(25 * time.Millisecond)
+
+	steps, alpha := c.Step(step)
+	if steps != 2 || !lmath.AlmostEqual(alpha, 0.5, 1e-9) {
+		t.Fatal("got", steps, alpha, "expected 2 0.5")
+	}
+
+	// The leftover 5ms carries over to the next frame.
+	steps, alpha = c.Step(step)
+	if steps != 3 || alpha != 0 {
+		t.Fatal("got", steps, alpha, "expected 3 0")
+	}
+
+	c.SetFixedDelta(5 * time.Millisecond)
+	steps, _ = c.Step(step)
+	if steps != 0 {
+		t.Fatal("got", steps, "expected 0")
+	}
+	c.ResetStep()
+	steps, alpha = c.Step(step)
+	if steps != 0 || !lmath.AlmostEqual(alpha, 0.5, 1e-9) {
+		t.Fatal("got", steps, alpha, "expected 0 0.5")
+	}
+}
+
+func TestSmoothing(t *testing.T) {
+	c := New()
+	c.SetAvgSamples(4)
+	for i := 0; i < 4; i++ {
+		c.addSample(0.1)
+	}
+	c.addSample(0.5)
+	if avg := c.AvgFrameRate(); !lmath.AlmostEqual(avg, 1/0.2, 1e-9) {
+		t.Fatal("got simple average", avg, "expected", 1/0.2)
+	}
+
+	c.SetSmoothing(ExponentialAverage, 0.5)
+	if mode, factor := c.Smoothing(); mode != ExponentialAverage || factor != 0.5 {
+		t.Fatal("got", mode, factor, "expected ExponentialAverage 0.5")
+	}
+	c.addSample(0.1)
+	c.addSample(0.3)
+	if avg := c.AvgFrameRate(); !lmath.AlmostEqual(avg, 1/0.2, 1e-9) {
+		t.Fatal("got exponential average", avg, "expected", 1/0.2)
+	}
+}
@@ -9,4 +9,7 @@
 //
 // When using a maximum frame rate, Tick blocks just long enough to ensure that
 // the application is at max running at MaxFrameRate.
+//
+// The high resolution timer used by clocks is also available directly via the
+// Now function, e.g. for profiling code using the same timebase.
 package clock
@@ -5,6 +5,7 @@
 package clock
 
 import (
+	"sync"
 	"syscall"
 	"time"
 	"unsafe"
@@ -63,6 +64,10 @@ var (
 	doFallback     bool
 	lastQueryValue time.Duration
 	programStart   = time.Now()
+
+	// getTimeLock guards doFallback and lastQueryValue, as getTime may be
+	// called from multiple goroutines concurrently.
+	getTimeLock sync.Mutex
 )
 
 func highResTimeFallback() time.Duration {
@@ -94,6 +99,9 @@ func init() {
 // getTime returns the number of milliseconds that have elapsed since the
 // program started
 func getTime() time.Duration {
+	getTimeLock.Lock()
+	defer getTimeLock.Unlock()
+
 	if doFallback {
 		return highResTimeFallback()
 	}
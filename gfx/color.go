@@ -42,3 +42,126 @@ func colorModel(c color.Color) color.Color {
 // ColorModel represents the graphics color model (i.e. normalized 32-bit
 // floating point values RGBA color).
 var ColorModel = color.ModelFunc(colorModel)
+
+// FromRGBA8 converts the given 8-bit alpha-premultiplied color into a
+// normalized Color. Just like a conversion using ColorModel, the components
+// are scaled to the normalized range but remain alpha-premultiplied (i.e. the
+// result must not be premultiplied again). The inverse is ToRGBA8.
+//
+// To convert a non-alpha-premultiplied color, use FromNRGBA instead.
+func FromRGBA8(c color.RGBA) Color {
+	return Color{
+		R: float32(c.R) / math.MaxUint8,
+		G: float32(c.G) / math.MaxUint8,
+		B: float32(c.B) / math.MaxUint8,
+		A: float32(c.A) / math.MaxUint8,
+	}
+}
+
+// FromNRGBA converts the given 8-bit non-alpha-premultiplied color into a
+// normalized, non-alpha-premultiplied, Color. The inverse is ToNRGBA.
+func FromNRGBA(c color.NRGBA) Color {
+	return Color{
+		R: float32(c.R) / math.MaxUint8,
+		G: float32(c.G) / math.MaxUint8,
+		B: float32(c.B) / math.MaxUint8,
+		A: float32(c.A) / math.MaxUint8,
+	}
+}
+
+// clampUint8 clamps v to the range of 0.0 to 1.0 and converts it to the range
+// of 0 to 255.
+func clampUint8(v float32) uint8 {
+	if v <= 0 {
+		return 0
+	}
+	if v >= 1 {
+		return math.MaxUint8
+	}
+	return uint8(v*math.MaxUint8 + 0.5)
+}
+
+// ToNRGBA converts this non-alpha-premultiplied color (e.g. from FromNRGBA)
+// into a 8-bit non-alpha-premultiplied color. Values outside of the normalized
+// range are clamped.
+func (c Color) ToNRGBA() color.NRGBA {
+	return color.NRGBA{
+		R: clampUint8(c.R),
+		G: clampUint8(c.G),
+		B: clampUint8(c.B),
+		A: clampUint8(c.A),
+	}
+}
+
+// ToRGBA8 converts this alpha-premultiplied color (e.g. from FromRGBA8 or
+// Premultiply) into a 8-bit alpha-premultiplied color. Values outside of the
+// normalized range are clamped.
+func (c Color) ToRGBA8() color.RGBA {
+	return color.RGBA{
+		R: clampUint8(c.R),
+		G: clampUint8(c.G),
+		B: clampUint8(c.B),
+		A: clampUint8(c.A),
+	}
+}
+
+// Lerp returns the linear interpolation between the colors c and b. The t
+// parameter is the amount to interpolate (0.0 - 1.0) between the colors.
+func (c Color) Lerp(b Color, t float32) Color {
+	return Color{
+		R: c.R + (b.R-c.R)*t,
+		G: c.G + (b.G-c.G)*t,
+		B: c.B + (b.B-c.B)*t,
+		A: c.A + (b.A-c.A)*t,
+	}
+}
+
+// Premultiply returns this non-alpha-premultiplied color (e.g. from FromNRGBA)
+// with it's RGB components multiplied by it's alpha component. Colors from
+// FromRGBA8 are already alpha-premultiplied.
+func (c Color) Premultiply() Color {
+	return Color{
+		R: c.R * c.A,
+		G: c.G * c.A,
+		B: c.B * c.A,
+		A: c.A,
+	}
+}
+
+// srgbToLinear converts a single sRGB color component to linear space.
+func srgbToLinear(v float32) float32 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return float32(math.Pow((float64(v)+0.055)/1.055, 2.4))
+}
+
+// linearToSRGB converts a single linear color component to sRGB space.
+func linearToSRGB(v float32) float32 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return float32(1.055*math.Pow(float64(v), 1/2.4) - 0.055)
+}
+
+// SRGBToLinear converts this color from the sRGB color space into linear
+// space. The alpha component is left unchanged.
+func (c Color) SRGBToLinear() Color {
+	return Color{
+		R: srgbToLinear(c.R),
+		G: srgbToLinear(c.G),
+		B: srgbToLinear(c.B),
+		A: c.A,
+	}
+}
+
+// LinearToSRGB converts this color from linear space into the sRGB color
+// space. The alpha component is left unchanged.
+func (c Color) LinearToSRGB() Color {
+	return Color{
+		R: linearToSRGB(c.R),
+		G: linearToSRGB(c.G),
+		B: linearToSRGB(c.B),
+		A: c.A,
+	}
+}
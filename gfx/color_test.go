@@ -0,0 +1,170 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+// colorNear reports whether a and b are equal within a small epsilon.
+func colorNear(a, b Color) bool {
+	const eps = 1e-4
+	near := func(x, y float32) bool {
+		return math.Abs(float64(x-y)) < eps
+	}
+	return near(a.R, b.R) && near(a.G, b.G) && near(a.B, b.B) && near(a.A, b.A)
+}
+
+var fromRGBA8Tests = []struct {
+	c    color.RGBA
+	want Color
+}{
+	{color.RGBA{0, 0, 0, 0}, Color{0, 0, 0, 0}},
+	{color.RGBA{255, 255, 255, 255}, Color{1, 1, 1, 1}},
+	{color.RGBA{255, 0, 0, 255}, Color{1, 0, 0, 1}},
+	{color.RGBA{51, 102, 0, 102}, Color{0.2, 0.4, 0, 0.4}},
+}
+
+func TestColorFromRGBA8(t *testing.T) {
+	for _, tst := range fromRGBA8Tests {
+		got := FromRGBA8(tst.c)
+		if !colorNear(got, tst.want) {
+			t.Errorf("FromRGBA8(%v): got %v, want %v", tst.c, got, tst.want)
+		}
+	}
+}
+
+func TestColorFromRGBA8RoundTrip(t *testing.T) {
+	for _, c := range []color.RGBA{
+		{0, 0, 0, 0},
+		{255, 255, 255, 255},
+		{51, 102, 0, 102},
+		{10, 20, 30, 40},
+		{128, 64, 32, 128},
+	} {
+		got := FromRGBA8(c)
+
+		// It must agree with the package's color model.
+		if want := ColorModel.Convert(c).(Color); !colorNear(got, want) {
+			t.Errorf("FromRGBA8(%v): got %v, ColorModel gives %v", c, got, want)
+		}
+
+		// And convert back to the same 8-bit color.
+		if back := color.RGBAModel.Convert(got).(color.RGBA); back != c {
+			t.Errorf("FromRGBA8(%v): converts back to %v", c, back)
+		}
+	}
+}
+
+var toNRGBATests = []struct {
+	c    Color
+	want color.NRGBA
+}{
+	{Color{0, 0, 0, 0}, color.NRGBA{0, 0, 0, 0}},
+	{Color{1, 1, 1, 1}, color.NRGBA{255, 255, 255, 255}},
+	{Color{0.5, 0.25, 0, 1}, color.NRGBA{128, 64, 0, 255}},
+	{Color{-1, 2, 0.5, 0.5}, color.NRGBA{0, 255, 128, 128}},
+}
+
+func TestColorToNRGBA(t *testing.T) {
+	for _, tst := range toNRGBATests {
+		got := tst.c.ToNRGBA()
+		if got != tst.want {
+			t.Errorf("%v.ToNRGBA(): got %v, want %v", tst.c, got, tst.want)
+		}
+	}
+}
+
+var nrgbaRoundTripTests = []color.NRGBA{
+	{0, 0, 0, 0},
+	{255, 255, 255, 255},
+	{255, 0, 0, 128},
+	{10, 20, 30, 40},
+	{128, 64, 32, 1},
+	{200, 100, 50, 254},
+}
+
+func TestColorNRGBARoundTrip(t *testing.T) {
+	for _, c := range nrgbaRoundTripTests {
+		got := FromNRGBA(c)
+		if back := got.ToNRGBA(); back != c {
+			t.Errorf("FromNRGBA(%v).ToNRGBA(): got %v", c, back)
+		}
+
+		// Premultiplying must agree with the standard library's conversion
+		// into an alpha-premultiplied color, which truncates instead of
+		// rounding.
+		want := color.RGBAModel.Convert(c).(color.RGBA)
+		pre := got.Premultiply().ToRGBA8()
+		near := func(x, y uint8) bool { return x-y <= 1 }
+		if !near(pre.R, want.R) || !near(pre.G, want.G) || !near(pre.B, want.B) || pre.A != want.A {
+			t.Errorf("FromNRGBA(%v).Premultiply().ToRGBA8(): got %v, want %v", c, pre, want)
+		}
+		if back := FromRGBA8(want).ToRGBA8(); back != want {
+			t.Errorf("FromRGBA8(%v).ToRGBA8(): got %v", want, back)
+		}
+	}
+}
+
+var lerpTests = []struct {
+	a, b Color
+	t    float32
+	want Color
+}{
+	{Color{0, 0, 0, 0}, Color{1, 1, 1, 1}, 0, Color{0, 0, 0, 0}},
+	{Color{0, 0, 0, 0}, Color{1, 1, 1, 1}, 1, Color{1, 1, 1, 1}},
+	{Color{0, 0, 0, 0}, Color{1, 1, 1, 1}, 0.5, Color{0.5, 0.5, 0.5, 0.5}},
+	{Color{1, 0, 0.5, 1}, Color{0, 1, 0.5, 0}, 0.25, Color{0.75, 0.25, 0.5, 0.75}},
+}
+
+func TestColorLerp(t *testing.T) {
+	for _, tst := range lerpTests {
+		got := tst.a.Lerp(tst.b, tst.t)
+		if !colorNear(got, tst.want) {
+			t.Errorf("%v.Lerp(%v, %v): got %v, want %v", tst.a, tst.b, tst.t, got, tst.want)
+		}
+	}
+}
+
+var premultiplyTests = []struct {
+	c, want Color
+}{
+	{Color{1, 1, 1, 1}, Color{1, 1, 1, 1}},
+	{Color{1, 0.5, 0, 0.5}, Color{0.5, 0.25, 0, 0.5}},
+	{Color{1, 1, 1, 0}, Color{0, 0, 0, 0}},
+}
+
+func TestColorPremultiply(t *testing.T) {
+	for _, tst := range premultiplyTests {
+		got := tst.c.Premultiply()
+		if !colorNear(got, tst.want) {
+			t.Errorf("%v.Premultiply(): got %v, want %v", tst.c, got, tst.want)
+		}
+	}
+}
+
+var srgbTests = []struct {
+	srgb, linear Color
+}{
+	{Color{0, 0, 0, 1}, Color{0, 0, 0, 1}},
+	{Color{1, 1, 1, 0.5}, Color{1, 1, 1, 0.5}},
+	{Color{0.5, 0.5, 0.5, 1}, Color{0.214041, 0.214041, 0.214041, 1}},
+	{Color{0.04045, 0.02, 0.8, 1}, Color{0.003131, 0.001548, 0.603827, 1}},
+}
+
+func TestColorSRGB(t *testing.T) {
+	for _, tst := range srgbTests {
+		linear := tst.srgb.SRGBToLinear()
+		if !colorNear(linear, tst.linear) {
+			t.Errorf("%v.SRGBToLinear(): got %v, want %v", tst.srgb, linear, tst.linear)
+		}
+		srgb := tst.linear.LinearToSRGB()
+		if !colorNear(srgb, tst.srgb) {
+			t.Errorf("%v.LinearToSRGB(): got %v, want %v", tst.linear, srgb, tst.srgb)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import (
+	"image"
+	"sync"
+)
+
+// CommandBuffer is a canvas which records the clear, draw, blit, and render
+// operations submitted to it instead of performing them, such that they may
+// later be replayed (any number of times) onto another canvas via Submit.
+//
+// This allows lists of draw operations to be built ahead of time and reused,
+// or to be built in parallel by worker goroutines (each with their own command
+// buffer) and then submitted in order by the goroutine rendering frames:
+//
+//	cb := gfx.NewCommandBuffer(d)
+//	go func() {
+//	    cb.Clear(cb.Bounds(), gfx.Color{1, 1, 1, 1})
+//	    cb.Draw(cb.Bounds(), obj, camera)
+//	    ready <- cb
+//	}()
+//	...
+//	(<-ready).Submit(d)
+//	d.Render()
+//
+// Any other canvas methods (e.g. Bounds, SetMSAA, QueryWait) are not recorded,
+// and are instead forwarded directly to the canvas the command buffer was
+// created for.
+//
+// As with any canvas, ownership of the objects given to Draw belongs to the
+// canvas: they must not be accessed until the canvas the command buffer is
+// submitted to returns ownership of them (i.e. after it's Render method).
+type CommandBuffer struct {
+	// The canvas that the command buffer was created for, to which non-recorded
+	// methods are forwarded.
+	Canvas
+
+	access sync.Mutex
+	ops    []func(c Canvas) error
+}
+
+// record records the given operation.
+func (b *CommandBuffer) record(op func(c Canvas) error) {
+	b.access.Lock()
+	b.ops = append(b.ops, op)
+	b.access.Unlock()
+}
+
+// Clear implements the Canvas interface, by recording the operation.
+func (b *CommandBuffer) Clear(r image.Rectangle, bg Color) {
+	b.record(func(c Canvas) error {
+		c.Clear(r, bg)
+		return nil
+	})
+}
+
+// ClearDepth implements the Canvas interface, by recording the operation.
+func (b *CommandBuffer) ClearDepth(r image.Rectangle, depth float64) {
+	b.record(func(c Canvas) error {
+		c.ClearDepth(r, depth)
+		return nil
+	})
+}
+
+// ClearStencil implements the Canvas interface, by recording the operation.
+func (b *CommandBuffer) ClearStencil(r image.Rectangle, stencil int) {
+	b.record(func(c Canvas) error {
+		c.ClearStencil(r, stencil)
+		return nil
+	})
+}
+
+// ClearRects implements the Canvas interface, by recording the operation. The
+// rects slice is copied, so it may be reused once this method returns.
+func (b *CommandBuffer) ClearRects(rects []ClearRect) {
+	cpy := make([]ClearRect, len(rects))
+	copy(cpy, rects)
+	b.record(func(c Canvas) error {
+		c.ClearRects(cpy)
+		return nil
+	})
+}
+
+// Draw implements the Canvas interface, by recording the operation.
+func (b *CommandBuffer) Draw(r image.Rectangle, o *Object, cam Camera) {
+	b.record(func(c Canvas) error {
+		c.Draw(r, o, cam)
+		return nil
+	})
+}
+
+// Blit implements the Canvas interface, by recording the operation. It always
+// returns nil, as the operation is only performed once the command buffer is
+// submitted (see Submit).
+//
+// If dst is the command buffer itself, then the operation blits into the
+// canvas that the command buffer is submitted to.
+func (b *CommandBuffer) Blit(dst Canvas, src, dstRect image.Rectangle, filter TexFilter) error {
+	b.record(func(c Canvas) error {
+		to := dst
+		if to == Canvas(b) {
+			to = c
+		}
+		return c.Blit(to, src, dstRect, filter)
+	})
+	return nil
+}
+
+// Render implements the Canvas interface, by recording the operation.
+func (b *CommandBuffer) Render() {
+	b.record(func(c Canvas) error {
+		c.Render()
+		return nil
+	})
+}
+
+// Len returns the number of operations recorded by the command buffer.
+func (b *CommandBuffer) Len() int {
+	b.access.Lock()
+	defer b.access.Unlock()
+	return len(b.ops)
+}
+
+// Reset removes all of the operations recorded by the command buffer, such
+// that it may be reused to record new ones.
+func (b *CommandBuffer) Reset() {
+	b.access.Lock()
+	for i := range b.ops {
+		b.ops[i] = nil
+	}
+	b.ops = b.ops[:0]
+	b.access.Unlock()
+}
+
+// Submit replays each operation recorded by the command buffer, in the order
+// they were recorded, onto the given canvas (which is typically the one the
+// command buffer was created for). The recorded operations are kept, such that
+// the command buffer may be submitted again (see Reset).
+//
+// Replaying continues even if an operation fails, the first error (i.e. from
+// a recorded Blit operation) is returned.
+func (b *CommandBuffer) Submit(c Canvas) error {
+	b.access.Lock()
+	defer b.access.Unlock()
+	var firstErr error
+	for _, op := range b.ops {
+		if err := op(c); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// NewCommandBuffer returns a new, empty, command buffer for the given canvas.
+// The canvas is only used for non-recorded methods (e.g. Bounds), see the
+// CommandBuffer type for details.
+func NewCommandBuffer(c Canvas) *CommandBuffer {
+	return &CommandBuffer{Canvas: c}
+}
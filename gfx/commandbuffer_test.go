@@ -0,0 +1,89 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"testing"
+)
+
+// opCanvas is a canvas which records the name of each operation performed on
+// it.
+type opCanvas struct {
+	Canvas
+	ops []string
+}
+
+func (c *opCanvas) Clear(r image.Rectangle, bg Color) {
+	c.ops = append(c.ops, fmt.Sprint("Clear ", bg))
+}
+
+func (c *opCanvas) ClearRects(rects []ClearRect) {
+	c.ops = append(c.ops, fmt.Sprint("ClearRects ", len(rects)))
+}
+
+func (c *opCanvas) Draw(r image.Rectangle, o *Object, cam Camera) {
+	c.ops = append(c.ops, "Draw")
+}
+
+func (c *opCanvas) Blit(dst Canvas, src, dstRect image.Rectangle, filter TexFilter) error {
+	if dst != Canvas(c) {
+		return errors.New("bad dst")
+	}
+	c.ops = append(c.ops, "Blit")
+	return nil
+}
+
+func (c *opCanvas) Render() {
+	c.ops = append(c.ops, "Render")
+}
+
+func TestCommandBuffer(t *testing.T) {
+	d := Nil()
+	b := NewCommandBuffer(d)
+	if b.Bounds() != d.Bounds() {
+		t.Fatal("Bounds was not forwarded")
+	}
+
+	b.Clear(b.Bounds(), Color{1, 0, 0, 1})
+	b.ClearRects([]ClearRect{{ClearColor: true}, {ClearDepth: true}})
+	b.Draw(b.Bounds(), NewObject(), nil)
+	if err := b.Blit(b, b.Bounds(), b.Bounds(), Nearest); err != nil {
+		t.Fatal(err)
+	}
+	b.Render()
+	if b.Len() != 5 {
+		t.Fatalf("got %d operations, want 5", b.Len())
+	}
+
+	want := "[Clear {1 0 0 1} ClearRects 2 Draw Blit Render]"
+	for i := 0; i < 2; i++ {
+		c := &opCanvas{Canvas: d}
+		if err := b.Submit(c); err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprint(c.ops); got != want {
+			t.Fatalf("Submit %d: got %s, want %s", i, got, want)
+		}
+	}
+
+	b.Reset()
+	c := &opCanvas{Canvas: d}
+	if err := b.Submit(c); err != nil || len(c.ops) != 0 {
+		t.Fatal("expected no operations after Reset")
+	}
+
+	// Errors from Blit are returned by Submit.
+	b.Blit(d, b.Bounds(), b.Bounds(), Nearest)
+	b.Render()
+	if err := b.Submit(c); err == nil {
+		t.Fatal("expected an error from Submit")
+	}
+	if len(c.ops) != 1 {
+		t.Fatal("expected operations to be replayed after an error")
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+// DepthBias represents a bias (sometimes called polygon offset) added to the
+// depth of each fragment of an object before depth testing, which is useful
+// for e.g. avoiding self-shadowing artifacts ("shadow acne") when rendering
+// shadow maps, or drawing decals on top of coplanar surfaces without
+// z-fighting.
+//
+// The bias added to the depth of each fragment is:
+//
+//	Slope * m + Constant * r
+//
+// where m is the maximum depth slope of the polygon, and r is the smallest
+// value that is guaranteed to produce a resolvable difference in the depth
+// buffer. Positive values push fragments away from the camera, negative ones
+// pull them towards it.
+//
+// It only applies to filled polygons, not to lines or points. The zero value
+// applies no bias.
+type DepthBias struct {
+	Slope, Constant float32
+}
+
+// Enabled tells if this bias is not zero, i.e. if it has any effect.
+func (d DepthBias) Enabled() bool {
+	return d.Slope != 0 || d.Constant != 0
+}
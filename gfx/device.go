@@ -64,6 +64,31 @@ type Canvas interface {
 	// MSAA returns the last value passed into SetMSAA on this canvas.
 	MSAA() bool
 
+	// SetAutoClear sets whether or not this canvas should automatically clear
+	// it's color and depth buffers (to the values given to SetClearColor)
+	// each frame. By default auto-clearing is disabled, and the canvas must be
+	// cleared manually.
+	//
+	// The clear happens just before the first clear, draw, or blit (into this
+	// canvas) operation of each frame. Device canvases (e.g. a window) are
+	// also cleared upon Render if nothing was drawn, while render-to-texture
+	// canvases are only ever cleared when drawing to them begins.
+	SetAutoClear(enabled bool)
+
+	// AutoClear returns the last value passed into SetAutoClear on this
+	// canvas.
+	AutoClear() bool
+
+	// SetClearColor sets the color and depth value (in the range of 0.0 to
+	// 1.0, where 1.0 is furthest away) that this canvas is cleared to when
+	// auto-clearing is enabled. By default the color is transparent black and
+	// the depth is 1.0.
+	SetClearColor(bg Color, depth float64)
+
+	// ClearColor returns the last values passed into SetClearColor on this
+	// canvas.
+	ClearColor() (bg Color, depth float64)
+
 	// Precision should return the precision of the canvas's color, depth, and
 	// stencil buffers.
 	Precision() Precision
@@ -97,6 +122,14 @@ type Canvas interface {
 	// If the rectangle is empty this function is no-op.
 	ClearStencil(r image.Rectangle, stencil int)
 
+	// ClearRects submits a batch of clear operations to the canvas, which is
+	// more efficient than many separate calls to Clear, ClearDepth, and
+	// ClearStencil (e.g. when clearing the viewports of a multi-viewport
+	// layout). The operations are performed in order.
+	//
+	// Clear operations with an empty rectangle are no-op.
+	ClearRects(rects []ClearRect)
+
 	// Draw submits a draw operation to the canvas. It will draw the given
 	// graphics object onto the specified rectangle of the canvas.
 	//
@@ -129,11 +162,30 @@ type Canvas interface {
 	//  len(o.Shader.Error) > 0
 	//  len(o.Meshes) == 0
 	//  !o.Meshes[N].Loaded && len(o.Meshes[N].Vertices) == 0
-	//  !o.Textures[n].Loaded && o.Textures[N].Source == nil
+	//  !o.Textures[N].Loaded && !o.Textures[N].HasData()
 	//
-	// If the rectangle is empty this function is no-op.
+	// If the rectangle is empty, or if o.Hidden is true, this function is
+	// no-op.
 	Draw(r image.Rectangle, o *Object, c Camera)
 
+	// Blit submits a blit operation to the canvas. It copies the src rectangle
+	// of this canvas's color buffer into the dstRect rectangle of the dst
+	// canvas's color buffer, without drawing any geometry. This is useful for
+	// e.g. post-processing chains that copy one render-to-texture canvas into
+	// another (or into the device itself).
+	//
+	// If the rectangles differ in size the image is stretched using the given
+	// filter, which must be either Nearest or Linear. If this canvas is
+	// multisampled then the samples are resolved as part of the copy.
+	//
+	// The dst canvas must be this canvas, the device this canvas was created
+	// by, or another canvas created by the same device. An error is returned
+	// if the blit cannot be performed, for instance if the device does not
+	// support it.
+	//
+	// If either rectangle is empty this function is no-op.
+	Blit(dst Canvas, src, dstRect image.Rectangle, filter TexFilter) error
+
 	// QueryWait blocks until all pending draw object's occlusion queries
 	// completely finish. Most clients should avoid this call as it can easilly
 	// cause graphics pipeline stalls if not handled with care.
@@ -147,6 +199,17 @@ type Canvas interface {
 	// OcclusionQuery field) then this function is no-op.
 	QueryWait()
 
+	// QueryPoll performs a single non-blocking check for the results of all
+	// pending draw object's occlusion queries, records the results of the ones
+	// that have finished, and returns the number of queries still pending.
+	//
+	// Unlike QueryWait it never stalls the graphics pipeline, so it may be used
+	// to opportunistically gather occlusion query results across frames.
+	//
+	// If the GPU does not support occlusion queries (see DeviceInfo's
+	// OcclusionQuery field) then this function is no-op and returns zero.
+	QueryPoll() (pending int)
+
 	// Render should finalize all pending clear and draw operations as if they
 	// where all submitted over a single channel like so:
 	//
@@ -179,6 +242,35 @@ type DeviceInfo struct {
 	// images for use with the device, or -1 if not available.
 	MaxTextureSize int
 
+	// MaxCubeMapSize is the maximum size of either X or Y dimension of cube
+	// map face images for use with the device, or -1 if not available.
+	MaxCubeMapSize int
+
+	// Whether or not the device supports seamless filtering of cube maps, that
+	// is filtering across the edges of cube map faces (instead of within just
+	// one face), which avoids visible seams at the edges of the faces. Devices
+	// may require it to be enabled explicitly, see e.g. the gl2 package.
+	SeamlessCubeMaps bool
+
+	// MaxVertexAttribs is the maximum number of vertex attributes (i.e. the
+	// number of mesh attributes plus vertices, colors, etc) that a single
+	// shader may make use of, or -1 if not available.
+	MaxVertexAttribs int
+
+	// MaxTextureImageUnits is the maximum number of textures that the fragment
+	// shader may access at once (i.e. the maximum length of Object.Textures),
+	// or -1 if not available.
+	MaxTextureImageUnits int
+
+	// MaxCombinedTextureImageUnits is the maximum number of textures that all
+	// shader stages combined may access at once, or -1 if not available.
+	MaxCombinedTextureImageUnits int
+
+	// Whether or not the device supports 32-bit mesh indices (see the
+	// IndexType type). If false, meshes with an index larger than 65535
+	// cannot be drawn.
+	Uint32Indices bool
+
 	// Whether or not the AlphaToCoverage alpha mode is supported (if false
 	// then BinaryAlpha will automatically be used as a fallback).
 	AlphaToCoverage bool
@@ -236,6 +328,38 @@ type DeviceInfo struct {
 	//
 	// (Desktop) OpenGL 2 always supports BorderColor.
 	TexWrapBorderColor bool
+
+	// Whether or not the device supports geometry shaders (i.e. the Geometry
+	// field of GLSLSources). If false, loading a shader with geometry shader
+	// source code will fail with an error.
+	GeometryShaders bool
+
+	// Whether or not the device supports uniform blocks (see the UniformBlock
+	// type), and the maximum number of binding points available to them.
+	UniformBlocks           bool
+	MaxUniformBlockBindings int
+
+	// Whether or not the device supports GPU timer queries, which measure the
+	// time the graphics hardware takes to execute operations.
+	TimerQuery bool
+
+	// Whether or not the device supports loading shaders from precompiled
+	// SPIR-V binaries (see the SPIRV field of Shader). If false, the GLSL
+	// sources of the shader are used instead.
+	SPIRV bool
+
+	// Whether or not the device draws all of the ranges of a mesh (see the
+	// Ranges field of Mesh) with a single draw call. If false, each range is
+	// drawn with a draw call of it's own.
+	MultiDraw bool
+
+	// Whether or not the device supports primitive restart (see the
+	// RestartIndex field of Mesh).
+	PrimitiveRestart bool
+
+	// Whether or not the device supports swizzling the components of textures
+	// when they are sampled (see the Swizzle field of Texture).
+	TextureSwizzle bool
 }
 
 // Device represents a graphics device and is capable of loading meshes,
@@ -257,6 +381,11 @@ type Device interface {
 	// Info should return information about the graphics hardware.
 	Info() DeviceInfo
 
+	// Stats should return the rendering statistics of the last frame rendered
+	// (i.e. up until the last call to Render). Devices that do not collect
+	// statistics return the zero value.
+	Stats() Stats
+
 	// LoadMesh should begin loading the specified mesh asynchronously.
 	//
 	// Additionally, the device will set m.Loaded to true, and then invoke
@@ -285,6 +414,24 @@ type Device interface {
 	// passes ownership back to you over the done channel.
 	LoadTexture(t *Texture, done chan *Texture)
 
+	// UpdateStream should begin uploading the source image (or raw pixel
+	// data) of the specified stream texture asynchronously. If the texture is
+	// already loaded and the image has the same dimensions as the last one,
+	// the existing texture is updated in-place; otherwise a new texture is
+	// allocated (just like LoadTexture).
+	//
+	// Additionally, the device will set t.Loaded to true, and then invoke
+	// t.ClearData(), thus allowing the source image to be garbage collected.
+	//
+	// When the texture is fully uploaded, it is sent to the done channel
+	// if != nil, and as long as sending would not block (i.e. ensure a buffer
+	// size of at least one).
+	//
+	// Upon calling this method, ownership of the texture is transferred to the
+	// device itself and you may no longer access it safely until the device
+	// passes ownership back to you over the done channel.
+	UpdateStream(t *StreamTexture, done chan *StreamTexture)
+
 	// LoadShader should begin loading the specified shader asynchronously.
 	//
 	// Additionally, if the shader was successfully loaded (no error log was
@@ -315,5 +462,19 @@ type Device interface {
 	// Any non-nil texture in the configuration will be set to loaded, will
 	// have ClearData() called on it, and will have it's bounds set to
 	// cfg.Bounds.
+	//
+	// If the configuration has a depth buffer but no color buffer, then
+	// downloading from the canvas (see Downloadable) downloads the depth
+	// buffer as an *image.Gray16 image, where white is furthest away (if
+	// supported by the device).
 	RenderToTexture(cfg RTTConfig) Canvas
+
+	// Fence inserts a fence into the stream of operations submitted to the
+	// device (i.e. after all of the operations submitted so far), and returns
+	// it. See the Fence type for details.
+	//
+	// If the device does not support fences natively, then waiting on the
+	// fence waits for all operations submitted to the device to complete
+	// (regardless of the timeout).
+	Fence() Fence
 }
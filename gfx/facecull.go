@@ -21,3 +21,18 @@ const (
 	// both sides will be drawn).
 	NoFaceCulling
 )
+
+// FrontFace represents the winding order of the vertices of a front-facing
+// triangle, as seen on screen. CounterClockwise is the default (zero value).
+type FrontFace uint8
+
+const (
+	// CounterClockwise means that triangles whose vertices appear in
+	// counter-clockwise order on screen are front-facing.
+	CounterClockwise FrontFace = iota
+
+	// Clockwise means that triangles whose vertices appear in clockwise order
+	// on screen are front-facing (e.g. for meshes exported with the opposite
+	// winding order, or drawn with a mirrored transform).
+	Clockwise
+)
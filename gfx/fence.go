@@ -0,0 +1,33 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import "time"
+
+// Fence represents a point in the stream of operations submitted to a device,
+// it becomes signaled once the graphics hardware has completed all of the
+// operations submitted before it (see Device.Fence).
+//
+// Fences allow CPU work to be coordinated with the graphics hardware precisely,
+// for instance to wait until a frame has been rendered before reading it back:
+//
+//	f := d.Fence()
+//	...
+//	if f.Wait(time.Second) {
+//	    // All operations before the fence have completed.
+//	}
+//
+// A fence and it's methods are safe for access from multiple goroutines
+// concurrently.
+type Fence interface {
+	// Wait blocks until the fence becomes signaled, or until the timeout
+	// elapses, and returns whether or not the fence is signaled. A timeout of
+	// zero checks whether the fence is signaled without waiting for the
+	// graphics hardware.
+	//
+	// Once a fence is signaled it remains signaled, and Wait always returns
+	// true.
+	Wait(timeout time.Duration) bool
+}
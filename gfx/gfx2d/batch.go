@@ -0,0 +1,302 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx2d
+
+import (
+	"image"
+	"math"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+var (
+	glslVert = []byte(`
+#version 120
+
+attribute vec3 Vertex;
+attribute vec4 Color;
+attribute vec2 TexCoord0;
+
+uniform mat4 MVP;
+
+varying vec4 tint;
+varying vec2 tc0;
+
+void main()
+{
+	tint = Color;
+	tc0 = TexCoord0;
+	gl_Position = MVP * vec4(Vertex, 1.0);
+}
+`)
+
+	glslFrag = []byte(`
+#version 120
+
+varying vec4 tint;
+varying vec2 tc0;
+
+uniform sampler2D Texture0;
+
+void main()
+{
+	gl_FragColor = texture2D(Texture0, tc0) * tint;
+}
+`)
+)
+
+// Shader is the default shader used by batches to draw sprites. It multiplies
+// the color of the texture by the tint of each sprite.
+//
+// A custom shader may be used instead (see the Batch.Shader field), it
+// receives the Vertex, Color (the tint) and TexCoord0 attributes, and the
+// sprite's texture as Texture0.
+var Shader = &gfx.Shader{
+	Name: "gfx2d.Shader",
+	GLSL: &gfx.GLSLSources{
+		Vertex:   glslVert,
+		Fragment: glslFrag,
+	},
+}
+
+// Sprite is a single textured quad drawn by a batch.
+type Sprite struct {
+	// The texture of the sprite, it must not be nil.
+	Texture *gfx.Texture
+
+	// The position of the center of the sprite, and it's width and height, in
+	// world units (pixels, when drawn with an orthographic camera). The Y
+	// component of the position is world-space Z (i.e. up).
+	Pos, Size lmath.Vec2
+
+	// The rotation of the sprite about it's center, in degrees
+	// counter-clockwise.
+	Rot float64
+
+	// The region of the texture to draw, for example one returned by
+	// gfxutil.AtlasPack. The zero value means the entire texture.
+	Region gfx.TexCoords
+
+	// The tint of the sprite, which the color of the texture is multiplied by.
+	// The zero value means opaque white (i.e. no tint). Like the default
+	// blend state, it is expected to have premultiplied alpha.
+	Tint gfx.Color
+}
+
+// corners returns the four corners of the sprite in world space, in the order
+// top-left, bottom-left, bottom-right, and top-right.
+func (s *Sprite) corners() [4]gfx.Vec3 {
+	hw, hh := s.Size.X/2, s.Size.Y/2
+	sin, cos := math.Sincos(lmath.Radians(s.Rot))
+	local := [4]lmath.Vec2{
+		{X: -hw, Y: hh},
+		{X: -hw, Y: -hh},
+		{X: hw, Y: -hh},
+		{X: hw, Y: hh},
+	}
+	var c [4]gfx.Vec3
+	for i, p := range local {
+		c[i] = gfx.Vec3{
+			X: float32(s.Pos.X + p.X*cos - p.Y*sin),
+			Z: float32(s.Pos.Y + p.X*sin + p.Y*cos),
+		}
+	}
+	return c
+}
+
+// AppendSprite appends the quad of the given sprite to the indexed mesh m, as
+// two triangles. It adds vertices, colors (the tint), and texture coordinates
+// to the first texture coordinate set of m (which is created if needed), but
+// does not mark them as changed. The texture of the sprite is not used.
+//
+// Meshes built this way are compatible with those drawn by a Batch, and may be
+// drawn with the same shader.
+func AppendSprite(m *gfx.Mesh, s Sprite) {
+	region := s.Region
+	if region == (gfx.TexCoords{}) {
+		region = gfx.TexCoords{Max: gfx.TexCoord{U: 1, V: 1}}
+	}
+	tint := s.Tint
+	if tint == (gfx.Color{}) {
+		tint = gfx.Color{R: 1, G: 1, B: 1, A: 1}
+	}
+	if len(m.TexCoords) == 0 {
+		m.TexCoords = make([]gfx.TexCoordSet, 1)
+	}
+
+	base := uint32(len(m.Vertices))
+	v := s.corners()
+	m.Vertices = append(m.Vertices, v[0], v[1], v[2], v[3])
+	m.Colors = append(m.Colors, tint, tint, tint, tint)
+	m.TexCoords[0].Slice = append(m.TexCoords[0].Slice,
+		gfx.TexCoord{U: region.Min.U, V: region.Min.V},
+		gfx.TexCoord{U: region.Min.U, V: region.Max.V},
+		gfx.TexCoord{U: region.Max.U, V: region.Max.V},
+		gfx.TexCoord{U: region.Max.U, V: region.Min.V},
+	)
+	m.Indices = append(m.Indices,
+		base, base+1, base+2,
+		base, base+2, base+3,
+	)
+}
+
+// Batch accumulates sprites and draws them with as few draw calls as possible:
+// the sprites sharing a texture are built into a single dynamic mesh, which is
+// drawn with one Draw call.
+//
+// Sprites are drawn in the order they were added, except that all of the
+// sprites sharing a texture are drawn together (in the order each texture was
+// first used). Using a texture atlas (see gfxutil.AtlasPack) avoids this.
+//
+// A batch and it's methods are not safe for access from multiple goroutines
+// concurrently.
+type Batch struct {
+	// The shader used to draw the sprites, by default Shader.
+	Shader *gfx.Shader
+
+	// The state used to draw the sprites, by default alpha blending is enabled,
+	// and depth testing, depth writing and face culling are disabled.
+	State *gfx.State
+
+	// The transform applied to every sprite, by default NewTransform().
+	Transform *gfx.Transform
+
+	// Sprites added since the last flush.
+	sprites []Sprite
+
+	// Objects by texture, whose meshes are re-used by each flush.
+	objects map[*gfx.Texture]*gfx.Object
+
+	// Order in which the textures are drawn by a flush.
+	order []*gfx.Texture
+}
+
+// Add adds the given sprite to the batch, to be drawn by the next call to
+// Flush.
+func (b *Batch) Add(s Sprite) {
+	b.sprites = append(b.sprites, s)
+}
+
+// Len returns the number of sprites added since the last call to Flush or
+// Reset.
+func (b *Batch) Len() int {
+	return len(b.sprites)
+}
+
+// Reset discards all of the sprites added since the last call to Flush,
+// without drawing them.
+func (b *Batch) Reset() {
+	for i := range b.sprites {
+		b.sprites[i].Texture = nil
+	}
+	b.sprites = b.sprites[:0]
+}
+
+// object returns the object used to draw sprites with the given texture, its
+// mesh is emptied the first time it is used by a flush.
+func (b *Batch) object(t *gfx.Texture) *gfx.Object {
+	o, ok := b.objects[t]
+	if !ok {
+		m := gfx.NewMesh()
+		m.Usage = gfx.Dynamic
+		m.KeepDataOnLoad = true
+		m.TexCoords = []gfx.TexCoordSet{{}}
+
+		o = gfx.NewObject()
+		o.Meshes = []*gfx.Mesh{m}
+		o.Textures = []*gfx.Texture{t}
+		b.objects[t] = o
+	}
+	for _, used := range b.order {
+		if used == t {
+			return o
+		}
+	}
+	b.order = append(b.order, t)
+	m := o.Meshes[0]
+	m.Indices = m.Indices[:0]
+	m.Vertices = m.Vertices[:0]
+	m.Colors = m.Colors[:0]
+	m.TexCoords[0].Slice = m.TexCoords[0].Slice[:0]
+	return o
+}
+
+// Flush draws all of the sprites added since the last call to Flush or Reset
+// onto the canvas, using the given rectangle and camera, and then removes them
+// from the batch.
+//
+// The meshes drawn are re-used by the next call to Flush, as such it should
+// be called at most once per frame.
+func (b *Batch) Flush(c gfx.Canvas, r image.Rectangle, cam gfx.Camera) {
+	// Build the mesh of each texture.
+	for _, s := range b.sprites {
+		AppendSprite(b.object(s.Texture).Meshes[0], s)
+	}
+	b.Reset()
+
+	// Drop the objects of textures which are no longer in use.
+	for t, o := range b.objects {
+		used := false
+		for _, u := range b.order {
+			if u == t {
+				used = true
+				break
+			}
+		}
+		if !used {
+			o.Meshes[0].Destroy()
+			o.Destroy()
+			delete(b.objects, t)
+		}
+	}
+
+	// Draw each object.
+	for i, t := range b.order {
+		o := b.objects[t]
+		m := o.Meshes[0]
+		m.IndicesChanged = true
+		m.VerticesChanged = true
+		m.ColorsChanged = true
+		m.TexCoords[0].Changed = true
+		m.CalculateBounds()
+
+		o.CachedBounds = nil
+		o.Shader = b.Shader
+		o.State = b.State
+		o.Transform = b.Transform
+		c.Draw(r, o, cam)
+		b.order[i] = nil
+	}
+	b.order = b.order[:0]
+}
+
+// Destroy destroys the meshes and objects used by the batch. The shader,
+// state, transform and textures are not destroyed. You must not use the batch
+// after calling this method.
+func (b *Batch) Destroy() {
+	b.Reset()
+	for t, o := range b.objects {
+		o.Meshes[0].Destroy()
+		o.Destroy()
+		delete(b.objects, t)
+	}
+}
+
+// NewBatch returns a new, empty, batch with the default shader, state and
+// transform.
+func NewBatch() *Batch {
+	state := gfx.NewState()
+	state.AlphaMode = gfx.AlphaBlend
+	state.DepthTest = false
+	state.DepthWrite = false
+	state.FaceCulling = gfx.NoFaceCulling
+	return &Batch{
+		Shader:    Shader,
+		State:     state,
+		Transform: gfx.NewTransform(),
+		objects:   make(map[*gfx.Texture]*gfx.Object),
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx2d
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+// drawRecorder is a canvas which records the objects drawn onto it.
+type drawRecorder struct {
+	gfx.Canvas
+	drawn []*gfx.Object
+}
+
+func (d *drawRecorder) Draw(r image.Rectangle, o *gfx.Object, c gfx.Camera) {
+	d.drawn = append(d.drawn, o)
+	d.Canvas.Draw(r, o, c)
+}
+
+func TestBatchFlush(t *testing.T) {
+	a, b := gfx.NewTexture(), gfx.NewTexture()
+	batch := NewBatch()
+	batch.Add(Sprite{Texture: a, Pos: lmath.Vec2{X: 10, Y: 20}, Size: lmath.Vec2{X: 4, Y: 2}})
+	batch.Add(Sprite{Texture: b, Pos: lmath.Vec2{X: 0, Y: 0}, Size: lmath.Vec2{X: 1, Y: 1}})
+	batch.Add(Sprite{Texture: a, Pos: lmath.Vec2{X: 0, Y: 0}, Size: lmath.Vec2{X: 1, Y: 1}})
+	if batch.Len() != 3 {
+		t.Fatal("expected 3 sprites, got", batch.Len())
+	}
+
+	c := &drawRecorder{Canvas: gfx.Nil()}
+	batch.Flush(c, c.Bounds(), nil)
+	if batch.Len() != 0 {
+		t.Fatal("expected no sprites after flush, got", batch.Len())
+	}
+	if len(c.drawn) != 2 {
+		t.Fatal("expected one draw call per texture, got", len(c.drawn))
+	}
+	if c.drawn[0].Textures[0] != a || c.drawn[1].Textures[0] != b {
+		t.Fatal("textures not drawn in order of first use")
+	}
+
+	m := c.drawn[0].Meshes[0]
+	if len(m.Vertices) != 8 || len(m.Indices) != 12 || len(m.Colors) != 8 || len(m.TexCoords[0].Slice) != 8 {
+		t.Fatal("expected two quads in the mesh of the first texture")
+	}
+	if m.EffectiveUsage() != gfx.Dynamic {
+		t.Fatal("expected a dynamic mesh")
+	}
+
+	// The top-left corner of the first sprite has the top-left texture
+	// coordinate, and the zero tint is white.
+	want := gfx.Vec3{X: 8, Z: 21}
+	if m.Vertices[0] != want {
+		t.Fatal("got top-left corner", m.Vertices[0], "want", want)
+	}
+	if tc := m.TexCoords[0].Slice[0]; tc != (gfx.TexCoord{U: 0, V: 0}) {
+		t.Fatal("got top-left texture coordinate", tc)
+	}
+	if m.Colors[0] != (gfx.Color{R: 1, G: 1, B: 1, A: 1}) {
+		t.Fatal("got tint", m.Colors[0])
+	}
+
+	// Flushing again re-uses the object of the texture still in use, and
+	// drops the other.
+	c.drawn = nil
+	batch.Add(Sprite{Texture: a, Size: lmath.Vec2{X: 1, Y: 1}})
+	batch.Flush(c, c.Bounds(), nil)
+	if len(c.drawn) != 1 || c.drawn[0].Meshes[0] != m || len(m.Vertices) != 4 {
+		t.Fatal("expected the mesh to be re-used for a single quad")
+	}
+	if len(batch.objects) != 1 {
+		t.Fatal("expected unused objects to be dropped, got", len(batch.objects))
+	}
+	batch.Destroy()
+}
+
+func TestSpriteCorners(t *testing.T) {
+	s := Sprite{
+		Pos:  lmath.Vec2{X: 5, Y: 5},
+		Size: lmath.Vec2{X: 2, Y: 4},
+		Rot:  90,
+	}
+	c := s.corners()
+
+	// Rotated 90 degrees counter-clockwise, the top-left corner is now at the
+	// bottom-left.
+	want := [4]gfx.Vec3{
+		{X: 3, Z: 4},
+		{X: 7, Z: 4},
+		{X: 7, Z: 6},
+		{X: 3, Z: 6},
+	}
+	for i := range c {
+		if math.Abs(float64(c[i].X-want[i].X)) > 1e-5 || math.Abs(float64(c[i].Z-want[i].Z)) > 1e-5 {
+			t.Fatal("corner", i, "got", c[i], "want", want[i])
+		}
+	}
+}
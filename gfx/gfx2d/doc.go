@@ -0,0 +1,17 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gfx2d implements helpers for drawing 2D graphics.
+//
+// The package is designed to be used with an orthographic camera (see the
+// camera.NewOrtho function), where one world unit is one pixel and the
+// origin is the bottom-left corner of the viewing rectangle. All geometry lies
+// in the XZ plane (Z is up), facing the camera which looks along the +Y axis:
+//
+//	cam := camera.NewOrtho(canvas.Bounds())
+//	cam.SetPos(lmath.Vec3{0, -2, 0})
+//
+// Texture coordinates follow the usual convention of V=0 being the top of the
+// texture image, such that a texture loaded from an image appears upright.
+package gfx2d // import "github.com/qmcloud/engine/gfx/gfx2d"
@@ -0,0 +1,275 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxtext
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+var (
+	// ErrNoCommon is returned by Parse when the font file does not have a
+	// common line (which holds the line height and texture size).
+	ErrNoCommon = errors.New("gfxtext: font file has no common line")
+
+	// ErrNoPages is returned by Parse when the font file does not reference
+	// any page (texture) files.
+	ErrNoPages = errors.New("gfxtext: font file has no pages")
+
+	// ErrBadScale is returned by Parse when the texture size of the font file
+	// (it's scaleW or scaleH value) is not positive.
+	ErrBadScale = errors.New("gfxtext: font file has an invalid texture size")
+)
+
+// maxPages is the maximum number of pages a font may have.
+const maxPages = 256
+
+// Glyph describes a single glyph of a font. All values are in pixels.
+type Glyph struct {
+	// The region of the page texture the glyph occupies, with the origin at
+	// the top-left of the texture.
+	X, Y, Width, Height int
+
+	// The offset from the pen position (at the top of the line) to the
+	// top-left of the glyph's region when drawn.
+	XOffset, YOffset int
+
+	// How far the pen position advances after drawing the glyph.
+	XAdvance int
+
+	// The index of the page (texture) the glyph is stored in.
+	Page int
+}
+
+// kernPair is a pair of characters, for which there is a kerning amount.
+type kernPair struct {
+	first, second rune
+}
+
+// Font is a bitmap font, made up of glyph metrics and the textures (pages)
+// that the glyphs are stored in.
+type Font struct {
+	// The name of the font face and it's size.
+	Face string
+	Size int
+
+	// The distance between lines, and from the top of a line to the
+	// baseline, in pixels.
+	LineHeight, Base int
+
+	// The width and height of each page texture, in pixels. They must be
+	// positive, or else the texture coordinates of each glyph are zero.
+	ScaleW, ScaleH int
+
+	// The file name of each page, relative to the font file.
+	Pages []string
+
+	// The texture of each page. They are loaded by LoadFile, or may be
+	// assigned after calling Parse.
+	Textures []*gfx.Texture
+
+	// The glyph of each character.
+	Glyphs map[rune]Glyph
+
+	// The kerning amount of pairs of characters.
+	kerning map[kernPair]int
+}
+
+// Kerning returns the amount (in pixels) that the pen position should be
+// adjusted by when the character b follows the character a.
+func (f *Font) Kerning(a, b rune) int {
+	return f.kerning[kernPair{a, b}]
+}
+
+// fields splits a line of a BMFont text file into it's tag, and a map of it's
+// key=value pairs. Values may be quoted, in which case they may hold spaces.
+func fields(line string) (tag string, attrs map[string]string) {
+	line = strings.TrimSpace(line)
+	if i := strings.IndexAny(line, " \t"); i >= 0 {
+		tag, line = line[:i], line[i+1:]
+	} else {
+		return line, nil
+	}
+	attrs = make(map[string]string)
+	for {
+		line = strings.TrimLeft(line, " \t")
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 {
+			return
+		}
+		key := line[:eq]
+		line = line[eq+1:]
+
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			end := strings.IndexByte(line[1:], '"')
+			if end < 0 {
+				end = len(line) - 1
+			}
+			value, line = line[1:end+1], line[min(end+2, len(line)):]
+		} else if end := strings.IndexAny(line, " \t"); end >= 0 {
+			value, line = line[:end], line[end:]
+		} else {
+			value, line = line, ""
+		}
+		attrs[key] = value
+	}
+}
+
+// Parse parses a BMFont file in the text format from the given reader. The
+// Textures of the returned font are not loaded (see LoadFile).
+func Parse(r io.Reader) (*Font, error) {
+	f := &Font{
+		Glyphs:  make(map[rune]Glyph),
+		kerning: make(map[kernPair]int),
+	}
+
+	var (
+		haveCommon bool
+		lineNum    int
+		err        error
+	)
+	atoi := func(attrs map[string]string, key string) int {
+		v, ok := attrs[key]
+		if !ok || err != nil {
+			return 0
+		}
+		var n int
+		n, err = strconv.Atoi(v)
+		if err != nil {
+			err = fmt.Errorf("gfxtext: line %d: invalid %s value %q", lineNum, key, v)
+		}
+		return n
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNum++
+		tag, attrs := fields(scanner.Text())
+		switch tag {
+		case "info":
+			f.Face = attrs["face"]
+			f.Size = atoi(attrs, "size")
+			if f.Size < 0 {
+				// Negative sizes denote a match of the character height
+				// instead of the cell height.
+				f.Size = -f.Size
+			}
+
+		case "common":
+			haveCommon = true
+			f.LineHeight = atoi(attrs, "lineHeight")
+			f.Base = atoi(attrs, "base")
+			f.ScaleW = atoi(attrs, "scaleW")
+			f.ScaleH = atoi(attrs, "scaleH")
+
+		case "page":
+			id := atoi(attrs, "id")
+			if err == nil && (id < 0 || id >= maxPages) {
+				err = fmt.Errorf("gfxtext: line %d: invalid page id %d", lineNum, id)
+			}
+			if err != nil {
+				return nil, err
+			}
+			for len(f.Pages) <= id {
+				f.Pages = append(f.Pages, "")
+			}
+			f.Pages[id] = attrs["file"]
+
+		case "char":
+			id := atoi(attrs, "id")
+			f.Glyphs[rune(id)] = Glyph{
+				X:        atoi(attrs, "x"),
+				Y:        atoi(attrs, "y"),
+				Width:    atoi(attrs, "width"),
+				Height:   atoi(attrs, "height"),
+				XOffset:  atoi(attrs, "xoffset"),
+				YOffset:  atoi(attrs, "yoffset"),
+				XAdvance: atoi(attrs, "xadvance"),
+				Page:     atoi(attrs, "page"),
+			}
+
+		case "kerning":
+			pair := kernPair{
+				first:  rune(atoi(attrs, "first")),
+				second: rune(atoi(attrs, "second")),
+			}
+			f.kerning[pair] = atoi(attrs, "amount")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !haveCommon {
+		return nil, ErrNoCommon
+	}
+	if f.ScaleW <= 0 || f.ScaleH <= 0 {
+		return nil, ErrBadScale
+	}
+	if len(f.Pages) == 0 {
+		return nil, ErrNoPages
+	}
+	return f, nil
+}
+
+// LoadFile parses the named BMFont file (see Parse), and loads the texture of
+// each of it's pages. The page images are decoded using the image package, as
+// such you will also need to import a image decoder, e.g. for png:
+//
+//	import _ "image/png"
+//
+// The textures have a MinFilter and MagFilter of Linear (mipmaps would cause
+// the glyphs to bleed into one another), and Format == RGBA.
+func LoadFile(path string) (*Font, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	f, err := Parse(file)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	f.Textures = make([]*gfx.Texture, len(f.Pages))
+	for i, page := range f.Pages {
+		img, err := openImage(filepath.Join(dir, page))
+		if err != nil {
+			return nil, err
+		}
+		tex := gfx.NewTexture()
+		tex.Source = img
+		tex.Bounds = img.Bounds()
+		tex.MinFilter = gfx.Linear
+		tex.MagFilter = gfx.Linear
+		tex.Format = gfx.RGBA
+		f.Textures[i] = tex
+	}
+	return f, nil
+}
+
+// openImage opens and decodes the named image file.
+func openImage(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	return img, err
+}
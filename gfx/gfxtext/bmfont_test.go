@@ -0,0 +1,103 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxtext
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testFont is a small BMFont file, whose glyphs are 8x10 pixels with an
+// advance of 9 pixels (and 4 pixels for space).
+const testFont = `info face="Test Sans" size=-12 bold=0 italic=0 charset="" unicode=1 padding=0,0,0,0 spacing=1,1
+common lineHeight=14 base=11 scaleW=64 scaleH=32 pages=1 packed=0
+page id=0 file="test_0.png"
+chars count=5
+char id=32   x=0     y=0     width=0     height=0     xoffset=0     yoffset=0     xadvance=4     page=0  chnl=15
+char id=65   x=0     y=0     width=8     height=10    xoffset=0     yoffset=1     xadvance=9     page=0  chnl=15
+char id=66   x=8     y=0     width=8     height=10    xoffset=1     yoffset=1     xadvance=9     page=0  chnl=15
+char id=86   x=16    y=0     width=8     height=10    xoffset=0     yoffset=1     xadvance=9     page=0  chnl=15
+char id=97   x=24    y=0     width=8     height=10    xoffset=0     yoffset=2     xadvance=9     page=0  chnl=15
+kernings count=1
+kerning first=65  second=86  amount=-2
+`
+
+func TestParse(t *testing.T) {
+	f, err := Parse(strings.NewReader(testFont))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Face != "Test Sans" || f.Size != 12 {
+		t.Fatalf("got face %q size %d", f.Face, f.Size)
+	}
+	if f.LineHeight != 14 || f.Base != 11 || f.ScaleW != 64 || f.ScaleH != 32 {
+		t.Fatal("bad common values", f.LineHeight, f.Base, f.ScaleW, f.ScaleH)
+	}
+	if len(f.Pages) != 1 || f.Pages[0] != "test_0.png" {
+		t.Fatal("bad pages", f.Pages)
+	}
+	if len(f.Glyphs) != 5 {
+		t.Fatal("expected 5 glyphs, got", len(f.Glyphs))
+	}
+	want := Glyph{X: 8, Width: 8, Height: 10, XOffset: 1, YOffset: 1, XAdvance: 9}
+	if g := f.Glyphs['B']; g != want {
+		t.Fatal("got glyph", g, "want", want)
+	}
+	if k := f.Kerning('A', 'V'); k != -2 {
+		t.Fatal("got kerning", k, "want -2")
+	}
+	if k := f.Kerning('V', 'A'); k != 0 {
+		t.Fatal("got kerning", k, "want 0")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want error
+	}{
+		{"info face=\"x\"\npage id=0 file=\"a.png\"\n", ErrNoCommon},
+		{"common lineHeight=1 base=1 scaleW=1 scaleH=1\n", ErrNoPages},
+		{"common lineHeight=1 base=1 scaleW=0 scaleH=1\npage id=0 file=\"a.png\"\n", ErrBadScale},
+		{"common lineHeight=1 base=1 scaleW=1\npage id=0 file=\"a.png\"\n", ErrBadScale},
+		{"common lineHeight=1 base=1 scaleW=1 scaleH=-1\npage id=0 file=\"a.png\"\n", ErrBadScale},
+	}
+	for _, tst := range tests {
+		if _, err := Parse(strings.NewReader(tst.src)); err != tst.want {
+			t.Fatal("got error", err, "want", tst.want)
+		}
+	}
+	if _, err := Parse(strings.NewReader("common lineHeight=abc\n")); err == nil {
+		t.Fatal("expected error for invalid value")
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.fnt")
+	if err := os.WriteFile(path, []byte(testFont), 0644); err != nil {
+		t.Fatal(err)
+	}
+	img, err := os.Create(filepath.Join(dir, "test_0.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(img, image.NewNRGBA(image.Rect(0, 0, 64, 32))); err != nil {
+		t.Fatal(err)
+	}
+	img.Close()
+
+	f, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Textures) != 1 || f.Textures[0].Bounds != image.Rect(0, 0, 64, 32) {
+		t.Fatal("expected a single 64x32 page texture")
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gfxtext implements text rendering using bitmap fonts.
+//
+// Fonts are loaded from AngelCode BMFont files (the text format), which
+// describe the metrics of each glyph and the texture atlas images (pages) the
+// glyphs are stored in. Such fonts can be generated by many tools, such as the
+// BMFont tool itself or Hiero.
+//
+// Text is laid out into gfx2d sprites, such that text and sprites may be drawn
+// together by a single gfx2d.Batch, or into a single object for text which
+// does not change often:
+//
+//	font, err := gfxtext.LoadFile("font.fnt")
+//	...
+//	for _, s := range font.Sprites("Hello world!", pos, 0) {
+//		batch.Add(s)
+//	}
+package gfxtext // import "github.com/qmcloud/engine/gfx/gfxtext"
@@ -0,0 +1,160 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxtext
+
+import (
+	"strings"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/gfx2d"
+	"github.com/qmcloud/engine/lmath"
+)
+
+// Measure returns the width in pixels of the given single line of text, i.e.
+// the sum of the advance of each glyph, adjusted by kerning.
+func (f *Font) Measure(line string) int {
+	var (
+		width int
+		prev  rune = -1
+	)
+	for _, r := range line {
+		g, ok := f.Glyphs[r]
+		if !ok {
+			continue
+		}
+		if prev >= 0 {
+			width += f.Kerning(prev, r)
+		}
+		width += g.XAdvance
+		prev = r
+	}
+	return width
+}
+
+// Lines splits the given text into lines. Lines are broken at each newline
+// character and, if maxWidth > 0, at the last space before the line would
+// become wider than maxWidth. A single word wider than maxWidth is not broken.
+func (f *Font) Lines(text string, maxWidth int) []string {
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		if maxWidth <= 0 {
+			lines = append(lines, para)
+			continue
+		}
+		words := strings.Split(para, " ")
+		line := words[0]
+		for _, w := range words[1:] {
+			if f.Measure(line+" "+w) > maxWidth {
+				lines = append(lines, line)
+				line = w
+				continue
+			}
+			line += " " + w
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Sprites lays out the given text (see Lines) and returns a sprite for each
+// visible glyph, such that the text may be drawn by a gfx2d.Batch alongside
+// other sprites. The top-left of the first line is placed at pos, following
+// lines are placed below it (i.e. towards -Y) by LineHeight.
+//
+// Characters without a glyph in the font are skipped. The texture of each
+// sprite is the texture of the glyph's page (see the Textures field), which
+// must be set in order for the sprites to be drawn.
+func (f *Font) Sprites(text string, pos lmath.Vec2, maxWidth int) []gfx2d.Sprite {
+	var sprites []gfx2d.Sprite
+	for n, line := range f.Lines(text, maxWidth) {
+		var (
+			penX int
+			prev rune = -1
+		)
+		penY := n * f.LineHeight
+		for _, r := range line {
+			g, ok := f.Glyphs[r]
+			if !ok {
+				continue
+			}
+			if prev >= 0 {
+				penX += f.Kerning(prev, r)
+			}
+			prev = r
+			x := penX + g.XOffset
+			penX += g.XAdvance
+			if g.Width == 0 || g.Height == 0 {
+				continue
+			}
+
+			var tex *gfx.Texture
+			if g.Page < len(f.Textures) {
+				tex = f.Textures[g.Page]
+			}
+			sprites = append(sprites, gfx2d.Sprite{
+				Texture: tex,
+				Pos: lmath.Vec2{
+					X: pos.X + float64(x) + float64(g.Width)/2,
+					Y: pos.Y - float64(penY+g.YOffset) - float64(g.Height)/2,
+				},
+				Size: lmath.Vec2{
+					X: float64(g.Width),
+					Y: float64(g.Height),
+				},
+				Region: f.region(g),
+			})
+		}
+	}
+	return sprites
+}
+
+// region returns the texture coordinates of the given glyph.
+func (f *Font) region(g Glyph) gfx.TexCoords {
+	if f.ScaleW <= 0 || f.ScaleH <= 0 {
+		return gfx.TexCoords{}
+	}
+	w, h := float32(f.ScaleW), float32(f.ScaleH)
+	return gfx.TexCoords{
+		Min: gfx.TexCoord{
+			U: float32(g.X) / w,
+			V: float32(g.Y) / h,
+		},
+		Max: gfx.TexCoord{
+			U: float32(g.X+g.Width) / w,
+			V: float32(g.Y+g.Height) / h,
+		},
+	}
+}
+
+// Object lays out the given text (see Sprites) into a single mesh, and returns
+// a new object which draws it using gfx2d.Shader. The top-left of the text is
+// at the object's origin, and it may be positioned using the object's
+// transform.
+//
+// The object uses the texture of the first page only, the glyphs of any other
+// pages are skipped (use Sprites with a gfx2d.Batch to draw text using fonts
+// with multiple pages).
+func (f *Font) Object(text string, maxWidth int) *gfx.Object {
+	m := gfx.NewMesh()
+	for _, s := range f.Sprites(text, lmath.Vec2{}, maxWidth) {
+		if len(f.Textures) > 0 && s.Texture != f.Textures[0] {
+			continue
+		}
+		gfx2d.AppendSprite(m, s)
+	}
+
+	o := gfx.NewObject()
+	o.State = gfx.NewState()
+	o.State.AlphaMode = gfx.AlphaBlend
+	o.State.DepthTest = false
+	o.State.DepthWrite = false
+	o.State.FaceCulling = gfx.NoFaceCulling
+	o.Shader = gfx2d.Shader
+	o.Meshes = []*gfx.Mesh{m}
+	if len(f.Textures) > 0 {
+		o.Textures = []*gfx.Texture{f.Textures[0]}
+	}
+	return o
+}
@@ -0,0 +1,113 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxtext
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+func parseTestFont(t *testing.T) *Font {
+	f, err := Parse(strings.NewReader(testFont))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Textures = []*gfx.Texture{gfx.NewTexture()}
+	return f
+}
+
+func TestMeasure(t *testing.T) {
+	f := parseTestFont(t)
+	tests := map[string]int{
+		"":    0,
+		"A":   9,
+		"AB":  18,
+		"AV":  16, // Kerned.
+		"A B": 22,
+		"A?":  9, // Missing glyph.
+	}
+	for s, want := range tests {
+		if got := f.Measure(s); got != want {
+			t.Errorf("Measure(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestLines(t *testing.T) {
+	f := parseTestFont(t)
+	tests := []struct {
+		text     string
+		maxWidth int
+		want     []string
+	}{
+		{"AB AB\nA", 0, []string{"AB AB", "A"}},
+		{"AB AB AB", 40, []string{"AB AB", "AB"}},
+		{"AB AB AB", 10, []string{"AB", "AB", "AB"}},
+		{"ABABAB", 10, []string{"ABABAB"}},
+	}
+	for _, tst := range tests {
+		if got := f.Lines(tst.text, tst.maxWidth); !reflect.DeepEqual(got, tst.want) {
+			t.Errorf("Lines(%q, %d) = %q, want %q", tst.text, tst.maxWidth, got, tst.want)
+		}
+	}
+}
+
+func TestSprites(t *testing.T) {
+	f := parseTestFont(t)
+	sprites := f.Sprites("AV a\nB", lmath.Vec2{X: 100, Y: 50}, 0)
+	if len(sprites) != 4 {
+		t.Fatal("expected 4 sprites (spaces are invisible), got", len(sprites))
+	}
+
+	// V is kerned towards A.
+	if x := sprites[1].Pos.X; x != 100+7+4 {
+		t.Fatal("got V center", x, "want", 100+7+4)
+	}
+
+	// The first glyph is below the top-left of the text by it's Y offset.
+	if y := sprites[0].Pos.Y; y != 50-1-5 {
+		t.Fatal("got A center", y, "want", 50-1-5)
+	}
+
+	// B is on the second line.
+	b := sprites[3]
+	if b.Pos != (lmath.Vec2{X: 100 + 1 + 4, Y: 50 - 14 - 1 - 5}) {
+		t.Fatal("got B center", b.Pos)
+	}
+	want := gfx.TexCoords{
+		Min: gfx.TexCoord{U: 8.0 / 64, V: 0},
+		Max: gfx.TexCoord{U: 16.0 / 64, V: 10.0 / 32},
+	}
+	if b.Region != want || b.Texture != f.Textures[0] {
+		t.Fatal("got B region", b.Region, "want", want)
+	}
+}
+
+func TestSpritesZeroScale(t *testing.T) {
+	// A font assigned by hand without a texture size must not divide by zero.
+	f := parseTestFont(t)
+	f.ScaleW = 0
+	for _, s := range f.Sprites("AB", lmath.Vec2{}, 0) {
+		if s.Region != (gfx.TexCoords{}) {
+			t.Fatal("got region", s.Region, "want zero texture coordinates")
+		}
+	}
+}
+
+func TestObject(t *testing.T) {
+	f := parseTestFont(t)
+	o := f.Object("AB\nBA", 0)
+	if len(o.Meshes) != 1 || len(o.Textures) != 1 || o.Textures[0] != f.Textures[0] {
+		t.Fatal("expected a single mesh and texture")
+	}
+	m := o.Meshes[0]
+	if len(m.Vertices) != 16 || len(m.Indices) != 24 {
+		t.Fatal("expected four quads, got", len(m.Vertices), "vertices")
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"sort"
+
+	"github.com/qmcloud/engine/binpack"
+	"github.com/qmcloud/engine/gfx"
+)
+
+var (
+	// ErrAtlasEmpty is returned by AtlasPack when there are no images to pack.
+	ErrAtlasEmpty = errors.New("gfxutil: no images to pack into atlas")
+
+	// ErrAtlasNilImage is returned by AtlasPack when one of the images is nil.
+	ErrAtlasNilImage = errors.New("gfxutil: cannot pack nil image into atlas")
+
+	// ErrAtlasTooLarge is returned by AtlasPack when the images cannot be
+	// packed within the maximum atlas size.
+	ErrAtlasTooLarge = errors.New("gfxutil: images do not fit within maximum atlas size")
+)
+
+// atlasBlocks implements the binpack.Packable interface over a set of images,
+// in the order given by the order slice.
+type atlasBlocks struct {
+	imgs  []image.Image
+	order []int
+	pos   []image.Point
+}
+
+func (a *atlasBlocks) Len() int {
+	return len(a.order)
+}
+
+func (a *atlasBlocks) Size(n int) (width, height int) {
+	sz := a.imgs[a.order[n]].Bounds().Size()
+	return sz.X, sz.Y
+}
+
+func (a *atlasBlocks) Place(n, x, y int) {
+	a.pos[a.order[n]] = image.Pt(x, y)
+}
+
+// AtlasPack packs the given images into a single texture atlas, which can be
+// used to draw many small images with a single texture (and hence without
+// texture state changes between them).
+//
+// The returned map holds the region of the atlas each image occupies, keyed by
+// the image's index in the imgs slice. The regions are suitable for building
+// the texture coordinates of a mesh (see the Mesh.TexCoords field).
+//
+// The returned texture has a RGBA source image, no larger than maxSize in
+// either dimension, and it's MinFilter and MagFilter are both Linear (mipmaps
+// would cause the images to bleed into one another).
+//
+// If the images cannot fit within maxSize, ErrAtlasTooLarge is returned.
+func AtlasPack(imgs []image.Image, maxSize int) (*gfx.Texture, map[int]gfx.TexCoords, error) {
+	if len(imgs) == 0 {
+		return nil, nil, ErrAtlasEmpty
+	}
+	blocks := &atlasBlocks{
+		imgs:  imgs,
+		order: make([]int, len(imgs)),
+		pos:   make([]image.Point, len(imgs)),
+	}
+	for i, img := range imgs {
+		if img == nil {
+			return nil, nil, ErrAtlasNilImage
+		}
+		blocks.order[i] = i
+	}
+
+	// Pack the largest images first, as suggested by binpack.Pack.
+	maxDim := func(i int) int {
+		sz := imgs[i].Bounds().Size()
+		if sz.X > sz.Y {
+			return sz.X
+		}
+		return sz.Y
+	}
+	sort.SliceStable(blocks.order, func(i, j int) bool {
+		return maxDim(blocks.order[i]) > maxDim(blocks.order[j])
+	})
+	width, height := binpack.Pack(blocks)
+	if width < 0 || height < 0 || width > maxSize || height > maxSize {
+		return nil, nil, ErrAtlasTooLarge
+	}
+
+	// Draw each image into the atlas and determine it's texture coordinates.
+	atlas := image.NewRGBA(image.Rect(0, 0, width, height))
+	coords := make(map[int]gfx.TexCoords, len(imgs))
+	for i, img := range imgs {
+		b := img.Bounds()
+		r := b.Sub(b.Min).Add(blocks.pos[i])
+		draw.Draw(atlas, r, img, b.Min, draw.Src)
+		coords[i] = gfx.TexCoords{
+			Min: gfx.TexCoord{
+				U: float32(r.Min.X) / float32(width),
+				V: float32(r.Min.Y) / float32(height),
+			},
+			Max: gfx.TexCoord{
+				U: float32(r.Max.X) / float32(width),
+				V: float32(r.Max.Y) / float32(height),
+			},
+		}
+	}
+
+	tex := gfx.NewTexture()
+	tex.Source = atlas
+	tex.Bounds = atlas.Bounds()
+	tex.MinFilter = gfx.Linear
+	tex.MagFilter = gfx.Linear
+	tex.Format = gfx.RGBA
+	return tex, coords, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func solidImage(w, h int, c color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c), image.ZP, draw.Src)
+	return img
+}
+
+func TestAtlasPack(t *testing.T) {
+	colors := []color.RGBA{
+		{255, 0, 0, 255},
+		{0, 255, 0, 255},
+		{0, 0, 255, 255},
+		{255, 255, 0, 255},
+	}
+	imgs := []image.Image{
+		solidImage(16, 16, colors[0]),
+		solidImage(32, 8, colors[1]),
+		solidImage(8, 32, colors[2]),
+		solidImage(4, 4, colors[3]),
+	}
+	tex, coords, err := AtlasPack(imgs, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coords) != len(imgs) {
+		t.Fatal("got", len(coords), "regions, expected", len(imgs))
+	}
+	atlas := tex.Source.(*image.RGBA)
+	size := atlas.Bounds().Size()
+	if size.X > 64 || size.Y > 64 {
+		t.Fatal("atlas size", size, "exceeds maximum")
+	}
+
+	var placed []image.Rectangle
+	for i, img := range imgs {
+		tc := coords[i]
+		r := image.Rect(
+			int(tc.Min.U*float32(size.X)+0.5),
+			int(tc.Min.V*float32(size.Y)+0.5),
+			int(tc.Max.U*float32(size.X)+0.5),
+			int(tc.Max.V*float32(size.Y)+0.5),
+		)
+		if r.Size() != img.Bounds().Size() {
+			t.Fatal("image", i, "region", r, "does not match image size", img.Bounds().Size())
+		}
+		for _, p := range placed {
+			if p.Overlaps(r) {
+				t.Fatal("image", i, "region", r, "overlaps", p)
+			}
+		}
+		placed = append(placed, r)
+		if got := atlas.RGBAAt(r.Min.X, r.Min.Y); got != colors[i] {
+			t.Fatal("image", i, "got color", got, "expected", colors[i])
+		}
+		if got := atlas.RGBAAt(r.Max.X-1, r.Max.Y-1); got != colors[i] {
+			t.Fatal("image", i, "got color", got, "expected", colors[i])
+		}
+	}
+}
+
+func TestAtlasPackTooLarge(t *testing.T) {
+	imgs := []image.Image{
+		solidImage(32, 32, color.White),
+		solidImage(32, 32, color.White),
+	}
+	if _, _, err := AtlasPack(imgs, 32); err != ErrAtlasTooLarge {
+		t.Fatal("got error", err, "expected", ErrAtlasTooLarge)
+	}
+	if _, _, err := AtlasPack(nil, 32); err != ErrAtlasEmpty {
+		t.Fatal("got error", err, "expected", ErrAtlasEmpty)
+	}
+}
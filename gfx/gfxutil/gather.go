@@ -0,0 +1,107 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"reflect"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+// gather returns a new per-vertex data slice whose i'th element is the
+// element of s at indices[i]. Array data slices (e.g. [][]gfx.Mat4, see
+// gfx.VertexAttrib) have each of their per-vertex slices gathered. Empty data
+// slices are returned as-is.
+func gather(s interface{}, indices []uint32) interface{} {
+	v := reflect.ValueOf(s)
+	if v.Len() == 0 {
+		return s
+	}
+	if v.Type().Elem().Kind() == reflect.Slice {
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(reflect.ValueOf(gather(v.Index(i).Interface(), indices)))
+		}
+		return out.Interface()
+	}
+	out := reflect.MakeSlice(v.Type(), len(indices), len(indices))
+	for i, index := range indices {
+		out.Index(i).Set(v.Index(int(index)))
+	}
+	return out.Interface()
+}
+
+// checkDataLength tells if the per-vertex data slice s is either empty or has
+// exactly n elements, such that it may be gathered (see gather). Each of the
+// per-vertex slices of array data slices is checked.
+func checkDataLength(s interface{}, n int) bool {
+	v := reflect.ValueOf(s)
+	if v.Kind() != reflect.Slice {
+		return false
+	}
+	if v.Len() == 0 {
+		return true
+	}
+	if v.Type().Elem().Kind() == reflect.Slice {
+		for i := 0; i < v.Len(); i++ {
+			if !checkDataLength(v.Index(i).Interface(), n) {
+				return false
+			}
+		}
+		return true
+	}
+	return v.Len() == n
+}
+
+// checkDataLengths returns ErrDataLength if a per-vertex data slice of the
+// mesh m (see checkDataLength) has the wrong length.
+func checkDataLengths(m *gfx.Mesh) error {
+	n := len(m.Vertices)
+	slices := []interface{}{
+		m.Colors, m.Normals, m.Bary, m.Tangents, m.BoneIndices, m.BoneWeights,
+	}
+	for _, set := range m.TexCoords {
+		slices = append(slices, set.Slice)
+	}
+	for _, attrib := range m.Attribs {
+		slices = append(slices, attrib.Data)
+	}
+	for _, s := range slices {
+		if !checkDataLength(s, n) {
+			return ErrDataLength
+		}
+	}
+	return nil
+}
+
+// gatherMesh gathers each per-vertex data slice of the mesh m by the given
+// indices (see gather), marking each one as changed. The mesh's indices are
+// left untouched.
+func gatherMesh(m *gfx.Mesh, indices []uint32) {
+	m.Vertices = gather(m.Vertices, indices).([]gfx.Vec3)
+	m.VerticesChanged = true
+	m.Colors = gather(m.Colors, indices).([]gfx.Color)
+	m.ColorsChanged = true
+	m.Normals = gather(m.Normals, indices).([]gfx.Vec3)
+	m.NormalsChanged = true
+	m.Bary = gather(m.Bary, indices).([]gfx.Vec3)
+	m.BaryChanged = true
+	m.Tangents = gather(m.Tangents, indices).([]gfx.Vec4)
+	m.TangentsChanged = true
+	m.BoneIndices = gather(m.BoneIndices, indices).([]gfx.Vec4)
+	m.BoneIndicesChanged = true
+	m.BoneWeights = gather(m.BoneWeights, indices).([]gfx.Vec4)
+	m.BoneWeightsChanged = true
+	for i, set := range m.TexCoords {
+		set.Slice = gather(set.Slice, indices).([]gfx.TexCoord)
+		set.Changed = true
+		m.TexCoords[i] = set
+	}
+	for name, attrib := range m.Attribs {
+		attrib.Data = gather(attrib.Data, indices)
+		attrib.Changed = true
+		m.Attribs[name] = attrib
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+// LODLevel is a single level of detail of an object.
+type LODLevel struct {
+	// The distance from the target at which this level of detail begins to
+	// be used.
+	Dist float64
+
+	// The meshes of the object at this level of detail.
+	Meshes []*gfx.Mesh
+}
+
+// LOD selects the meshes of an object based on it's distance away from a
+// target position (typically the camera), such that lower-detail meshes can
+// be drawn for distant objects:
+//
+//	lod := gfxutil.NewLOD(tree)
+//	lod.Add(0, treeHigh)
+//	lod.Add(50, treeMedium)
+//	lod.Add(200, treeLow)
+//
+//	// Each frame, before drawing:
+//	t := cam.Transform()
+//	lod.Update(t.ConvertPos(t.Pos(), gfx.ParentToWorld))
+//
+// The distance is measured in world space just like ByDist does.
+type LOD struct {
+	// The object whose meshes are selected.
+	Object *gfx.Object
+
+	// The levels of detail, in no particular order.
+	Levels []LODLevel
+
+	// If true, the distance is measured to the center of the object's world
+	// bounds instead of to it's position (see ByDist.UseBounds).
+	UseBounds bool
+}
+
+// Add adds a level of detail which is used starting at the given distance
+// from the target.
+func (l *LOD) Add(dist float64, meshes ...*gfx.Mesh) {
+	l.Levels = append(l.Levels, LODLevel{Dist: dist, Meshes: meshes})
+}
+
+// Level returns the index of the level of detail to use for the object given
+// the target position, which is the one with the greatest Dist not exceeding
+// the object's distance from the target. If the object is closer than every
+// level's Dist, the level with the smallest Dist is used instead. If there are
+// no levels, -1 is returned.
+func (l *LOD) Level(target lmath.Vec3) int {
+	pos := ByDist{UseBounds: l.UseBounds}.pos(l.Object)
+	dist := pos.Sub(target).Length()
+
+	best, nearest := -1, -1
+	for i, lvl := range l.Levels {
+		if nearest == -1 || lvl.Dist < l.Levels[nearest].Dist {
+			nearest = i
+		}
+		if lvl.Dist <= dist && (best == -1 || lvl.Dist > l.Levels[best].Dist) {
+			best = i
+		}
+	}
+	if best == -1 {
+		return nearest
+	}
+	return best
+}
+
+// Update assigns the meshes of the level of detail to use (see Level) given
+// the target position to the object's Meshes field. If there are no levels,
+// the object is left unchanged.
+func (l *LOD) Update(target lmath.Vec3) {
+	if i := l.Level(target); i != -1 {
+		l.Object.Meshes = l.Levels[i].Meshes
+	}
+}
+
+// NewLOD returns a new level of detail selector for the given object, without
+// any levels.
+func NewLOD(o *gfx.Object) *LOD {
+	return &LOD{Object: o}
+}
@@ -0,0 +1,44 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+func TestLOD(t *testing.T) {
+	high, medium, low := gfx.NewMesh(), gfx.NewMesh(), gfx.NewMesh()
+	o := gfx.NewObject()
+	o.Transform.SetPos(lmath.Vec3{Y: 10})
+
+	l := NewLOD(o)
+	l.Update(lmath.Vec3{})
+	if o.Meshes != nil {
+		t.Fatal("Update without levels changed the object's meshes")
+	}
+
+	// Levels need not be in order.
+	l.Add(200, low)
+	l.Add(5, high)
+	l.Add(50, medium)
+	tests := []struct {
+		target lmath.Vec3
+		want   *gfx.Mesh
+	}{
+		{lmath.Vec3{Y: 10}, high}, // Closer than every level.
+		{lmath.Vec3{}, high},
+		{lmath.Vec3{Y: -40}, medium},
+		{lmath.Vec3{X: 300}, low},
+	}
+	for _, tst := range tests {
+		l.Update(tst.target)
+		if len(o.Meshes) != 1 || o.Meshes[0] != tst.want {
+			t.Fatalf("target %v: got meshes %p, want %p", tst.target, o.Meshes, tst.want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"errors"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+var (
+	// ErrIndexRange is returned by ComputeNormals when an index of the mesh
+	// is out of range of it's vertices.
+	ErrIndexRange = errors.New("gfxutil: mesh index out of range")
+
+	// ErrDataLength is returned by ComputeNormals when a per-vertex data slice
+	// of the mesh is neither empty nor has exactly one element per vertex.
+	ErrDataLength = errors.New("gfxutil: mesh data slice length differs from vertex count")
+)
+
+// ComputeNormals computes the normals of the triangle mesh m from it's
+// vertices (and indices, if any), and stores them in m.Normals (which is
+// marked as changed, so that the mesh is re-uploaded).
+//
+// If smooth is true, each vertex is given the average normal of the triangles
+// sharing it (via the mesh's Indices), weighted by their area. A mesh that is
+// not indexed has no shared vertices, so it's smooth normals equal it's flat
+// ones.
+//
+// If smooth is false, each vertex is given the normal of it's triangle (flat
+// shading). As flat normals cannot be shared between triangles, an indexed
+// mesh is first converted into a non-indexed one: each of it's data slices is
+// expanded by it's indices, and the indices are set to nil.
+//
+// The normals face the side from which the triangle's vertices appear in
+// counter-clockwise order. Zero-area triangles do not contribute to the
+// normals of their vertices, a vertex without any valid normal is given the
+// up vector (+Z).
+//
+// If the mesh is not made of triangles then it is left unchanged. If an index
+// is out of range, or (when converting an indexed mesh) a per-vertex data
+// slice has the wrong length, then an error is returned and the mesh is left
+// unchanged.
+func ComputeNormals(m *gfx.Mesh, smooth bool) error {
+	if m.Primitive != gfx.Triangles || len(m.Vertices) == 0 {
+		return nil
+	}
+	if err := checkIndices(m); err != nil {
+		return err
+	}
+	if !smooth && m.Indices != nil {
+		if err := unindex(m); err != nil {
+			return err
+		}
+	}
+
+	// Accumulate the (area-weighted) normal of each triangle at each of it's
+	// vertices. The length of the cross product of two triangle edges is twice
+	// the area of the triangle.
+	var (
+		n       = len(m.Vertices)
+		normals = make([]lmath.Vec3, n)
+		count   = n
+	)
+	if m.Indices != nil {
+		count = len(m.Indices)
+	}
+	index := func(i int) uint32 {
+		if m.Indices != nil {
+			return m.Indices[i]
+		}
+		return uint32(i)
+	}
+	for i := 0; i+2 < count; i += 3 {
+		i0, i1, i2 := index(i), index(i+1), index(i+2)
+		p0 := m.Vertices[i0].Vec3()
+		e1 := m.Vertices[i1].Vec3().Sub(p0)
+		e2 := m.Vertices[i2].Vec3().Sub(p0)
+		face := e1.Cross(e2)
+		for _, v := range [3]uint32{i0, i1, i2} {
+			normals[v] = normals[v].Add(face)
+		}
+	}
+
+	if cap(m.Normals) >= n {
+		m.Normals = m.Normals[:n]
+	} else {
+		m.Normals = make([]gfx.Vec3, n)
+	}
+	for i, normal := range normals {
+		normal, ok := normal.Normalized()
+		if !ok {
+			// Only part of zero-area triangles.
+			normal = lmath.Vec3{Z: 1}
+		}
+		m.Normals[i] = gfx.ConvertVec3(normal)
+	}
+	m.NormalsChanged = true
+	return nil
+}
+
+// checkIndices returns ErrIndexRange if an index of the mesh m is out of range
+// of it's vertices.
+func checkIndices(m *gfx.Mesh) error {
+	for _, index := range m.Indices {
+		if uint64(index) >= uint64(len(m.Vertices)) {
+			return ErrIndexRange
+		}
+	}
+	return nil
+}
+
+// unindex converts the indexed mesh m into a non-indexed one, by expanding each
+// of it's data slices by the indices. The indices must be in range (see
+// checkIndices), and ErrDataLength is returned if a data slice has the wrong
+// length (in which case the mesh is left unchanged).
+func unindex(m *gfx.Mesh) error {
+	if err := checkDataLengths(m); err != nil {
+		return err
+	}
+	gatherMesh(m, m.Indices)
+	m.Indices = nil
+	m.IndicesChanged = true
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+// normalsRoof returns an indexed mesh of two triangles sharing an edge along
+// the Y axis, both sloping down and away from it like a roof.
+func normalsRoof() *gfx.Mesh {
+	m := gfx.NewMesh()
+	m.Vertices = []gfx.Vec3{
+		{0, 0, 1}, {0, 1, 1}, // Ridge.
+		{-1, 0, 0}, {1, 0, 0}, // Eaves.
+	}
+	m.Colors = []gfx.Color{{R: 1}, {G: 1}, {B: 1}, {A: 1}}
+	m.Indices = []uint32{
+		0, 1, 2,
+		0, 3, 1,
+	}
+	return m
+}
+
+func TestComputeNormalsSmooth(t *testing.T) {
+	m := normalsRoof()
+	if err := ComputeNormals(m, true); err != nil {
+		t.Fatal(err)
+	}
+	if !m.NormalsChanged || len(m.Normals) != 4 || len(m.Indices) != 6 {
+		t.Fatal("expected 4 changed normals of an indexed mesh")
+	}
+
+	// The shared ridge vertices average the two slopes, pointing straight up.
+	for _, i := range []int{0, 1} {
+		if n := m.Normals[i]; n != (gfx.Vec3{Z: 1}) {
+			t.Fatal("ridge vertex", i, "got normal", n)
+		}
+	}
+	if n := m.Normals[2]; n.X >= 0 || n.Z <= 0 {
+		t.Fatal("left eave got normal", n)
+	}
+}
+
+func TestComputeNormalsFlat(t *testing.T) {
+	m := normalsRoof()
+	if err := ComputeNormals(m, false); err != nil {
+		t.Fatal(err)
+	}
+	if m.Indices != nil || len(m.Vertices) != 6 {
+		t.Fatal("expected the mesh to be converted to a non-indexed one")
+	}
+	if len(m.Colors) != 6 || m.Colors[4] != (gfx.Color{A: 1}) {
+		t.Fatal("expected the colors to be expanded by the indices", m.Colors)
+	}
+	for i, n := range m.Normals {
+		// Each triangle's vertices share the normal of that triangle.
+		if n != m.Normals[i/3*3] {
+			t.Fatal("vertex", i, "got normal", n, "want", m.Normals[i/3*3])
+		}
+	}
+	if m.Normals[0].X >= 0 || m.Normals[3].X <= 0 {
+		t.Fatal("got face normals", m.Normals[0], m.Normals[3])
+	}
+}
+
+func TestComputeNormalsZeroArea(t *testing.T) {
+	m := gfx.NewMesh()
+	m.Vertices = []gfx.Vec3{{0, 0, 0}, {1, 0, 0}, {2, 0, 0}}
+	if err := ComputeNormals(m, true); err != nil {
+		t.Fatal(err)
+	}
+	for i, n := range m.Normals {
+		if n != (gfx.Vec3{Z: 1}) {
+			t.Fatal("vertex", i, "got normal", n, "want up vector")
+		}
+	}
+}
+
+func TestComputeNormalsErrors(t *testing.T) {
+	tests := map[string]struct {
+		change func(m *gfx.Mesh)
+		smooth bool
+		want   error
+	}{
+		"index":       {func(m *gfx.Mesh) { m.Indices[4] = 4 }, true, ErrIndexRange},
+		"index flat":  {func(m *gfx.Mesh) { m.Indices[4] = 4 }, false, ErrIndexRange},
+		"colors":      {func(m *gfx.Mesh) { m.Colors = m.Colors[:3] }, false, ErrDataLength},
+		"tex coords":  {func(m *gfx.Mesh) { m.TexCoords = []gfx.TexCoordSet{{Slice: make([]gfx.TexCoord, 5)}} }, false, ErrDataLength},
+		"attrib type": {func(m *gfx.Mesh) { m.Attribs = map[string]gfx.VertexAttrib{"X": {Data: 1.0}} }, false, ErrDataLength},
+		"attrib array": {func(m *gfx.Mesh) {
+			m.Attribs = map[string]gfx.VertexAttrib{"X": {Data: [][]float32{make([]float32, 4), make([]float32, 2)}}}
+		}, false, ErrDataLength},
+	}
+	for name, tst := range tests {
+		m := normalsRoof()
+		tst.change(m)
+		if err := ComputeNormals(m, tst.smooth); err != tst.want {
+			t.Errorf("%s: got error %v, want %v", name, err, tst.want)
+		}
+		if m.NormalsChanged || len(m.Indices) != 6 || len(m.Vertices) != 4 {
+			t.Errorf("%s: mesh changed despite the error", name)
+		}
+	}
+
+	// Colors of the right length, and empty data slices, are fine.
+	m := normalsRoof()
+	m.Attribs = map[string]gfx.VertexAttrib{"X": {Data: [][]float32{make([]float32, 4), nil}}}
+	if err := ComputeNormals(m, false); err != nil {
+		t.Fatal(err)
+	}
+}
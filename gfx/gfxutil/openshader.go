@@ -6,6 +6,7 @@ package gfxutil
 
 import (
 	"io/ioutil"
+	"os"
 	"path/filepath"
 
 	"github.com/qmcloud/engine/gfx"
@@ -21,6 +22,9 @@ import (
 //	glsl/basic.vert
 //	glsl/basic.frag
 //
+// If a geometry shader source file (e.g. glsl/basic.geom) exists, it is loaded
+// as well.
+//
 // The filename (e.g. "basic") will be the name of the shader (which is used
 // for debug output only).
 //
@@ -36,11 +40,18 @@ func OpenShader(basePath string) (*gfx.Shader, error) {
 		return nil, err
 	}
 
+	// Load the optional GLSL geometry shader source file.
+	geom, err := ioutil.ReadFile(basePath + ".geom")
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
 	// Create the new GLSL shader with the filename as the shader name.
 	shader := gfx.NewShader(filepath.Base(basePath))
 	shader.GLSL = &gfx.GLSLSources{
 		Vertex:   vert,
 		Fragment: frag,
+		Geometry: geom,
 	}
 	return shader, nil
 }
@@ -0,0 +1,204 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"time"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+// Particle represents a single particle of a particle system.
+type Particle struct {
+	// The position of the particle.
+	Position lmath.Vec3
+
+	// The velocity of the particle, in units per second.
+	Velocity lmath.Vec3
+
+	// The color of the particle.
+	Color gfx.Color
+
+	// The remaining life of the particle, once it reaches zero the particle
+	// dies and is removed from the particle system.
+	Life time.Duration
+}
+
+// ParticleSystem maintains a fixed-size pool of particles, and builds a mesh
+// for drawing them. Particles are added to the system via Emit, are moved by
+// Update (typically with the delta time of a clock.Clock), and are removed
+// once their life expires:
+//
+//	obj.Meshes = []*gfx.Mesh{ps.Mesh()}
+//
+//	// Each frame:
+//	ps.Update(clock.Delta())
+//	ps.Mesh() // Rebuilds the mesh of obj.
+//	canvas.Draw(canvas.Bounds(), obj, camera)
+//
+// The mesh has a Stream usage hint, and it's data slices are reused each time
+// it is rebuilt, such that updating the particles does not allocate memory.
+//
+// A particle system and it's methods are not safe for access from multiple
+// goroutines concurrently.
+type ParticleSystem struct {
+	// The particles that are currently alive, in no particular order. The
+	// capacity of the slice is the maximum number of particles.
+	Particles []Particle
+
+	// The acceleration applied to the velocity of each particle, in units per
+	// second squared (e.g. gravity).
+	Acceleration lmath.Vec3
+
+	// If true then each particle is drawn as a square quad (two triangles)
+	// centered at it's position, otherwise each particle is drawn as a single
+	// point. See also Size, Right and Up.
+	Quads bool
+
+	// The size of each quad.
+	Size float64
+
+	// The axis along which the width and height of each quad lie. To make the
+	// quads face the camera (i.e. billboards), set them to the camera's right
+	// and up vectors before calling Mesh.
+	Right, Up lmath.Vec3
+
+	mesh *gfx.Mesh
+}
+
+// Emit adds the given particle to the system. If the maximum number of
+// particles are already alive then the particle is dropped and false is
+// returned.
+func (p *ParticleSystem) Emit(particle Particle) bool {
+	if len(p.Particles) == cap(p.Particles) {
+		return false
+	}
+	p.Particles = append(p.Particles, particle)
+	return true
+}
+
+// Update advances the particles of the system by the given delta time, that is
+// each particle's life is reduced, it's velocity is accelerated, and it is
+// moved by it's velocity. Particles whose life expires are removed.
+func (p *ParticleSystem) Update(dt time.Duration) {
+	var (
+		secs = dt.Seconds()
+		dv   = p.Acceleration.MulScalar(secs)
+	)
+	for i := 0; i < len(p.Particles); {
+		particle := &p.Particles[i]
+		particle.Life -= dt
+		if particle.Life <= 0 {
+			// Replace the dead particle with the last one.
+			last := len(p.Particles) - 1
+			p.Particles[i] = p.Particles[last]
+			p.Particles = p.Particles[:last]
+			continue
+		}
+		particle.Velocity = particle.Velocity.Add(dv)
+		particle.Position = particle.Position.Add(particle.Velocity.MulScalar(secs))
+		i++
+	}
+}
+
+// Mesh rebuilds and returns the mesh of the particle system, which contains
+// the vertices and colors of the particles that are currently alive. The same
+// mesh is returned each time, with it's changed data marked as such (so that
+// it is re-uploaded when next drawn).
+//
+// If p.Quads is true the mesh is an indexed triangle mesh with four vertices
+// (wound counter-clockwise when viewed from the side Right and Up are seen as
+// right and up) and a single texture coordinate set, otherwise it is a mesh
+// of points.
+func (p *ParticleSystem) Mesh() *gfx.Mesh {
+	m := p.mesh
+	m.Vertices = m.Vertices[:0]
+	m.Colors = m.Colors[:0]
+	m.VerticesChanged = true
+	m.ColorsChanged = true
+
+	if !p.Quads {
+		if m.Primitive != gfx.Points {
+			m.Primitive = gfx.Points
+			m.Indices = nil
+			m.IndicesChanged = true
+			m.TexCoords = nil
+		}
+		for _, particle := range p.Particles {
+			m.Vertices = append(m.Vertices, gfx.ConvertVec3(particle.Position))
+			m.Colors = append(m.Colors, particle.Color)
+		}
+		m.CalculateBounds()
+		return m
+	}
+
+	if m.Primitive != gfx.Triangles || len(m.TexCoords) != 1 {
+		m.Primitive = gfx.Triangles
+		m.TexCoords = []gfx.TexCoordSet{{}}
+	}
+	tcs := &m.TexCoords[0]
+	tcs.Slice = tcs.Slice[:0]
+	tcs.Changed = true
+
+	// The indices only change if the number of particles does.
+	nIndices := 6 * len(p.Particles)
+	if len(m.Indices) != nIndices {
+		m.Indices = m.Indices[:0]
+		for i := 0; i < len(p.Particles); i++ {
+			v := uint32(4 * i)
+			m.Indices = append(m.Indices, v, v+1, v+2, v, v+2, v+3)
+		}
+		m.IndicesChanged = true
+	}
+
+	var (
+		right = p.Right.MulScalar(p.Size / 2)
+		up    = p.Up.MulScalar(p.Size / 2)
+	)
+	for _, particle := range p.Particles {
+		pos := particle.Position
+		m.Vertices = append(m.Vertices,
+			gfx.ConvertVec3(pos.Sub(right).Sub(up)), // Bottom-left.
+			gfx.ConvertVec3(pos.Add(right).Sub(up)), // Bottom-right.
+			gfx.ConvertVec3(pos.Add(right).Add(up)), // Top-right.
+			gfx.ConvertVec3(pos.Sub(right).Add(up)), // Top-left.
+		)
+		m.Colors = append(m.Colors, particle.Color, particle.Color, particle.Color, particle.Color)
+		tcs.Slice = append(tcs.Slice,
+			gfx.TexCoord{U: 0, V: 1},
+			gfx.TexCoord{U: 1, V: 1},
+			gfx.TexCoord{U: 1, V: 0},
+			gfx.TexCoord{U: 0, V: 0},
+		)
+	}
+	m.CalculateBounds()
+	return m
+}
+
+// NewParticleSystem returns a new particle system which holds at most max
+// particles, with:
+//
+//	Size == 1
+//	Right == lmath.Vec3{X: 1}
+//	Up == lmath.Vec3{Z: 1}
+//
+// Such that quads face the -Y axis (i.e. a camera looking forward). The data
+// slices of the mesh are allocated up front.
+func NewParticleSystem(max int) *ParticleSystem {
+	m := gfx.NewMesh()
+	m.Usage = gfx.Stream
+	m.KeepDataOnLoad = true
+	m.Primitive = gfx.Points
+	m.Vertices = make([]gfx.Vec3, 0, 4*max)
+	m.Colors = make([]gfx.Color, 0, 4*max)
+	return &ParticleSystem{
+		Particles: make([]Particle, 0, max),
+		Size:      1,
+		Right:     lmath.Vec3{X: 1},
+		Up:        lmath.Vec3{Z: 1},
+		mesh:      m,
+	}
+}
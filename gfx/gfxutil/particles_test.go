@@ -0,0 +1,88 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+func TestParticleSystemUpdate(t *testing.T) {
+	ps := NewParticleSystem(2)
+	ps.Acceleration = lmath.Vec3{Z: -10}
+	if !ps.Emit(Particle{Velocity: lmath.Vec3{X: 1}, Life: 2 * time.Second}) {
+		t.Fatal("Emit: particle dropped")
+	}
+	if !ps.Emit(Particle{Life: time.Second / 2}) {
+		t.Fatal("Emit: particle dropped")
+	}
+	if ps.Emit(Particle{Life: time.Second}) {
+		t.Fatal("Emit: expected particle to be dropped when full")
+	}
+
+	ps.Update(time.Second)
+	if len(ps.Particles) != 1 {
+		t.Fatalf("got %d particles, want 1", len(ps.Particles))
+	}
+	p := ps.Particles[0]
+	if want := (lmath.Vec3{X: 1, Z: -10}); !p.Position.Equals(want) {
+		t.Fatalf("got position %v, want %v", p.Position, want)
+	}
+	if p.Life != time.Second {
+		t.Fatalf("got life %v, want %v", p.Life, time.Second)
+	}
+	if !ps.Emit(Particle{Life: time.Second}) {
+		t.Fatal("Emit: particle dropped after one died")
+	}
+}
+
+func TestParticleSystemMesh(t *testing.T) {
+	ps := NewParticleSystem(4)
+	ps.Emit(Particle{Position: lmath.Vec3{X: 1}, Color: gfx.Color{R: 1, A: 1}, Life: time.Second})
+	ps.Emit(Particle{Position: lmath.Vec3{Y: 1}, Color: gfx.Color{G: 1, A: 1}, Life: time.Second})
+
+	m := ps.Mesh()
+	if m.Primitive != gfx.Points || len(m.Vertices) != 2 || len(m.Colors) != 2 {
+		t.Fatal("expected a point mesh with 2 vertices and colors")
+	}
+	if m.Vertices[1] != (gfx.Vec3{Y: 1}) || m.Colors[1] != (gfx.Color{G: 1, A: 1}) {
+		t.Fatal("got vertex", m.Vertices[1], "color", m.Colors[1])
+	}
+	if m.EffectiveUsage() != gfx.Stream || !m.KeepDataOnLoad {
+		t.Fatal("expected a Stream mesh which keeps it's data on load")
+	}
+
+	// Rebuilding the mesh should reuse it's data slices.
+	vertices := &m.Vertices[0]
+	ps.Quads = true
+	ps.Size = 2
+	if ps.Mesh() != m {
+		t.Fatal("Mesh returned a different mesh")
+	}
+	if m.Primitive != gfx.Triangles || len(m.Vertices) != 8 || len(m.Indices) != 12 {
+		t.Fatal("expected a triangle mesh with 8 vertices and 12 indices")
+	}
+	if &m.Vertices[0] != vertices {
+		t.Fatal("vertices slice was reallocated")
+	}
+	if len(m.TexCoords) != 1 || len(m.TexCoords[0].Slice) != 8 {
+		t.Fatal("expected 8 texture coordinates")
+	}
+	want := []gfx.Vec3{{0, 0, -1}, {2, 0, -1}, {2, 0, 1}, {0, 0, 1}}
+	for i, v := range want {
+		if m.Vertices[i] != v {
+			t.Fatal("got vertices", m.Vertices[:4], "want", want)
+		}
+	}
+
+	ps.Update(time.Second)
+	ps.Mesh()
+	if len(m.Vertices) != 0 || len(m.Indices) != 0 || !m.IndicesChanged {
+		t.Fatal("expected an empty mesh once all particles died")
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"fmt"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+// Pass is a single named render pass of a graph, it clears and draws to it's
+// target canvas.
+type Pass struct {
+	// The name of the pass, which other passes refer to in their After field.
+	Name string
+
+	// The canvas that the pass draws to, e.g. a render-to-texture canvas (see
+	// gfx.Device.RenderToTexture) or the device itself.
+	Target gfx.Canvas
+
+	// The names of the passes that must be executed before this one, e.g. the
+	// passes that draw to the textures which this pass samples from.
+	After []string
+
+	// The clear operation performed on the target canvas before drawing, if
+	// any of it's ClearColor, ClearDepth, or ClearStencil fields are true. If
+	// it's Rect field is empty, the entire bounds of the canvas are cleared.
+	Clear gfx.ClearRect
+
+	// The function called to draw the pass to the target canvas, or nil if
+	// the pass only clears the canvas.
+	Draw func(c gfx.Canvas)
+
+	// If true, the target canvas is rendered (see gfx.Canvas.Render) after
+	// the pass is drawn. This is needed for render-to-texture canvases, such
+	// that their textures are ready to be sampled by later passes.
+	Render bool
+}
+
+// Graph is a set of render passes which are executed in dependency order each
+// frame, e.g. for a simple pipeline with shadows and post-processing:
+//
+//	g := &gfxutil.Graph{}
+//	g.Add(&gfxutil.Pass{
+//		Name:   "shadow",
+//		Target: shadowCanvas,
+//		Clear:  gfx.ClearRect{ClearDepth: true, Depth: 1.0},
+//		Draw:   drawShadowCasters,
+//		Render: true,
+//	}, &gfxutil.Pass{
+//		Name:   "scene",
+//		Target: sceneCanvas,
+//		After:  []string{"shadow"},
+//		Clear:  gfx.ClearRect{ClearColor: true, ClearDepth: true, Depth: 1.0},
+//		Draw:   drawScene,
+//		Render: true,
+//	}, &gfxutil.Pass{
+//		Name:   "post",
+//		Target: device,
+//		After:  []string{"scene"},
+//		Draw:   drawPostProcess,
+//	})
+//
+//	// Each frame:
+//	if err := g.Execute(); err != nil {
+//		log.Fatal(err)
+//	}
+//	device.Render()
+//
+// Passes without a dependency between them are executed in the order they were
+// added to the graph.
+//
+// A graph and it's methods are not safe for access from multiple goroutines
+// concurrently.
+type Graph struct {
+	// The passes of the graph, in no particular order other than that of
+	// passes without a dependency between them.
+	Passes []*Pass
+
+	order []*Pass
+	state map[*Pass]int
+}
+
+// Add adds the given passes to the graph.
+func (g *Graph) Add(passes ...*Pass) {
+	g.Passes = append(g.Passes, passes...)
+}
+
+// Pass returns the pass with the given name, or nil if there is no such pass.
+func (g *Graph) Pass(name string) *Pass {
+	for _, p := range g.Passes {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Order returns the passes of the graph in the order they are executed, such
+// that each pass comes after the ones named in it's After field. An error is
+// returned if a pass depends on one that does not exist, or if the passes
+// depend on each other in a cycle.
+//
+// The returned slice is only valid until the next call to Order or Execute.
+func (g *Graph) Order() ([]*Pass, error) {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	if g.state == nil {
+		g.state = make(map[*Pass]int, len(g.Passes))
+	}
+	for p := range g.state {
+		delete(g.state, p)
+	}
+	g.order = g.order[:0]
+
+	var visit func(p *Pass) error
+	visit = func(p *Pass) error {
+		switch g.state[p] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("gfxutil: render pass %q depends on itself (dependency cycle)", p.Name)
+		}
+		g.state[p] = visiting
+		for _, name := range p.After {
+			dep := g.Pass(name)
+			if dep == nil {
+				return fmt.Errorf("gfxutil: render pass %q depends on unknown pass %q", p.Name, name)
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		g.state[p] = visited
+		g.order = append(g.order, p)
+		return nil
+	}
+	for _, p := range g.Passes {
+		if err := visit(p); err != nil {
+			return nil, err
+		}
+	}
+	return g.order, nil
+}
+
+// Execute executes each pass of the graph in dependency order (see Order). For
+// each pass the target canvas is cleared, the pass is drawn, and then the
+// canvas is rendered, according to the pass's settings.
+//
+// If the passes cannot be ordered, an error is returned and no pass is
+// executed.
+func (g *Graph) Execute() error {
+	order, err := g.Order()
+	if err != nil {
+		return err
+	}
+	for _, p := range order {
+		c := p.Clear
+		if c.ClearColor || c.ClearDepth || c.ClearStencil {
+			if c.Rect.Empty() {
+				c.Rect = p.Target.Bounds()
+			}
+			p.Target.ClearRects([]gfx.ClearRect{c})
+		}
+		if p.Draw != nil {
+			p.Draw(p.Target)
+		}
+		if p.Render {
+			p.Target.Render()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"image"
+	"reflect"
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+// passCanvas records the operations performed on it into a shared log.
+type passCanvas struct {
+	gfx.Canvas
+	name string
+	log  *[]string
+}
+
+func (c *passCanvas) Bounds() image.Rectangle {
+	return image.Rect(0, 0, 64, 64)
+}
+
+func (c *passCanvas) ClearRects(rects []gfx.ClearRect) {
+	for _, r := range rects {
+		*c.log = append(*c.log, c.name+".clear"+r.Rect.String())
+	}
+}
+
+func (c *passCanvas) Render() {
+	*c.log = append(*c.log, c.name+".render")
+}
+
+func TestGraphExecute(t *testing.T) {
+	var log []string
+	canvas := func(name string) *passCanvas {
+		return &passCanvas{Canvas: gfx.Nil(), name: name, log: &log}
+	}
+	draw := func(c gfx.Canvas) {
+		log = append(log, c.(*passCanvas).name+".draw")
+	}
+
+	g := &Graph{}
+	g.Add(&Pass{
+		Name:   "post",
+		Target: canvas("device"),
+		After:  []string{"scene"},
+		Draw:   draw,
+	}, &Pass{
+		Name:   "scene",
+		Target: canvas("scene"),
+		After:  []string{"shadow"},
+		Clear:  gfx.ClearRect{ClearColor: true, Rect: image.Rect(0, 0, 8, 8)},
+		Draw:   draw,
+		Render: true,
+	}, &Pass{
+		Name:   "shadow",
+		Target: canvas("shadow"),
+		Clear:  gfx.ClearRect{ClearDepth: true, Depth: 1.0},
+		Draw:   draw,
+		Render: true,
+	})
+	if err := g.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"shadow.clear(0,0)-(64,64)",
+		"shadow.draw",
+		"shadow.render",
+		"scene.clear(0,0)-(8,8)",
+		"scene.draw",
+		"scene.render",
+		"device.draw",
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("got %q\nwant %q", log, want)
+	}
+
+	// Dependency cycles and unknown passes are errors, and nothing is
+	// executed.
+	log = nil
+	g.Pass("shadow").After = []string{"post"}
+	if _, err := g.Order(); err == nil {
+		t.Fatal("expected an error for a dependency cycle")
+	}
+	g.Pass("shadow").After = []string{"missing"}
+	if err := g.Execute(); err == nil {
+		t.Fatal("expected an error for an unknown pass")
+	}
+	if len(log) != 0 {
+		t.Fatal("passes were executed despite an error:", log)
+	}
+}
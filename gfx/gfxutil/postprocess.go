@@ -0,0 +1,102 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import "github.com/qmcloud/engine/gfx"
+
+// postProcessVert is the vertex shader used by PostProcess. It passes the
+// vertices of the fullscreen quad straight through (they are already in
+// normalized device coordinates), and flips the V texture coordinate because
+// render-to-texture images are stored bottom-up.
+var postProcessVert = []byte(`
+#version 120
+
+attribute vec3 Vertex;
+attribute vec2 TexCoord0;
+
+varying vec2 tc0;
+
+void main()
+{
+	tc0 = vec2(TexCoord0.x, 1.0 - TexCoord0.y);
+	gl_Position = vec4(Vertex.xy, 0.0, 1.0);
+}
+`)
+
+// FullscreenQuad returns a new mesh made up of two triangles which cover the
+// entire screen when their vertices are used directly as normalized device
+// coordinates, i.e. a vertex shader like:
+//
+//	gl_Position = vec4(Vertex.xy, 0.0, 1.0);
+//
+// The vertices lie in the XY plane (from -1 to +1 along both axis) and are
+// wound counter-clockwise. A single texture coordinate set is provided which
+// follows the usual convention of V=0 being the top of the texture image, such
+// that a texture loaded from an image appears upright.
+func FullscreenQuad() *gfx.Mesh {
+	m := gfx.NewMesh()
+	m.Vertices = append(m.Vertices,
+		// Bottom-right triangle.
+		gfx.Vec3{X: -1, Y: -1},
+		gfx.Vec3{X: 1, Y: -1},
+		gfx.Vec3{X: 1, Y: 1},
+
+		// Top-left triangle.
+		gfx.Vec3{X: -1, Y: -1},
+		gfx.Vec3{X: 1, Y: 1},
+		gfx.Vec3{X: -1, Y: 1},
+	)
+	m.TexCoords = []gfx.TexCoordSet{{
+		Slice: []gfx.TexCoord{
+			// Bottom-right triangle.
+			{U: 0, V: 1},
+			{U: 1, V: 1},
+			{U: 1, V: 0},
+
+			// Top-left triangle.
+			{U: 0, V: 1},
+			{U: 1, V: 0},
+			{U: 0, V: 0},
+		},
+	}}
+	return m
+}
+
+// PostProcess returns a new object which performs a post-processing pass when
+// drawn (with a nil camera) onto a canvas. The object draws a FullscreenQuad
+// using the given GLSL fragment shader, and has the input texture as it's only
+// texture, which is typically the color texture of a render-to-texture canvas.
+//
+// The fragment shader receives the texture as Texture0, and the texture
+// coordinate (already flipped for render-to-texture images) as tc0:
+//
+//	#version 120
+//
+//	varying vec2 tc0;
+//
+//	uniform sampler2D Texture0;
+//
+//	void main()
+//	{
+//		gl_FragColor = texture2D(Texture0, tc0);
+//	}
+//
+// Depth testing and depth writing are disabled in the object's state.
+func PostProcess(fragment []byte, input *gfx.Texture) *gfx.Object {
+	shader := gfx.NewShader("PostProcess")
+	shader.GLSL = &gfx.GLSLSources{
+		Vertex:   postProcessVert,
+		Fragment: fragment,
+	}
+
+	o := gfx.NewObject()
+	o.State = gfx.NewState()
+	o.State.DepthTest = false
+	o.State.DepthWrite = false
+	o.Shader = shader
+	o.Textures = []*gfx.Texture{input}
+	o.Meshes = []*gfx.Mesh{FullscreenQuad()}
+	return o
+}
@@ -0,0 +1,33 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+func TestFullscreenQuad(t *testing.T) {
+	m := FullscreenQuad()
+	if len(m.Vertices) != 6 || len(m.TexCoords) != 1 || len(m.TexCoords[0].Slice) != 6 {
+		t.Fatal("expected 6 vertices and a single set of 6 texture coordinates")
+	}
+	for i := 0; i < len(m.Vertices); i += 3 {
+		// Counter-clockwise winding has a positive signed area.
+		a, b, c := m.Vertices[i], m.Vertices[i+1], m.Vertices[i+2]
+		if area := (b.X-a.X)*(c.Y-a.Y) - (c.X-a.X)*(b.Y-a.Y); area <= 0 {
+			t.Fatal("triangle", i/3, "is not wound counter-clockwise")
+		}
+	}
+	for i, v := range m.Vertices {
+		// Top-left texture origin: U follows X, V is inverse to Y.
+		tc := m.TexCoords[0].Slice[i]
+		want := gfx.TexCoord{U: (v.X + 1) / 2, V: (1 - v.Y) / 2}
+		if tc != want {
+			t.Fatal("vertex", v, "got texture coordinate", tc, "want", want)
+		}
+	}
+}
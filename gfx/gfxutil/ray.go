@@ -0,0 +1,107 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"math"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+// RayBounds tests for intersection of the ray, starting at origin and going in
+// the direction dir, against the axis-aligned bounding box b. It is typically
+// used along with camera.ScreenToRay for picking objects with the mouse.
+//
+// If the ray hits the box, dist is the distance along the ray to the nearest
+// hit (in units of dir, which is the actual distance when dir is normalized).
+// If the origin is inside of the box, dist is zero.
+func RayBounds(origin, dir lmath.Vec3, b gfx.Bounds) (hit bool, dist float64) {
+	var (
+		o    = [3]float64{origin.X, origin.Y, origin.Z}
+		d    = [3]float64{dir.X, dir.Y, dir.Z}
+		bMin = [3]float64{b.Min.X, b.Min.Y, b.Min.Z}
+		bMax = [3]float64{b.Max.X, b.Max.Y, b.Max.Z}
+	)
+	tMin, tMax := math.Inf(-1), math.Inf(1)
+	for axis := 0; axis < 3; axis++ {
+		if d[axis] == 0 {
+			// The ray is parallel to this slab, it misses unless the origin is
+			// already inside of it.
+			if o[axis] < bMin[axis] || o[axis] > bMax[axis] {
+				return false, 0
+			}
+			continue
+		}
+		t1 := (bMin[axis] - o[axis]) / d[axis]
+		t2 := (bMax[axis] - o[axis]) / d[axis]
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		tMin = math.Max(tMin, t1)
+		tMax = math.Min(tMax, t2)
+	}
+	if tMax < tMin || tMax < 0 {
+		return false, 0
+	}
+	return true, math.Max(tMin, 0)
+}
+
+// RayMesh tests for intersection of the ray, starting at origin and going in
+// the direction dir, against each triangle of the mesh. The triangles are
+// formed by the mesh's Indices (or it's Vertices, if it is not indexed).
+//
+// The ray must be in the local space of the mesh, i.e. if the mesh is drawn as
+// part of an object then the ray should first be converted using the object's
+// transform. The mesh's data must be available (see Mesh.KeepDataOnLoad) or
+// else nothing is hit.
+//
+// If the ray hits the mesh, dist is the distance along the ray to the nearest
+// hit (in units of dir, which is the actual distance when dir is normalized).
+func RayMesh(origin, dir lmath.Vec3, m *gfx.Mesh) (hit bool, dist float64) {
+	vertex := func(i int) lmath.Vec3 {
+		if m.Indices != nil {
+			return m.Vertices[m.Indices[i]].Vec3()
+		}
+		return m.Vertices[i].Vec3()
+	}
+	n := len(m.Vertices)
+	if m.Indices != nil {
+		n = len(m.Indices)
+	}
+	for i := 0; i+2 < n; i += 3 {
+		t, ok := rayTriangle(origin, dir, vertex(i), vertex(i+1), vertex(i+2))
+		if ok && (!hit || t < dist) {
+			hit, dist = true, t
+		}
+	}
+	return
+}
+
+// rayTriangle tests for intersection of the ray against the triangle (a, b, c)
+// using the Möller–Trumbore algorithm. Both sides of the triangle are hit.
+func rayTriangle(origin, dir, a, b, c lmath.Vec3) (t float64, ok bool) {
+	e1 := b.Sub(a)
+	e2 := c.Sub(a)
+	p := dir.Cross(e2)
+	det := e1.Dot(p)
+	if lmath.Equal(det, 0) {
+		// The ray is parallel to the triangle.
+		return 0, false
+	}
+	invDet := 1 / det
+	s := origin.Sub(a)
+	u := s.Dot(p) * invDet
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+	q := s.Cross(e1)
+	v := dir.Dot(q) * invDet
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+	t = e2.Dot(q) * invDet
+	return t, t >= 0
+}
@@ -0,0 +1,64 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+func TestRayBounds(t *testing.T) {
+	b := gfx.Bounds{
+		Min: lmath.Vec3{-1, -1, -1},
+		Max: lmath.Vec3{1, 1, 1},
+	}
+	tests := []struct {
+		origin, dir lmath.Vec3
+		hit         bool
+		dist        float64
+	}{
+		{lmath.Vec3{0, -5, 0}, lmath.Vec3{0, 1, 0}, true, 4},
+		{lmath.Vec3{0, 5, 0}, lmath.Vec3{0, 1, 0}, false, 0},
+		{lmath.Vec3{3, -5, 0}, lmath.Vec3{0, 1, 0}, false, 0},
+		{lmath.Vec3{0, 0, 0}, lmath.Vec3{1, 0, 0}, true, 0},
+		{lmath.Vec3{-5, -5, 0}, lmath.Vec3{1, 1, 0}, true, 4},
+	}
+	for _, tst := range tests {
+		hit, dist := RayBounds(tst.origin, tst.dir, b)
+		if hit != tst.hit || !lmath.Equal(dist, tst.dist) {
+			t.Errorf("RayBounds(%v, %v) = (%v, %v), want (%v, %v)", tst.origin, tst.dir, hit, dist, tst.hit, tst.dist)
+		}
+	}
+}
+
+func TestRayMesh(t *testing.T) {
+	// Two quads facing the -Y axis, at Y=2 and Y=4.
+	m := gfx.NewMesh()
+	for _, y := range []float32{4, 2} {
+		base := uint32(len(m.Vertices))
+		m.Vertices = append(m.Vertices,
+			gfx.Vec3{-1, y, -1},
+			gfx.Vec3{1, y, -1},
+			gfx.Vec3{1, y, 1},
+			gfx.Vec3{-1, y, 1},
+		)
+		m.Indices = append(m.Indices, base, base+1, base+2, base, base+2, base+3)
+	}
+
+	hit, dist := RayMesh(lmath.Vec3{0.5, 0, 0.5}, lmath.Vec3{0, 1, 0}, m)
+	if !hit || !lmath.Equal(dist, 2) {
+		t.Fatalf("RayMesh = (%v, %v), want (true, 2)", hit, dist)
+	}
+	hit, _ = RayMesh(lmath.Vec3{0.5, 0, 0.5}, lmath.Vec3{0, -1, 0}, m)
+	if hit {
+		t.Fatal("RayMesh hit behind the ray origin")
+	}
+	hit, _ = RayMesh(lmath.Vec3{2, 0, 0}, lmath.Vec3{0, 1, 0}, m)
+	if hit {
+		t.Fatal("RayMesh hit outside of the mesh")
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+// Layer is a single named layer of objects in a render list.
+type Layer struct {
+	// The name of the layer.
+	Name string
+
+	// The objects assigned to the layer, in no particular order.
+	Objects []*gfx.Object
+
+	// If true, the depth buffer is cleared before drawing the objects of this
+	// layer, such that they are drawn over the objects of previous layers
+	// (e.g. a user interface over the 3D scene).
+	ClearDepth bool
+
+	// If not nil, the camera used to draw the objects of this layer instead of
+	// the one given to RenderList.Draw (e.g. an orthographic camera for a user
+	// interface layer).
+	Camera gfx.Camera
+}
+
+// RenderList is an ordered list of named layers of objects. It draws each
+// layer in order, and the objects of each layer in the order needed for them
+// to appear correct:
+//
+//	r := gfxutil.NewRenderList("sky", "scene", "ui")
+//	r.Layer("ui").ClearDepth = true
+//	r.Add("scene", house, window)
+//	r.Add("ui", healthBar)
+//
+//	// Each frame:
+//	r.Draw(canvas, camera)
+//
+// Within a layer, opaque objects are drawn first and front-to-back (which is
+// efficient due to depth testing), then alpha-blended objects (those whose
+// State.AlphaMode is gfx.AlphaBlend) are drawn back-to-front (such that their
+// transparency appears correct). Both are sorted using ByDist, with the camera
+// as the target. Hidden objects are skipped.
+//
+// A render list and it's methods are not safe for access from multiple
+// goroutines concurrently.
+type RenderList struct {
+	// The layers of the render list, in the order they are drawn.
+	Layers []*Layer
+
+	opaque, transparent, sorted []*gfx.Object
+}
+
+// Layer returns the layer with the given name, or nil if there is no such
+// layer.
+func (r *RenderList) Layer(name string) *Layer {
+	for _, l := range r.Layers {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// Add assigns the given objects to the named layer. If there is no such layer
+// then a panic occurs.
+func (r *RenderList) Add(layer string, objs ...*gfx.Object) {
+	l := r.Layer(layer)
+	if l == nil {
+		panic(fmt.Sprintf("RenderList.Add: no such layer %q", layer))
+	}
+	l.Objects = append(l.Objects, objs...)
+}
+
+// Reset removes all of the objects from each layer of the render list, the
+// layers themselves are kept.
+func (r *RenderList) Reset() {
+	for _, l := range r.Layers {
+		for i := range l.Objects {
+			l.Objects[i] = nil
+		}
+		l.Objects = l.Objects[:0]
+	}
+}
+
+// Objects returns the visible objects of the given layer, in the order they
+// should be drawn as seen by the given camera (see the RenderList type for
+// details). The returned slice is only valid until the next call to Objects
+// or Draw.
+func (r *RenderList) Objects(l *Layer, cam gfx.Camera) []*gfx.Object {
+	r.opaque = r.opaque[:0]
+	r.transparent = r.transparent[:0]
+	for _, o := range l.Objects {
+		switch {
+		case o.Hidden:
+		case o.State != nil && o.State.AlphaMode == gfx.AlphaBlend:
+			r.transparent = append(r.transparent, o)
+		default:
+			r.opaque = append(r.opaque, o)
+		}
+	}
+
+	var target lmath.Vec3
+	if cam != nil {
+		t := cam.Transform()
+		target = t.ConvertPos(t.Pos(), gfx.ParentToWorld)
+	}
+	sort.Sort(sort.Reverse(ByDist{Objects: r.opaque, Target: target}))
+	sort.Sort(ByDist{Objects: r.transparent, Target: target})
+
+	r.sorted = append(r.sorted[:0], r.opaque...)
+	r.sorted = append(r.sorted, r.transparent...)
+	return r.sorted
+}
+
+// Draw draws each layer of the render list, in order, to the entire bounds of
+// the given canvas as seen by the given camera (unless the layer has it's own
+// camera).
+func (r *RenderList) Draw(c gfx.Canvas, cam gfx.Camera) {
+	bounds := c.Bounds()
+	for _, l := range r.Layers {
+		if l.ClearDepth {
+			c.ClearDepth(bounds, 1.0)
+		}
+		layerCam := cam
+		if l.Camera != nil {
+			layerCam = l.Camera
+		}
+		for _, o := range r.Objects(l, layerCam) {
+			c.Draw(bounds, o, layerCam)
+		}
+	}
+}
+
+// NewRenderList returns a new render list with empty layers of the given
+// names, in the order they are drawn.
+func NewRenderList(layers ...string) *RenderList {
+	r := &RenderList{
+		Layers: make([]*Layer, len(layers)),
+	}
+	for i, name := range layers {
+		r.Layers[i] = &Layer{Name: name}
+	}
+	return r
+}
@@ -0,0 +1,80 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"image"
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/camera"
+	"github.com/qmcloud/engine/lmath"
+)
+
+// drawCanvas records the objects drawn to it, and depth clears as nil.
+type drawCanvas struct {
+	gfx.Canvas
+	drawn []*gfx.Object
+}
+
+func (c *drawCanvas) ClearDepth(r image.Rectangle, depth float64) {
+	c.drawn = append(c.drawn, nil)
+}
+
+func (c *drawCanvas) Draw(r image.Rectangle, o *gfx.Object, cam gfx.Camera) {
+	c.drawn = append(c.drawn, o)
+}
+
+func TestRenderListDraw(t *testing.T) {
+	obj := func(y float64, blend, hidden bool) *gfx.Object {
+		o := gfx.NewObject()
+		o.State = gfx.NewState()
+		if blend {
+			o.State.AlphaMode = gfx.AlphaBlend
+		}
+		o.Hidden = hidden
+		o.Transform.SetPos(lmath.Vec3{Y: y})
+		return o
+	}
+	var (
+		nearOpaque = obj(1, false, false)
+		farOpaque  = obj(5, false, false)
+		nearBlend  = obj(2, true, false)
+		farBlend   = obj(4, true, false)
+		hidden     = obj(3, false, true)
+		ui         = obj(0, false, false)
+		cam        = camera.New(image.Rect(0, 0, 64, 64))
+		c          = &drawCanvas{Canvas: gfx.Nil()}
+		r          = NewRenderList("scene", "ui")
+	)
+	r.Layer("ui").ClearDepth = true
+	r.Add("scene", farBlend, nearOpaque, hidden, nearBlend, farOpaque)
+	r.Add("ui", ui)
+	r.Draw(c, cam)
+
+	want := []*gfx.Object{nearOpaque, farOpaque, farBlend, nearBlend, nil, ui}
+	if len(c.drawn) != len(want) {
+		t.Fatalf("got %d operations, want %d", len(c.drawn), len(want))
+	}
+	for i, o := range want {
+		if c.drawn[i] != o {
+			t.Fatalf("operation %d: got %p want %p", i, c.drawn[i], o)
+		}
+	}
+
+	r.Reset()
+	if len(r.Layers) != 2 || len(r.Layer("scene").Objects) != 0 {
+		t.Fatal("expected Reset to keep the layers, but remove their objects")
+	}
+}
+
+func TestRenderListAddPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	NewRenderList("scene").Add("ui", gfx.NewObject())
+}
@@ -11,6 +11,21 @@ import (
 	"github.com/qmcloud/engine/lmath"
 )
 
+// Visible appends each object in objs which is not hidden (see
+// gfx.Object.Hidden) to dst and returns the new slice, such that hidden
+// objects can be skipped before sorting, e.g.:
+//
+//	visible = gfxutil.Visible(visible[:0], scene)
+//	sort.Sort(gfxutil.ByDist{Objects: visible, Target: camPos})
+func Visible(dst, objs []*gfx.Object) []*gfx.Object {
+	for _, o := range objs {
+		if !o.Hidden {
+			dst = append(dst, o)
+		}
+	}
+	return dst
+}
+
 // ByDist sorts a list of graphics objects based on their distance away from
 // a target position (typically the camera). As such if the sorted objects are
 // drawn in order then they are drawn back-to-front (which is useful for
@@ -18,6 +33,8 @@ import (
 //
 // Using sort.Reverse this doubles as front-to-back sorting (which is useful
 // for drawing opaque objects efficiently due to depth testing).
+//
+// Hidden objects are sorted just like any others, see Visible to exclude them.
 type ByDist struct {
 	// The list of objects to sort.
 	Objects []*gfx.Object
@@ -26,6 +43,19 @@ type ByDist struct {
 	// each object's distance away from this position (typically this is the
 	// camera's position).
 	Target lmath.Vec3
+
+	// If true, the distance is measured to the center of each object's world
+	// bounds (see gfx.Object.WorldBounds) instead of to it's position, which
+	// accounts for meshes that are not centered around the object's origin.
+	UseBounds bool
+}
+
+// pos returns the world space position of the object, as used for sorting.
+func (b ByDist) pos(o *gfx.Object) lmath.Vec3 {
+	if b.UseBounds {
+		return lmath.Rect3(o.WorldBounds()).Center()
+	}
+	return o.Transform.ConvertPos(o.Transform.Pos(), gfx.ParentToWorld)
 }
 
 // Len implements the sort interface.
@@ -40,12 +70,9 @@ func (b ByDist) Swap(i, j int) {
 
 // Less implements the sort interface.
 func (b ByDist) Less(ii, jj int) bool {
-	i := b.Objects[ii].Transform
-	j := b.Objects[jj].Transform
-
-	// Convert each position to world space.
-	iPos := i.ConvertPos(i.Pos(), gfx.ParentToWorld)
-	jPos := j.ConvertPos(j.Pos(), gfx.ParentToWorld)
+	// Find each position in world space.
+	iPos := b.pos(b.Objects[ii])
+	jPos := b.pos(b.Objects[jj])
 
 	// Calculate the distance from each object to the target position.
 	iDist := iPos.Sub(b.Target).LengthSq()
@@ -71,6 +98,8 @@ func InsertionSort(data sort.Interface) {
 // graphics state in order to reduce graphics state changes and increase the
 // overall throughput when rendering several objects whose graphics state
 // differ.
+//
+// Hidden objects are sorted just like any others, see Visible to exclude them.
 type ByState []*gfx.Object
 
 // Len implements the sort interface.
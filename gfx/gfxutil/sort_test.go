@@ -13,6 +13,16 @@ import (
 	"github.com/qmcloud/engine/lmath"
 )
 
+func TestVisible(t *testing.T) {
+	a, b, c := gfx.NewObject(), gfx.NewObject(), gfx.NewObject()
+	b.Hidden = true
+
+	got := Visible(nil, []*gfx.Object{a, b, c})
+	if len(got) != 2 || got[0] != a || got[1] != c {
+		t.Fatal("got", got, "want", []*gfx.Object{a, c})
+	}
+}
+
 func TestSortByDist(t *testing.T) {
 	a := gfx.NewObject()
 	a.Transform.SetPos(lmath.Vec3{10, 10, 10})
@@ -51,6 +61,28 @@ func TestSortByDist(t *testing.T) {
 	}
 }
 
+func TestSortByDistBounds(t *testing.T) {
+	// Both objects are at the origin, but the mesh of b is far away from it.
+	near := gfx.NewMesh()
+	near.Vertices = []gfx.Vec3{{-1, -1, -1}, {1, 1, 1}}
+	far := gfx.NewMesh()
+	far.Vertices = []gfx.Vec3{{99, 99, 99}, {101, 101, 101}}
+
+	a := gfx.NewObject()
+	a.Meshes = []*gfx.Mesh{near}
+	b := gfx.NewObject()
+	b.Meshes = []*gfx.Mesh{far}
+
+	byDist := ByDist{
+		Objects:   []*gfx.Object{a, b},
+		UseBounds: true,
+	}
+	sort.Sort(byDist)
+	if byDist.Objects[0] != b {
+		t.Fatal("expected the object with far away bounds to sort first")
+	}
+}
+
 func sortByDist(shifts, amount int, b *testing.B, standard bool) {
 	b.StopTimer()
 	byDist := ByDist{
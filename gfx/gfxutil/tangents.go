@@ -0,0 +1,117 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"math"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+// ComputeTangents computes the per-vertex tangents of the triangle mesh m, for
+// normal mapping, using Lengyel's method. They are computed from the mesh's
+// vertices, normals, and first set of texture coordinates, and are stored in
+// m.Tangents (which is marked as changed, so that the mesh is re-uploaded).
+//
+// The tangents of each triangle sharing a vertex (via the mesh's Indices) are
+// averaged, and then made orthogonal to the vertex normal. The W component of
+// each tangent is the handedness of the tangent space (see gfx.Mesh.Tangents).
+//
+// Triangles whose texture coordinates are degenerate (i.e. they have no area
+// in texture space) do not contribute to the tangents of their vertices. A
+// vertex without any valid tangent is given an arbitrary one, perpendicular to
+// it's normal.
+//
+// If the mesh is not made of triangles, or it does not have as many normals
+// and texture coordinates as vertices, then it is left unchanged.
+func ComputeTangents(m *gfx.Mesh) {
+	n := len(m.Vertices)
+	if m.Primitive != gfx.Triangles || n == 0 || len(m.Normals) < n {
+		return
+	}
+	if len(m.TexCoords) == 0 || len(m.TexCoords[0].Slice) < n {
+		return
+	}
+	uvs := m.TexCoords[0].Slice
+
+	// Accumulate the tangent and bitangent of each triangle at each of it's
+	// vertices.
+	var (
+		tan   = make([]lmath.Vec3, n)
+		bitan = make([]lmath.Vec3, n)
+		count = n
+	)
+	if m.Indices != nil {
+		count = len(m.Indices)
+	}
+	index := func(i int) uint32 {
+		if m.Indices != nil {
+			return m.Indices[i]
+		}
+		return uint32(i)
+	}
+	for i := 0; i+2 < count; i += 3 {
+		i0, i1, i2 := index(i), index(i+1), index(i+2)
+		if int(i0) >= n || int(i1) >= n || int(i2) >= n {
+			continue
+		}
+		var (
+			p0 = m.Vertices[i0].Vec3()
+			e1 = m.Vertices[i1].Vec3().Sub(p0)
+			e2 = m.Vertices[i2].Vec3().Sub(p0)
+			s1 = float64(uvs[i1].U - uvs[i0].U)
+			t1 = float64(uvs[i1].V - uvs[i0].V)
+			s2 = float64(uvs[i2].U - uvs[i0].U)
+			t2 = float64(uvs[i2].V - uvs[i0].V)
+		)
+		det := s1*t2 - s2*t1
+		if lmath.Equal(det, 0) {
+			// Degenerate texture coordinates.
+			continue
+		}
+		r := 1 / det
+		sdir := e1.MulScalar(t2).Sub(e2.MulScalar(t1)).MulScalar(r)
+		tdir := e2.MulScalar(s1).Sub(e1.MulScalar(s2)).MulScalar(r)
+		for _, v := range [3]uint32{i0, i1, i2} {
+			tan[v] = tan[v].Add(sdir)
+			bitan[v] = bitan[v].Add(tdir)
+		}
+	}
+
+	// Orthogonalize each tangent against the normal (Gram-Schmidt) and
+	// calculate the handedness.
+	if cap(m.Tangents) >= n {
+		m.Tangents = m.Tangents[:n]
+	} else {
+		m.Tangents = make([]gfx.Vec4, n)
+	}
+	for i := range m.Tangents {
+		normal := m.Normals[i].Vec3()
+		t, ok := tan[i].Sub(normal.MulScalar(normal.Dot(tan[i]))).Normalized()
+		if !ok {
+			t = perpendicular(normal)
+		}
+		w := float32(1)
+		if normal.Cross(t).Dot(bitan[i]) < 0 {
+			w = -1
+		}
+		m.Tangents[i] = gfx.Vec4{X: float32(t.X), Y: float32(t.Y), Z: float32(t.Z), W: w}
+	}
+	m.TangentsChanged = true
+}
+
+// perpendicular returns an arbitrary unit vector perpendicular to n.
+func perpendicular(n lmath.Vec3) lmath.Vec3 {
+	// Cross with the axis least aligned with n.
+	axis := lmath.Vec3{X: 1}
+	if math.Abs(n.X) > math.Abs(n.Y) {
+		axis = lmath.Vec3{Y: 1}
+	}
+	if p, ok := n.Cross(axis).Normalized(); ok {
+		return p
+	}
+	return lmath.Vec3{X: 1}
+}
@@ -0,0 +1,88 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+// tangentQuad returns an indexed quad in the XZ plane facing -Y, whose
+// texture coordinates (U right, V down) may be mirrored horizontally.
+func tangentQuad(mirrored bool) *gfx.Mesh {
+	m := gfx.NewMesh()
+	m.Vertices = []gfx.Vec3{
+		{-1, 0, 1}, {-1, 0, -1}, {1, 0, -1}, {1, 0, 1},
+	}
+	m.Normals = []gfx.Vec3{
+		{0, -1, 0}, {0, -1, 0}, {0, -1, 0}, {0, -1, 0},
+	}
+	uvs := []gfx.TexCoord{
+		{U: 0, V: 0}, {U: 0, V: 1}, {U: 1, V: 1}, {U: 1, V: 0},
+	}
+	if mirrored {
+		for i := range uvs {
+			uvs[i].U = 1 - uvs[i].U
+		}
+	}
+	m.TexCoords = []gfx.TexCoordSet{{Slice: uvs}}
+	m.Indices = []uint32{0, 1, 2, 0, 2, 3}
+	return m
+}
+
+func TestComputeTangents(t *testing.T) {
+	m := tangentQuad(false)
+	ComputeTangents(m)
+	if !m.TangentsChanged || !m.HasChanged() {
+		t.Fatal("expected the tangents to be marked as changed")
+	}
+	if len(m.Tangents) != len(m.Vertices) {
+		t.Fatal("expected", len(m.Vertices), "tangents, got", len(m.Tangents))
+	}
+	for i, tan := range m.Tangents {
+		// U increases along +X, so the tangent must be +X for every shared
+		// vertex.
+		if tan.X != 1 || tan.Y != 0 || tan.Z != 0 {
+			t.Fatal("vertex", i, "got tangent", tan)
+		}
+	}
+
+	// Mirroring the texture coordinates flips both the tangent and the
+	// handedness.
+	mirrored := tangentQuad(true)
+	ComputeTangents(mirrored)
+	for i, tan := range mirrored.Tangents {
+		if tan.X != -1 || tan.W != -m.Tangents[i].W {
+			t.Fatal("mirrored vertex", i, "got tangent", tan, "want handedness", -m.Tangents[i].W)
+		}
+	}
+}
+
+func TestComputeTangentsDegenerate(t *testing.T) {
+	// Every vertex shares the same texture coordinate, so no triangle has a
+	// valid tangent: an arbitrary one perpendicular to the normal is used.
+	m := tangentQuad(false)
+	for i := range m.TexCoords[0].Slice {
+		m.TexCoords[0].Slice[i] = gfx.TexCoord{U: 0.5, V: 0.5}
+	}
+	ComputeTangents(m)
+	for i, tan := range m.Tangents {
+		n := m.Normals[i]
+		dot := tan.X*n.X + tan.Y*n.Y + tan.Z*n.Z
+		length := tan.X*tan.X + tan.Y*tan.Y + tan.Z*tan.Z
+		if dot != 0 || length < 0.99 || length > 1.01 {
+			t.Fatal("vertex", i, "got tangent", tan, "for normal", n)
+		}
+	}
+
+	// Meshes without texture coordinates are left unchanged.
+	m = tangentQuad(false)
+	m.TexCoords = nil
+	ComputeTangents(m)
+	if m.Tangents != nil || m.TangentsChanged {
+		t.Fatal("expected no tangents without texture coordinates")
+	}
+}
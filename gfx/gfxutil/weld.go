@@ -0,0 +1,174 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+// Weld merges the near-identical vertices of the mesh m, whose components each
+// differ by at most epsilon, and returns the number of vertices that were
+// removed. Vertices are only merged if all of their data (normals, texture
+// coordinates, colors, etc) is also near-identical, so that no information is
+// lost; custom vertex attributes must be exactly equal.
+//
+// If any vertices are removed, each data slice of the mesh is compacted
+// (marking it as changed) and the mesh's Indices are rebuilt to refer to the
+// remaining vertices. A mesh that was not indexed becomes an indexed one.
+//
+// Welding reduces the memory used by the mesh, and improves the efficiency of
+// the vertex cache of the graphics hardware when rendering it.
+func Weld(m *gfx.Mesh, epsilon float64) (removed int) {
+	n := len(m.Vertices)
+	if n == 0 {
+		return 0
+	}
+
+	// Vertices are bucketed into a grid of cells, epsilon in size, such that
+	// the vertices near to a vertex are found in it's own and it's neighboring
+	// cells.
+	type cell [3]int64
+	cellOf := func(v gfx.Vec3) cell {
+		if epsilon <= 0 {
+			return cell{
+				int64(math.Float32bits(v.X)),
+				int64(math.Float32bits(v.Y)),
+				int64(math.Float32bits(v.Z)),
+			}
+		}
+		return cell{
+			int64(math.Floor(float64(v.X) / epsilon)),
+			int64(math.Floor(float64(v.Y) / epsilon)),
+			int64(math.Floor(float64(v.Z) / epsilon)),
+		}
+	}
+	neighbors := 1
+	if epsilon <= 0 {
+		neighbors = 0
+	}
+
+	var (
+		remap = make([]uint32, n) // Old vertex index -> new vertex index.
+		kept  []uint32            // New vertex index -> old vertex index.
+		grid  = make(map[cell][]uint32)
+	)
+	for i := 0; i < n; i++ {
+		c := cellOf(m.Vertices[i])
+		match := -1
+	search:
+		for dx := -neighbors; dx <= neighbors; dx++ {
+			for dy := -neighbors; dy <= neighbors; dy++ {
+				for dz := -neighbors; dz <= neighbors; dz++ {
+					nc := cell{c[0] + int64(dx), c[1] + int64(dy), c[2] + int64(dz)}
+					for _, k := range grid[nc] {
+						if weldable(m, int(kept[k]), i, epsilon) {
+							match = int(k)
+							break search
+						}
+					}
+				}
+			}
+		}
+		if match < 0 {
+			match = len(kept)
+			kept = append(kept, uint32(i))
+			grid[c] = append(grid[c], uint32(match))
+		}
+		remap[i] = uint32(match)
+	}
+
+	removed = n - len(kept)
+	if removed == 0 {
+		return 0
+	}
+
+	// Rebuild the indices, and compact the data slices.
+	if m.Indices != nil {
+		for i, index := range m.Indices {
+			m.Indices[i] = remap[index]
+		}
+	} else {
+		m.Indices = remap
+	}
+	m.IndicesChanged = true
+	gatherMesh(m, kept)
+	return removed
+}
+
+// weldable tells if the vertices a and b of the mesh m, and all of their data,
+// are near-identical (i.e. each component differs by at most epsilon).
+func weldable(m *gfx.Mesh, a, b int, epsilon float64) bool {
+	near := func(x ...float32) bool {
+		half := len(x) / 2
+		for i := 0; i < half; i++ {
+			if math.Abs(float64(x[i]-x[half+i])) > epsilon {
+				return false
+			}
+		}
+		return true
+	}
+	vec3 := func(s []gfx.Vec3) bool {
+		if len(s) == 0 {
+			return true
+		}
+		x, y := s[a], s[b]
+		return near(x.X, x.Y, x.Z, y.X, y.Y, y.Z)
+	}
+	if !vec3(m.Vertices) || !vec3(m.Normals) || !vec3(m.Bary) {
+		return false
+	}
+	if len(m.Colors) > 0 {
+		x, y := m.Colors[a], m.Colors[b]
+		if !near(x.R, x.G, x.B, x.A, y.R, y.G, y.B, y.A) {
+			return false
+		}
+	}
+	vec4 := func(s []gfx.Vec4) bool {
+		if len(s) == 0 {
+			return true
+		}
+		x, y := s[a], s[b]
+		return near(x.X, x.Y, x.Z, x.W, y.X, y.Y, y.Z, y.W)
+	}
+	if !vec4(m.Tangents) || !vec4(m.BoneIndices) || !vec4(m.BoneWeights) {
+		return false
+	}
+	for _, set := range m.TexCoords {
+		if len(set.Slice) == 0 {
+			continue
+		}
+		x, y := set.Slice[a], set.Slice[b]
+		if !near(x.U, x.V, y.U, y.V) {
+			return false
+		}
+	}
+	for _, attrib := range m.Attribs {
+		if !attribEqual(reflect.ValueOf(attrib.Data), a, b) {
+			return false
+		}
+	}
+	return true
+}
+
+// attribEqual tells if the elements a and b of the per-vertex data slice v are
+// exactly equal. Array data slices (e.g. [][]gfx.Mat4, see gfx.VertexAttrib)
+// have each of their per-vertex slices compared.
+func attribEqual(v reflect.Value, a, b int) bool {
+	if v.Len() == 0 {
+		return true
+	}
+	if v.Type().Elem().Kind() == reflect.Slice {
+		for i := 0; i < v.Len(); i++ {
+			if !attribEqual(v.Index(i), a, b) {
+				return false
+			}
+		}
+		return true
+	}
+	return v.Index(a).Interface() == v.Index(b).Interface()
+}
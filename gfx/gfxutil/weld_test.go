@@ -0,0 +1,89 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfxutil
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+// weldQuad returns a non-indexed quad made of two triangles, whose shared
+// vertices are offset from each other by the given amount.
+func weldQuad(offset float32) *gfx.Mesh {
+	m := gfx.NewMesh()
+	m.Vertices = []gfx.Vec3{
+		{0, 0, 0}, {1, 0, 0}, {1, 0, 1},
+		{0, 0, 0}, {1, 0, 1 + offset}, {0, 0, 1},
+	}
+	m.TexCoords = []gfx.TexCoordSet{{Slice: []gfx.TexCoord{
+		{U: 0, V: 1}, {U: 1, V: 1}, {U: 1, V: 0},
+		{U: 0, V: 1}, {U: 1, V: 0}, {U: 0, V: 0},
+	}}}
+	return m
+}
+
+func TestWeld(t *testing.T) {
+	m := weldQuad(0)
+	if removed := Weld(m, 0); removed != 2 {
+		t.Fatal("expected 2 vertices removed, got", removed)
+	}
+	if len(m.Vertices) != 4 || len(m.TexCoords[0].Slice) != 4 {
+		t.Fatal("expected 4 vertices and texture coordinates")
+	}
+	want := []uint32{0, 1, 2, 0, 2, 3}
+	for i, index := range m.Indices {
+		if index != want[i] {
+			t.Fatal("got indices", m.Indices, "want", want)
+		}
+	}
+	if !m.IndicesChanged || !m.VerticesChanged || !m.TexCoords[0].Changed {
+		t.Fatal("expected the data slices to be marked as changed")
+	}
+	if m.TexCoords[0].Slice[3] != (gfx.TexCoord{U: 0, V: 0}) {
+		t.Fatal("texture coordinates are not consistent with the vertices")
+	}
+
+	// Welding again removes nothing.
+	if removed := Weld(m, 0); removed != 0 {
+		t.Fatal("expected no vertices removed, got", removed)
+	}
+}
+
+func TestWeldEpsilon(t *testing.T) {
+	if removed := Weld(weldQuad(0.001), 0); removed != 1 {
+		t.Fatal("expected 1 vertex removed without epsilon, got", removed)
+	}
+	if removed := Weld(weldQuad(0.001), 0.01); removed != 2 {
+		t.Fatal("expected 2 vertices removed with epsilon, got", removed)
+	}
+}
+
+func TestWeldAttributes(t *testing.T) {
+	// The shared vertices have different normals, so they must not be merged.
+	m := weldQuad(0)
+	m.Normals = []gfx.Vec3{
+		{0, -1, 0}, {0, -1, 0}, {0, -1, 0},
+		{0, 1, 0}, {0, 1, 0}, {0, 1, 0},
+	}
+	if removed := Weld(m, 0.01); removed != 0 {
+		t.Fatal("expected no vertices removed, got", removed)
+	}
+	if m.Indices != nil {
+		t.Fatal("expected the mesh to remain non-indexed")
+	}
+
+	// Custom attributes must be exactly equal.
+	m = weldQuad(0)
+	m.Attribs["Weight"] = gfx.VertexAttrib{
+		Data: []float32{0, 0, 0, 1, 0, 0},
+	}
+	if removed := Weld(m, 0); removed != 1 {
+		t.Fatal("expected 1 vertex removed, got", removed)
+	}
+	if data := m.Attribs["Weight"].Data.([]float32); len(data) != 5 {
+		t.Fatal("expected 5 attribute values, got", len(data))
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"errors"
+	"image"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/internal/gl/2.0/gl"
+	"github.com/qmcloud/engine/gfx/internal/glutil"
+	"github.com/qmcloud/engine/gfx/internal/util"
+)
+
+var (
+	// ErrBlitUnsupported is returned by Blit when the device does not support
+	// the GL_ARB_framebuffer_object extension.
+	ErrBlitUnsupported = errors.New("gl2: Blit requires GL_ARB_framebuffer_object")
+
+	// ErrBlitCanvas is returned by Blit when the destination canvas was not
+	// created by the same device as the source canvas.
+	ErrBlitCanvas = errors.New("gl2: Blit destination canvas belongs to a different device")
+
+	// ErrBlitMultisample is returned by Blit when the destination canvas is
+	// multisampled, which OpenGL cannot blit into.
+	ErrBlitMultisample = errors.New("gl2: Blit destination canvas is multisampled")
+
+	// ErrBlitFilter is returned by Blit when the filter is not one of Nearest
+	// or Linear.
+	ErrBlitFilter = errors.New("gl2: Blit filter must be Nearest or Linear")
+)
+
+// blitTarget is the source or destination framebuffer of a blit operation.
+type blitTarget struct {
+	// The framebuffer object ID, zero for the default framebuffer.
+	fbo uint32
+
+	// The number of samples of the color buffer, zero if not multisampled.
+	samples int
+
+	// The OpenGL internal format of the color buffer.
+	format int32
+
+	// The canvas, used to find the bounds of the framebuffer.
+	canvas gfx.Canvas
+
+	// The base canvas, and the pre and post functions of it's hooked methods,
+	// used to auto-clear the canvas (see device.autoClear).
+	base      *util.BaseCanvas
+	pre, post func()
+}
+
+// blitTarget returns the blit target for the given canvas, ok is false if the
+// canvas does not belong to this device. A swapper (e.g. the device of a
+// window) is a target for the device it currently wraps.
+func (r *device) blitTarget(c gfx.Canvas) (t blitTarget, ok bool) {
+	switch v := c.(type) {
+	case *util.Swapper:
+		return r.blitTarget(v.Device())
+	case *device:
+		if v != r {
+			return t, false
+		}
+		t.canvas = r
+		t.base = r.BaseCanvas
+		t.format = precisionColorFormat(r.Precision())
+		if r.sampleBuffers > 0 {
+			t.samples = int(r.samples)
+		}
+		return t, true
+	case *rttCanvas:
+		if v.r != r {
+			return t, false
+		}
+		t.fbo = v.fbo
+		t.canvas = v
+		t.base, t.pre, t.post = v.BaseCanvas, v.rttBegin, v.rttEnd
+		t.format = r.rttTexFormats[v.cfg.ColorFormat]
+		if v.rbColor != 0 {
+			t.samples = v.cfg.Samples
+		}
+		return t, true
+	}
+	return t, false
+}
+
+// precisionColorFormat returns the OpenGL internal format best matching the
+// color precision of a framebuffer.
+func precisionColorFormat(p gfx.Precision) int32 {
+	if p.AlphaBits > 0 {
+		return gl.RGBA8
+	}
+	return gl.RGB8
+}
+
+// blitRects holds the rectangles, in OpenGL framebuffer coordinates (i.e.
+// x0, y0, x1, y1 with the origin at the bottom-left), of a blit operation.
+type blitRects struct {
+	// The source and destination rectangles.
+	src, dst [4]int32
+
+	// Whether or not the multisampled source must first be resolved into a
+	// temporary single-sampled framebuffer of the given size. The resolve
+	// blit uses src as both it's source and destination rectangle, as is
+	// required for multisampled read framebuffers.
+	resolve    bool
+	tmpW, tmpH int32
+}
+
+// convertBlitRects converts the source and destination rectangles of a blit,
+// relative to the given bounds of each canvas, into OpenGL framebuffer
+// coordinates. A multisampled source may only be resolved into the exact same
+// rectangle, so if the image must be scaled or moved, it is first resolved
+// into a temporary framebuffer the size of the source canvas.
+func convertBlitRects(srcRect, dstRect, srcBounds, dstBounds image.Rectangle, samples int) blitRects {
+	sx, sy, sw, sh := glutil.ConvertRect(srcRect, srcBounds)
+	dx, dy, dw, dh := glutil.ConvertRect(dstRect, dstBounds)
+	b := blitRects{
+		src: [4]int32{int32(sx), int32(sy), int32(sx + sw), int32(sy + sh)},
+		dst: [4]int32{int32(dx), int32(dy), int32(dx + dw), int32(dy + dh)},
+	}
+	if samples > 0 && b.src != b.dst {
+		b.resolve = true
+		b.tmpW, b.tmpH = int32(srcBounds.Dx()), int32(srcBounds.Dy())
+	}
+	return b
+}
+
+// hookedBlit performs a blit from the src canvas to the dst canvas, both of
+// which must be owned by this device.
+func (r *device) hookedBlit(src, dst gfx.Canvas, srcRect, dstRect image.Rectangle, filter gfx.TexFilter) error {
+	// Blitting an empty rectangle is effectively no-op.
+	if srcRect.Empty() || dstRect.Empty() {
+		return nil
+	}
+	if !r.glArbFramebufferObject {
+		return ErrBlitUnsupported
+	}
+	if filter != gfx.Nearest && filter != gfx.Linear {
+		return ErrBlitFilter
+	}
+	s, ok := r.blitTarget(src)
+	if !ok {
+		return ErrBlitCanvas
+	}
+	d, ok := r.blitTarget(dst)
+	if !ok {
+		return ErrBlitCanvas
+	}
+	if d.samples > 0 {
+		return ErrBlitMultisample
+	}
+
+	// Blitting begins drawing to the destination canvas this frame, just
+	// like a draw operation would.
+	r.autoClear(d.base, d.pre, d.post)
+
+	ok = r.exec(func() bool {
+		r.graphicsState.Begin(r)
+
+		// The color write mask and scissor test both effect blitting.
+		r.graphicsState.ColorWrite(true, true, true, true)
+
+		srcBounds, dstBounds := s.canvas.Bounds(), d.canvas.Bounds()
+		b := convertBlitRects(srcRect, dstRect, srcBounds, dstBounds, s.samples)
+		readFBO := s.fbo
+
+		// Resolve into a temporary single-sampled framebuffer of the same
+		// format, if needed.
+		var tmpFBO, tmpRb uint32
+		if b.resolve {
+			gl.GenRenderbuffers(1, &tmpRb)
+			gl.BindRenderbuffer(gl.RENDERBUFFER, tmpRb)
+			gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, 0, uint32(s.format), b.tmpW, b.tmpH)
+			gl.BindRenderbuffer(gl.RENDERBUFFER, 0)
+
+			gl.GenFramebuffers(1, &tmpFBO)
+			gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, tmpFBO)
+			gl.FramebufferRenderbuffer(gl.DRAW_FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.RENDERBUFFER, tmpRb)
+			gl.BindFramebuffer(gl.READ_FRAMEBUFFER, s.fbo)
+
+			r.graphicsState.Scissor(srcBounds, srcRect)
+			gl.BlitFramebuffer(
+				b.src[0], b.src[1], b.src[2], b.src[3],
+				b.src[0], b.src[1], b.src[2], b.src[3],
+				gl.COLOR_BUFFER_BIT, gl.NEAREST,
+			)
+			readFBO = tmpFBO
+		}
+
+		// Perform the blit.
+		r.graphicsState.Scissor(dstBounds, dstRect)
+		gl.BindFramebuffer(gl.READ_FRAMEBUFFER, readFBO)
+		gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, d.fbo)
+		gl.BlitFramebuffer(
+			b.src[0], b.src[1], b.src[2], b.src[3],
+			b.dst[0], b.dst[1], b.dst[2], b.dst[3],
+			gl.COLOR_BUFFER_BIT, uint32(r.common.ConvertTexFilter(filter)),
+		)
+		gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+
+		// Free the temporary framebuffer, if any.
+		if tmpFBO != 0 {
+			gl.DeleteFramebuffers(1, &tmpFBO)
+			gl.DeleteRenderbuffers(1, &tmpRb)
+		}
+
+		r.queryYield()
+		return false
+	})
+	if !ok {
+		return ErrDestroyed
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"image"
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/internal/gl/2.0/gl"
+	"github.com/qmcloud/engine/gfx/internal/util"
+)
+
+func TestBlitTargetFormat(t *testing.T) {
+	r := &device{rttTexFormats: map[gfx.TexFormat]int32{
+		gfx.RGB:  gl.RGB8,
+		gfx.RGBA: gl.RGBA8,
+	}}
+	canvas := &rttCanvas{r: r, cfg: gfx.RTTConfig{ColorFormat: gfx.RGB}}
+	tgt, ok := r.blitTarget(canvas)
+	if !ok || tgt.format != gl.RGB8 {
+		t.Fatalf("got format %#x, want %#x (RGB8)", tgt.format, gl.RGB8)
+	}
+
+	if f := precisionColorFormat(gfx.Precision{RedBits: 8, GreenBits: 8, BlueBits: 8}); f != gl.RGB8 {
+		t.Fatalf("got format %#x, want %#x (RGB8)", f, gl.RGB8)
+	}
+	if f := precisionColorFormat(gfx.Precision{RedBits: 8, GreenBits: 8, BlueBits: 8, AlphaBits: 8}); f != gl.RGBA8 {
+		t.Fatalf("got format %#x, want %#x (RGBA8)", f, gl.RGBA8)
+	}
+}
+
+func TestBlitSwapper(t *testing.T) {
+	bounds := image.Rect(0, 0, 64, 64)
+	r := &device{
+		BaseCanvas:             &util.BaseCanvas{VBounds: bounds},
+		renderExec:             make(chan func() bool, 8),
+		glArbFramebufferObject: true,
+	}
+	rtt := &rttCanvas{r: r, BaseCanvas: &util.BaseCanvas{VBounds: bounds}}
+
+	// The device of a window is a swapper, wrapping the actual device.
+	tgt, ok := r.blitTarget(util.NewSwapper(r))
+	if !ok || tgt.canvas != r || tgt.fbo != 0 {
+		t.Fatalf("got target %+v, %v, want the default framebuffer of the device", tgt, ok)
+	}
+	if err := rtt.Blit(util.NewSwapper(r), bounds, bounds, gfx.Nearest); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.renderExec) != 1 {
+		t.Fatal("queued", len(r.renderExec), "operations, want 1")
+	}
+
+	// A swapper of another device is still a different device.
+	other := util.NewSwapper(&device{BaseCanvas: &util.BaseCanvas{VBounds: bounds}})
+	if err := rtt.Blit(other, bounds, bounds, gfx.Nearest); err != ErrBlitCanvas {
+		t.Fatal("got", err, "want", ErrBlitCanvas)
+	}
+}
+
+func TestConvertBlitRects(t *testing.T) {
+	src := image.Rect(0, 0, 100, 50)
+	dst := image.Rect(0, 0, 200, 100)
+	tests := []struct {
+		srcRect, dstRect image.Rectangle
+		samples          int
+		want             blitRects
+	}{
+		// Y is flipped into OpenGL's bottom-left origin.
+		{
+			image.Rect(10, 0, 30, 10), image.Rect(0, 80, 20, 100), 0,
+			blitRects{src: [4]int32{10, 40, 30, 50}, dst: [4]int32{0, 0, 20, 20}},
+		},
+
+		// Multisampled sources are resolved in-place when the rectangles
+		// match.
+		{
+			image.Rect(10, 10, 30, 20), image.Rect(10, 60, 30, 70), 4,
+			blitRects{src: [4]int32{10, 30, 30, 40}, dst: [4]int32{10, 30, 30, 40}},
+		},
+
+		// Otherwise they are resolved into a temporary framebuffer the size
+		// of the source canvas, keeping the (non-zero) source offset such
+		// that the resolve blit's rectangles are identical.
+		{
+			image.Rect(10, 10, 30, 20), image.Rect(0, 0, 40, 20), 4,
+			blitRects{
+				src:     [4]int32{10, 30, 30, 40},
+				dst:     [4]int32{0, 80, 40, 100},
+				resolve: true,
+				tmpW:    100,
+				tmpH:    50,
+			},
+		},
+	}
+	for _, tst := range tests {
+		got := convertBlitRects(tst.srcRect, tst.dstRect, src, dst, tst.samples)
+		if got != tst.want {
+			t.Errorf("convertBlitRects(%v, %v, samples=%d)\ngot  %+v\nwant %+v", tst.srcRect, tst.dstRect, tst.samples, got, tst.want)
+		}
+	}
+}
+
+func TestBlitAutoClear(t *testing.T) {
+	bounds := image.Rect(0, 0, 64, 64)
+	r := &device{
+		BaseCanvas:             &util.BaseCanvas{VBounds: bounds},
+		renderExec:             make(chan func() bool, 8),
+		glArbFramebufferObject: true,
+	}
+	rtt := &rttCanvas{r: r, BaseCanvas: &util.BaseCanvas{VBounds: bounds}}
+	r.SetAutoClear(true)
+	rtt.SetAutoClear(true)
+
+	// Blitting into a canvas at the start of a frame auto-clears it first,
+	// such that the blit is not cleared by the next draw (or Render).
+	for i, dst := range []gfx.Canvas{r, rtt} {
+		src := gfx.Canvas(rtt)
+		if dst == rtt {
+			src = r
+		}
+		if err := src.Blit(dst, bounds, bounds, gfx.Nearest); err != nil {
+			t.Fatal(err)
+		}
+		if err := src.Blit(dst, bounds, bounds, gfx.Nearest); err != nil {
+			t.Fatal(err)
+		}
+		if n := len(r.renderExec); n != 3 {
+			t.Fatalf("canvas %d: queued %d operations, want 3 (one clear and two blits)", i, n)
+		}
+		for len(r.renderExec) > 0 {
+			<-r.renderExec
+		}
+	}
+	if clear, _, _ := r.BeginFrame(); clear {
+		t.Fatal("device frame was not begun by the blit")
+	}
+	if clear, _, _ := rtt.BeginFrame(); clear {
+		t.Fatal("render-to-texture frame was not begun by the blit")
+	}
+}
@@ -5,13 +5,16 @@
 package gl2
 
 import (
+	"context"
 	"fmt"
 	"image"
 	"io"
 	"log"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unsafe"
 
 	"github.com/qmcloud/engine/gfx"
 	"github.com/qmcloud/engine/gfx/clock"
@@ -34,11 +37,16 @@ type pendingQuery struct {
 // renderbuffers that should be free'd at the next available time.
 type rsrcManager struct {
 	sync.RWMutex
-	meshes        []*nativeMesh
-	shaders       []*nativeShader
-	textures      []uint32
-	fbos          []uint32
-	renderbuffers []uint32
+	meshes         []*nativeMesh
+	shaders        []*nativeShader
+	textures       []uint32
+	fbos           []uint32
+	renderbuffers  []uint32
+	uniformBuffers []uint32
+	syncs          []unsafe.Pointer
+
+	// The estimated memory in use by resources, see MemoryUsage.
+	mem memoryUsage
 }
 
 // freePending free's all of the pending resources.
@@ -68,6 +76,8 @@ func (r *rsrcManager) freePending() {
 	r.freeTextures()
 	r.freeFBOs()
 	r.freeRenderbuffers()
+	r.freeUniformBuffers()
+	r.freeSyncs()
 }
 
 // device implements the Device interface.
@@ -80,8 +90,10 @@ type device struct {
 	rsrcManager   *rsrcManager
 	graphicsState *graphicsState
 
-	// Render execution channel.
+	// Render execution channel, and it's buffer size (see the QueueSize
+	// option).
 	renderExec chan func() bool
+	queueSize  int
 
 	// The other shared device to be used for loading assets, or nil.
 	shared struct {
@@ -89,9 +101,26 @@ type device struct {
 		*device
 	}
 
+	// The error to return from New if the Share option was given an invalid
+	// device, or nil.
+	shareErr error
+
 	// Whether or not certain extensions we use are present or not.
 	glArbDebugOutput, glArbMultisample, glArbFramebufferObject,
-	glArbOcclusionQuery bool
+	glArbOcclusionQuery, glArbUniformBufferObject, glArbTimerQuery,
+	glArbSync bool
+
+	// Whether or not glMultiDrawArrays and glMultiDrawElements are present.
+	glMultiDraw bool
+
+	// Whether or not glPrimitiveRestartIndex is present.
+	glPrimitiveRestart bool
+
+	// Whether or not texture swizzling (GL_TEXTURE_SWIZZLE_RGBA) is present.
+	glTextureSwizzle bool
+
+	// Whether or not the video memory information extensions are present.
+	glNvxGpuMemoryInfo, glAtiMeminfo bool
 
 	// Number of multisampling samples, buffers.
 	samples, sampleBuffers int32
@@ -104,25 +133,77 @@ type device struct {
 	// free'd.
 	wantFree chan struct{}
 
-	// Structure used to manage pending occlusion queries.
+	// Structure used to manage pending occlusion and timer queries.
 	pending struct {
 		sync.Mutex
 		queries []pendingQuery
+		timers  []pendingTimer
+	}
+
+	// Rendering statistics of the frame being rendered, and the state of the
+	// last object drawn (used to count state changes). They are only touched
+	// inside renderExec.
+	stats          gfx.Stats
+	statsState     gfx.State
+	statsStateUsed bool
+
+	// Rendering statistics of the last frame rendered.
+	lastStats struct {
+		sync.RWMutex
+		gfx.Stats
 	}
 
+	// Scratch slices used to draw the ranges of a mesh. They are only touched
+	// inside renderExec.
+	multiFirst, multiCount []int32
+	multiOffsets           []unsafe.Pointer
+
+	// The ID of the active GPU timer query, or zero if there is none. It is
+	// only touched inside renderExec.
+	gpuTimer uint32
+
 	// RTT format lookups (from gfx formats to GL ones).
 	rttTexFormats map[gfx.TexFormat]int32
 	rttDSFormats  map[gfx.DSFormat]int32
 
+	// The depth range in use by the device, see the DepthRange option.
+	depthRange [2]float64
+
+	// Whether or not occlusion queries were disabled by the OcclusionQueries
+	// option.
+	noOcclusionQueries bool
+
+	// Whether or not objects are drawn as wireframes, see SetWireframe. It is
+	// only touched inside renderExec.
+	wireframe bool
+
+	// Functions to call at the start and end of each frame, see OnPreRender
+	// and OnPostRender. They are only touched inside renderExec.
+	preRender, postRender []func()
+
+	// Whether or not seamless cube map filtering was enabled by the
+	// SeamlessCubeMaps option.
+	seamlessCubeMaps bool
+
+	// Uniform buffer objects bound to each binding point during the current
+	// frame. It is only touched inside renderExec.
+	uniformBindings map[int]uint32
+
 	// If non-nil, then we are currently rendering to a texture. It is only
 	// touched inside renderExec.
 	rttCanvas *rttCanvas
 
-	// Channel to wait for a Render() call to finish.
-	renderComplete chan struct{}
-
 	// yieldExit signals to the yield goroutine that it should exit.
 	yieldExit chan struct{}
+
+	// destroyed is closed once the device is destroyed, such that operations
+	// waiting on the execution channel return instead of waiting forever.
+	destroyed   chan struct{}
+	destroyOnce sync.Once
+
+	// garbageInterval sends a new garbage interval to the yield goroutine,
+	// see SetGarbageInterval.
+	garbageInterval chan time.Duration
 }
 
 // Exec implements the Device interface.
@@ -140,32 +221,104 @@ func (r *device) Clock() *clock.Clock {
 
 // Clear implements the gfx.Canvas interface.
 func (r *device) Clear(rect image.Rectangle, bg gfx.Color) {
+	r.autoClear(r.BaseCanvas, nil, nil)
 	r.hookedClear(rect, bg, nil, nil)
 }
 
 // ClearDepth implements the gfx.Canvas interface.
 func (r *device) ClearDepth(rect image.Rectangle, depth float64) {
+	r.autoClear(r.BaseCanvas, nil, nil)
 	r.hookedClearDepth(rect, depth, nil, nil)
 }
 
 // ClearStencil implements the gfx.Canvas interface.
 func (r *device) ClearStencil(rect image.Rectangle, stencil int) {
+	r.autoClear(r.BaseCanvas, nil, nil)
 	r.hookedClearStencil(rect, stencil, nil, nil)
 }
 
+// ClearRects implements the gfx.Canvas interface.
+func (r *device) ClearRects(rects []gfx.ClearRect) {
+	r.autoClear(r.BaseCanvas, nil, nil)
+	r.hookedClearRects(rects, nil, nil)
+}
+
 // Draw implements the gfx.Canvas interface.
 func (r *device) Draw(rect image.Rectangle, o *gfx.Object, c gfx.Camera) {
+	r.autoClear(r.BaseCanvas, nil, nil)
 	r.hookedDraw(rect, o, c, nil, nil)
 }
 
+// DrawMulti implements the Device interface.
+func (r *device) DrawMulti(m *gfx.Mesh, ranges []gfx.MeshRange, s *gfx.State, shader *gfx.Shader, c gfx.Camera) {
+	if len(ranges) == 0 {
+		return
+	}
+	o := &gfx.Object{
+		State:     s,
+		Transform: gfx.NewTransform(),
+		Shader:    shader,
+		Meshes:    []*gfx.Mesh{m},
+	}
+	r.autoClear(r.BaseCanvas, nil, nil)
+	r.hookedDrawRanges(context.Background(), r.Bounds(), o, append([]gfx.MeshRange(nil), ranges...), c, nil, nil)
+}
+
+// DrawContext implements the Device interface.
+func (r *device) DrawContext(ctx context.Context, rect image.Rectangle, o *gfx.Object, c gfx.Camera) error {
+	r.autoClear(r.BaseCanvas, nil, nil)
+	return r.hookedDrawRanges(ctx, rect, o, nil, c, nil, nil)
+}
+
+// Blit implements the gfx.Canvas interface.
+func (r *device) Blit(dst gfx.Canvas, src, dstRect image.Rectangle, filter gfx.TexFilter) error {
+	return r.hookedBlit(r, dst, src, dstRect, filter)
+}
+
 // QueryWait implements the gfx.Canvas interface.
 func (r *device) QueryWait() {
 	r.hookedQueryWait(nil, nil)
 }
 
+// QueryPoll implements the gfx.Canvas interface.
+func (r *device) QueryPoll() (pending int) {
+	return r.hookedQueryPoll(nil, nil)
+}
+
 // Render implements the gfx.Canvas interface.
 func (r *device) Render() {
-	r.hookedRender(nil, nil)
+	r.RenderContext(context.Background())
+}
+
+// RenderContext implements the Device interface.
+func (r *device) RenderContext(ctx context.Context) error {
+	// Clear the canvas, even if nothing was drawn this frame.
+	r.autoClear(r.BaseCanvas, nil, nil)
+	begun, err := r.hookedRenderContext(ctx, r.preRenderHooks, r.postRenderHooks)
+	if !begun {
+		// The frame was abandoned, it's drawing continues in the next one.
+		return err
+	}
+
+	// The frame is (being) rendered, even if we stopped waiting for it.
+	r.EndFrame()
+	return err
+}
+
+// preRenderHooks calls the functions registered via OnPreRender. It is only
+// called inside renderExec.
+func (r *device) preRenderHooks() {
+	for _, f := range r.preRender {
+		f()
+	}
+}
+
+// postRenderHooks calls the functions registered via OnPostRender. It is only
+// called inside renderExec.
+func (r *device) postRenderHooks() {
+	for _, f := range r.postRender {
+		f()
+	}
 }
 
 // Info implements the gfx.Device interface.
@@ -173,6 +326,38 @@ func (r *device) Info() gfx.DeviceInfo {
 	return r.devInfo
 }
 
+// Stats implements the gfx.Device interface.
+func (r *device) Stats() gfx.Stats {
+	r.lastStats.RLock()
+	stats := r.lastStats.Stats
+	r.lastStats.RUnlock()
+	return stats
+}
+
+// publishStats makes the statistics of the frame that was just rendered
+// available via Stats, and resets them for the next frame.
+//
+// It may only be called on the render goroutine.
+func (r *device) publishStats() {
+	r.lastStats.Lock()
+	r.lastStats.Stats = r.stats
+	r.lastStats.Unlock()
+	r.stats = gfx.Stats{}
+	r.statsStateUsed = false
+}
+
+// countState counts a state change in the frame's statistics if the given
+// state differs from the one counted last.
+//
+// It may only be called on the render goroutine.
+func (r *device) countState(s *gfx.State) {
+	if !r.statsStateUsed || !s.Equal(&r.statsState) {
+		r.stats.StateChanges++
+		r.statsState = *s
+		r.statsStateUsed = true
+	}
+}
+
 // SetDebugOutput implements the Device interface.
 func (r *device) SetDebugOutput(w io.Writer) {
 	r.warner.RLock()
@@ -187,8 +372,29 @@ func (r *device) RestoreState() {
 
 // Destroy implements the Device interface.
 func (r *device) Destroy() {
-	// TODO(slimsag): free pending resources.
-	r.yieldExit <- struct{}{}
+	r.destroyOnce.Do(func() {
+		close(r.destroyed)
+
+		// TODO(slimsag): free pending resources.
+		r.yieldExit <- struct{}{}
+	})
+}
+
+// autoClear clears the entire canvas to it's clear color and depth, if
+// auto-clearing is enabled and drawing of the current frame has just begun.
+// The pre and post functions are those of the canvas's hooked methods.
+func (r *device) autoClear(b *util.BaseCanvas, pre, post func()) {
+	clear, bg, depth := b.BeginFrame()
+	if !clear {
+		return
+	}
+	r.hookedClearRects([]gfx.ClearRect{{
+		Rect:       b.Bounds(),
+		ClearColor: true,
+		Color:      bg,
+		ClearDepth: true,
+		Depth:      depth,
+	}}, pre, post)
 }
 
 // Implements gfx.Canvas interface.
@@ -197,7 +403,7 @@ func (r *device) hookedClear(rect image.Rectangle, bg gfx.Color, pre, post func(
 	if rect.Empty() {
 		return
 	}
-	r.renderExec <- func() bool {
+	r.exec(func() bool {
 		if pre != nil {
 			pre()
 		}
@@ -216,7 +422,7 @@ func (r *device) hookedClear(rect image.Rectangle, bg gfx.Color, pre, post func(
 			post()
 		}
 		return false
-	}
+	})
 }
 
 // Implements gfx.Canvas interface.
@@ -225,7 +431,7 @@ func (r *device) hookedClearDepth(rect image.Rectangle, depth float64, pre, post
 	if rect.Empty() {
 		return
 	}
-	r.renderExec <- func() bool {
+	r.exec(func() bool {
 		if pre != nil {
 			pre()
 		}
@@ -244,7 +450,7 @@ func (r *device) hookedClearDepth(rect image.Rectangle, depth float64, pre, post
 			post()
 		}
 		return false
-	}
+	})
 }
 
 // Implements gfx.Canvas interface.
@@ -253,7 +459,7 @@ func (r *device) hookedClearStencil(rect image.Rectangle, stencil int, pre, post
 	if rect.Empty() {
 		return
 	}
-	r.renderExec <- func() bool {
+	r.exec(func() bool {
 		if pre != nil {
 			pre()
 		}
@@ -272,12 +478,64 @@ func (r *device) hookedClearStencil(rect image.Rectangle, stencil int, pre, post
 			post()
 		}
 		return false
+	})
+}
+
+// Implements gfx.Canvas interface.
+func (r *device) hookedClearRects(rects []gfx.ClearRect, pre, post func()) {
+	// Copy the non-empty clear operations, as the caller may reuse the slice
+	// before the render loop gets to it.
+	var cpy []gfx.ClearRect
+	for _, c := range rects {
+		if !c.Rect.Empty() && (c.ClearColor || c.ClearDepth || c.ClearStencil) {
+			cpy = append(cpy, c)
+		}
 	}
+	if len(cpy) == 0 {
+		return
+	}
+	r.exec(func() bool {
+		if pre != nil {
+			pre()
+		}
+		r.graphicsState.Begin(r)
+
+		// The write masks effect the glClear calls below.
+		r.graphicsState.ColorWrite(true, true, true, true)
+		r.graphicsState.DepthWrite(true)
+		r.graphicsState.stencilMaskSeparate(0xFFFF, 0xFFFF)
+
+		// Perform clearing.
+		for _, c := range cpy {
+			var mask uint32
+			if c.ClearColor {
+				r.graphicsState.ClearColor(c.Color)
+				mask |= gl.COLOR_BUFFER_BIT
+			}
+			if c.ClearDepth {
+				r.graphicsState.ClearDepth(c.Depth)
+				mask |= gl.DEPTH_BUFFER_BIT
+			}
+			if c.ClearStencil {
+				r.graphicsState.ClearStencil(c.Stencil)
+				mask |= gl.STENCIL_BUFFER_BIT
+			}
+			r.performScissor(c.Rect)
+			gl.Clear(mask)
+		}
+
+		r.queryYield()
+		if post != nil {
+			post()
+		}
+		return false
+	})
 }
 
 func (r *device) hookedQueryWait(pre, post func()) {
 	// Ask the render channel to wait for query results now.
-	r.renderExec <- func() bool {
+	done := make(chan struct{})
+	ok := r.exec(func() bool {
 		if pre != nil {
 			pre()
 		}
@@ -293,36 +551,257 @@ func (r *device) hookedQueryWait(pre, post func()) {
 		}
 
 		// signal render completion.
-		r.renderComplete <- struct{}{}
+		close(done)
+		return false
+	})
+	if !ok {
+		return
+	}
+	select {
+	case <-done:
+	case <-r.destroyed:
+	}
+}
+
+func (r *device) hookedQueryPoll(pre, post func()) (pending int) {
+	// Ask the render channel to poll for query results now.
+	result := make(chan int, 1)
+	ok := r.exec(func() bool {
+		if pre != nil {
+			pre()
+		}
+
+		// Check for occlusion query results, without waiting.
+		result <- r.queryYield()
+
+		if post != nil {
+			post()
+		}
 		return false
+	})
+	if !ok {
+		return 0
 	}
-	<-r.renderComplete
+	select {
+	case pending = <-result:
+	case <-r.destroyed:
+	}
+	return
 }
 
 func (r *device) yield() {
-	tick := time.NewTicker(200 * time.Millisecond)
-	defer tick.Stop()
+	// Pending queries are polled at a fixed interval, regardless of the
+	// garbage interval, such that their results arrive even when periodic
+	// freeing is disabled.
+	queries := time.NewTicker(queryPollInterval)
+	defer queries.Stop()
+
+	garbage := time.NewTicker(defaultGarbageInterval)
+	defer func() {
+		if garbage != nil {
+			garbage.Stop()
+		}
+	}()
 	for {
+		// A nil ticker (i.e. periodic freeing is disabled) leaves garbageC nil,
+		// which blocks forever.
+		var garbageC <-chan time.Time
+		if garbage != nil {
+			garbageC = garbage.C
+		}
+		var f func() bool
 		select {
-		case <-tick.C:
-			r.renderExec <- func() bool {
-				r.rsrcManager.freePending()
+		case <-queries.C:
+			f = func() bool {
 				r.queryYield()
 				return false
 			}
+		case <-garbageC:
+			f = func() bool {
+				r.rsrcManager.freePending()
+				return false
+			}
+		case d := <-r.garbageInterval:
+			if garbage != nil {
+				garbage.Stop()
+				garbage = nil
+			}
+			if d > 0 {
+				garbage = time.NewTicker(d)
+			}
+			continue
 		case <-r.yieldExit:
 			return
 		}
+		select {
+		case r.renderExec <- f:
+		case <-r.destroyed:
+			return
+		}
+	}
+}
+
+// defaultQueueSize is the default buffer size of the execution channel, see
+// the QueueSize option.
+const defaultQueueSize = 1024
+
+// defaultGarbageInterval is the default interval at which the yield goroutine
+// frees pending resources, see SetGarbageInterval.
+const defaultGarbageInterval = 200 * time.Millisecond
+
+// queryPollInterval is the interval at which the yield goroutine polls for the
+// results of pending occlusion queries.
+const queryPollInterval = 200 * time.Millisecond
+
+// SetGarbageInterval implements the Device interface.
+func (r *device) SetGarbageInterval(d time.Duration) {
+	select {
+	case r.garbageInterval <- d:
+	case <-r.destroyed:
+		// The yield goroutine has exited, there is nothing to update.
+	}
+}
+
+// exec sends f to the execution channel, unless the device is destroyed first
+// (as the execution channel may then no longer be executed), in which case f
+// is never executed and false is returned.
+func (r *device) exec(f func() bool) bool {
+	select {
+	case r.renderExec <- f:
+		return true
+	case <-r.destroyed:
+		return false
+	}
+}
+
+// SetWireframe implements the Device interface.
+func (r *device) SetWireframe(enabled bool) bool {
+	r.exec(func() bool {
+		r.wireframe = enabled
+		return false
+	})
+	return true
+}
+
+// OnPreRender implements the Device interface.
+func (r *device) OnPreRender(f func()) {
+	r.exec(func() bool {
+		r.preRender = append(r.preRender, f)
+		return false
+	})
+}
+
+// OnPostRender implements the Device interface.
+func (r *device) OnPostRender(f func()) {
+	r.exec(func() bool {
+		r.postRender = append(r.postRender, f)
+		return false
+	})
+}
+
+// RenderDo implements the Device interface.
+func (r *device) RenderDo(f func()) {
+	done := make(chan struct{})
+	ok := r.exec(func() bool {
+		f()
+		close(done)
+		return false
+	})
+	if !ok {
+		return
+	}
+	select {
+	case <-done:
+	case <-r.destroyed:
+	}
+}
+
+// Flush implements the Device interface.
+func (r *device) Flush() {
+	done := make(chan struct{})
+	ok := r.exec(func() bool {
+		gl.Flush()
+		close(done)
+		return false
+	})
+	if !ok {
+		return
+	}
+	select {
+	case <-done:
+	case <-r.destroyed:
 	}
 }
 
+// FreeNow implements the Device interface.
+func (r *device) FreeNow() {
+	r.exec(func() bool {
+		r.rsrcManager.freePending()
+		return false
+	})
+}
+
 func (r *device) hookedRender(pre, post func()) {
+	r.hookedRenderContext(context.Background(), pre, post)
+}
+
+// renderState tracks whether a render submitted to the execution channel has
+// begun, or was abandoned (i.e. it's wait stopped) before it began.
+type renderState struct {
+	v atomic.Int32
+}
+
+const (
+	renderBegun = iota + 1
+	renderAbandoned
+)
+
+// begin is called by the render once it executes, and reports whether it
+// should proceed (i.e. it was not abandoned).
+func (s *renderState) begin() bool {
+	return s.v.CompareAndSwap(0, renderBegun)
+}
+
+// abandon abandons the render unless it has already begun, and reports
+// whether it had.
+func (s *renderState) abandon() (begun bool) {
+	return !s.v.CompareAndSwap(0, renderAbandoned)
+}
+
+// hookedRenderContext is like hookedRender, except it stops waiting for the
+// render to complete once the context is done (returning ctx.Err()) or the
+// device is destroyed (returning ErrDestroyed). The begun value reports
+// whether the render began executing (i.e. it was not abandoned), even if
+// waiting for it stopped.
+func (r *device) hookedRenderContext(ctx context.Context, pre, post func()) (begun bool, err error) {
+	// Render completion is signaled over a channel of it's own, such that a
+	// render whose wait was abandoned cannot signal the completion of a later
+	// one.
+	complete := make(chan struct{})
+
+	// The render is abandoned if the wait stops before it begins, in which
+	// case it is skipped entirely (e.g. the clock is not ticked).
+	var state renderState
+	abandon := func(err error) (bool, error) {
+		return state.abandon(), err
+	}
+
 	// Ask the render channel to render things now.
-	r.renderExec <- func() bool {
+	render := func() bool {
+		if !state.begin() {
+			return false
+		}
+
 		// If any finalizers have ran and actually want us to free something,
 		// then we perform this operation now.
 		r.rsrcManager.freePending()
 
+		// Uniform blocks are bound once per frame, forget the previous
+		// frame's bindings.
+		for binding := range r.uniformBindings {
+			delete(r.uniformBindings, binding)
+		}
+
 		if pre != nil {
 			pre()
 		}
@@ -352,23 +831,41 @@ func (r *device) hookedRender(pre, post func()) {
 			// state, tick the clock, or return true (frame rendered).
 
 			// We do still need to signal render completion.
-			r.renderComplete <- struct{}{}
+			close(complete)
 			return false
 		}
 
 		// Tick the clock.
 		r.clock.Tick()
 
+		// Publish this frame's statistics, and start the next frame's.
+		r.publishStats()
+
 		// signal render completion.
-		r.renderComplete <- struct{}{}
+		close(complete)
 		return true
 	}
-	<-r.renderComplete
+	select {
+	case r.renderExec <- render:
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-r.destroyed:
+		return false, ErrDestroyed
+	}
+	select {
+	case <-complete:
+		return true, nil
+	case <-ctx.Done():
+		return abandon(ctx.Err())
+	case <-r.destroyed:
+		return abandon(ErrDestroyed)
+	}
 }
 
 // Tries to receive pending occlusion query results, returns immediately if
 // none are available yet. Returns the number of queries still pending.
 func (r *device) queryYield() int {
+	r.timerYield()
 	if !r.glArbOcclusionQuery {
 		return 0
 	}
@@ -448,26 +945,37 @@ var initLock sync.Mutex
 func newDevice(opts ...Option) (Device, error) {
 	r := &device{
 		BaseCanvas: &util.BaseCanvas{
-			VMSAA: true,
+			VMSAA:       true,
+			VClearDepth: 1.0,
 		},
-		warner:         util.NewWarner(nil),
-		common:         glc.NewContext(),
-		clock:          clock.New(),
-		rsrcManager:    &rsrcManager{},
-		renderExec:     make(chan func() bool, 1024),
-		renderComplete: make(chan struct{}, 8),
-		wantFree:       make(chan struct{}, 1),
-		yieldExit:      make(chan struct{}, 1),
+		warner:          util.NewWarner(nil),
+		common:          glc.NewContext(),
+		clock:           clock.New(),
+		rsrcManager:     &rsrcManager{},
+		queueSize:       defaultQueueSize,
+		wantFree:        make(chan struct{}, 1),
+		yieldExit:       make(chan struct{}, 1),
+		destroyed:       make(chan struct{}),
+		garbageInterval: make(chan time.Duration),
+		uniformBindings: make(map[int]uint32),
+		depthRange:      [2]float64{0, 1},
 	}
 	r.graphicsState = &graphicsState{
 		GraphicsState: glc.NewGraphicsState(r.common),
 	}
-	go r.yield()
 
 	for _, opt := range opts {
 		opt(r)
 	}
 
+	// The execution channel is created after the options are applied, as it's
+	// size is specified by the QueueSize option.
+	if r.queueSize < 1 {
+		return nil, ErrQueueSize
+	}
+	r.renderExec = make(chan func() bool, r.queueSize)
+	go r.yield()
+
 	// Initialize OpenGL.
 	initLock.Lock()
 	err := gl.Init()
@@ -506,8 +1014,34 @@ func newDevice(opts ...Option) (Device, error) {
 	// Query whether we have the GL_ARB_framebuffer_object extension.
 	r.glArbFramebufferObject = exts.Present("GL_ARB_framebuffer_object")
 
-	// Query whether we have the GL_ARB_occlusion_query extension.
-	r.glArbOcclusionQuery = exts.Present("GL_ARB_occlusion_query")
+	// Query whether we have the GL_ARB_occlusion_query extension. If the user
+	// disabled occlusion queries we act as if it were not present, such that
+	// no queries are issued or waited on.
+	r.glArbOcclusionQuery = exts.Present("GL_ARB_occlusion_query") && !r.noOcclusionQueries
+
+	// Query whether we have the GL_ARB_timer_query extension.
+	r.glArbTimerQuery = exts.Present("GL_ARB_timer_query")
+
+	// glMultiDrawArrays and glMultiDrawElements are core as of OpenGL 1.4.
+	major, minor, _, _ := r.common.Version()
+	r.glMultiDraw = major > 1 || (major == 1 && minor >= 4)
+
+	// Primitive restart is core as of OpenGL 3.1.
+	r.glPrimitiveRestart = major > 3 || (major == 3 && minor >= 1)
+
+	// Texture swizzling is core as of OpenGL 3.3.
+	r.glTextureSwizzle = exts.Present("GL_ARB_texture_swizzle") || exts.Present("GL_EXT_texture_swizzle") || major > 3 || (major == 3 && minor >= 3)
+
+	// Query whether we have the GL_ARB_sync extension (core as of OpenGL 3.2).
+	r.glArbSync = exts.Present("GL_ARB_sync") || major > 3 || (major == 3 && minor >= 2)
+
+	// Query whether we have the GL_ARB_uniform_buffer_object extension.
+	r.glArbUniformBufferObject = exts.Present("GL_ARB_uniform_buffer_object")
+
+	// Query whether we have the GL_NVX_gpu_memory_info or GL_ATI_meminfo
+	// extensions.
+	r.glNvxGpuMemoryInfo = exts.Present("GL_NVX_gpu_memory_info")
+	r.glAtiMeminfo = exts.Present("GL_ATI_meminfo")
 
 	// Query whether we have the GL_ARB_multisample extension.
 	r.glArbMultisample = exts.Present("GL_ARB_multisample")
@@ -519,18 +1053,32 @@ func newDevice(opts ...Option) (Device, error) {
 	}
 
 	// Store GPU info.
-	var maxTextureSize, maxVaryingFloats, maxVertexInputs, maxFragmentInputs, occlusionQueryBits int32
+	var maxTextureSize, maxCubeMapSize, maxVaryingFloats, maxVertexInputs, maxFragmentInputs, occlusionQueryBits int32
 	gl.GetIntegerv(gl.MAX_TEXTURE_SIZE, &maxTextureSize)
+	gl.GetIntegerv(gl.MAX_CUBE_MAP_TEXTURE_SIZE, &maxCubeMapSize)
+	var maxVertexAttribs, maxTextureImageUnits, maxCombinedTextureImageUnits int32
+	gl.GetIntegerv(gl.MAX_VERTEX_ATTRIBS, &maxVertexAttribs)
+	gl.GetIntegerv(gl.MAX_TEXTURE_IMAGE_UNITS, &maxTextureImageUnits)
+	gl.GetIntegerv(gl.MAX_COMBINED_TEXTURE_IMAGE_UNITS, &maxCombinedTextureImageUnits)
 	gl.GetIntegerv(gl.MAX_VARYING_FLOATS, &maxVaryingFloats)
 	gl.GetIntegerv(gl.MAX_VERTEX_UNIFORM_COMPONENTS, &maxVertexInputs)
 	gl.GetIntegerv(gl.MAX_FRAGMENT_UNIFORM_COMPONENTS, &maxFragmentInputs)
 	if r.glArbOcclusionQuery {
 		gl.GetQueryiv(gl.SAMPLES_PASSED, gl.QUERY_COUNTER_BITS, &occlusionQueryBits)
 	}
+	var maxUniformBufferBindings int32
+	if r.glArbUniformBufferObject {
+		gl.GetIntegerv(gl.MAX_UNIFORM_BUFFER_BINDINGS, &maxUniformBufferBindings)
+	}
 
 	// Collect GPU information.
 	r.devInfo.DepthClamp = exts.Present("GL_ARB_depth_clamp")
 	r.devInfo.MaxTextureSize = int(maxTextureSize)
+	r.devInfo.MaxCubeMapSize = int(maxCubeMapSize)
+	r.devInfo.MaxVertexAttribs = int(maxVertexAttribs)
+	r.devInfo.MaxTextureImageUnits = int(maxTextureImageUnits)
+	r.devInfo.MaxCombinedTextureImageUnits = int(maxCombinedTextureImageUnits)
+	r.devInfo.Uint32Indices = true // Always supported by desktop OpenGL.
 	r.devInfo.AlphaToCoverage = r.glArbMultisample && r.samples > 0 && r.sampleBuffers > 0
 	r.devInfo.Name = gl.GoStr(gl.GetString(gl.RENDERER))
 	r.devInfo.Vendor = gl.GoStr(gl.GetString(gl.VENDOR))
@@ -538,6 +1086,21 @@ func newDevice(opts ...Option) (Device, error) {
 	r.devInfo.OcclusionQueryBits = int(occlusionQueryBits)
 	r.devInfo.NPOT = exts.Present("GL_ARB_texture_non_power_of_two")
 	r.devInfo.TexWrapBorderColor = true
+	r.devInfo.UniformBlocks = r.glArbUniformBufferObject
+	r.devInfo.MaxUniformBlockBindings = int(maxUniformBufferBindings)
+	r.devInfo.TimerQuery = r.glArbTimerQuery
+	r.devInfo.MultiDraw = r.glMultiDraw
+	r.devInfo.PrimitiveRestart = r.glPrimitiveRestart
+	r.devInfo.TextureSwizzle = r.glTextureSwizzle
+	r.devInfo.SeamlessCubeMaps = exts.Present("GL_ARB_seamless_cube_map") || major > 3 || (major == 3 && minor >= 2)
+	if r.seamlessCubeMaps && r.devInfo.SeamlessCubeMaps {
+		gl.Enable(gl.TEXTURE_CUBE_MAP_SEAMLESS)
+	}
+
+	// GL_ARB_gl_spirv requires glShaderBinary (i.e. OpenGL 4.1 or
+	// GL_ARB_ES2_compatibility), but drivers may still fail to provide either
+	// entry point, so check that both were loaded too.
+	r.devInfo.SPIRV = exts.Present("GL_ARB_gl_spirv") && gl.SPIRVLoaded()
 
 	// OpenGL Information.
 	glInfo := &gfx.GLInfo{
@@ -546,6 +1109,10 @@ func newDevice(opts ...Option) (Device, error) {
 	glInfo.MajorVersion, glInfo.MinorVersion, glInfo.ReleaseVersion, glInfo.VendorVersion = r.common.Version()
 	r.devInfo.GL = glInfo
 
+	// Geometry shaders are core as of OpenGL 3.2 (the ARB/EXT extensions
+	// require glProgramParameteri, which our bindings do not expose).
+	r.devInfo.GeometryShaders = glInfo.MajorVersion > 3 || (glInfo.MajorVersion == 3 && glInfo.MinorVersion >= 2)
+
 	// GLSL information.
 	glslInfo := &gfx.GLSLInfo{
 		MaxVaryingFloats:  int(maxVaryingFloats),
@@ -632,5 +1199,11 @@ func newDevice(opts ...Option) (Device, error) {
 		r.compressedTextureFormats = make([]int32, numFormats)
 		gl.GetIntegerv(gl.COMPRESSED_TEXTURE_FORMATS, &r.compressedTextureFormats[0])
 	}
+
+	// Verify that we can actually share objects with the shared device.
+	if err := r.checkShare(); err != nil {
+		r.Destroy()
+		return nil, err
+	}
 	return r, nil
 }
@@ -0,0 +1,236 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"context"
+	"image"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/clock"
+	"github.com/qmcloud/engine/gfx/internal/util"
+)
+
+// newYieldDevice returns a device with just enough state for it's yield
+// goroutine to run, without an OpenGL context.
+func newYieldDevice() *device {
+	return &device{
+		renderExec:      make(chan func() bool, 16),
+		yieldExit:       make(chan struct{}, 1),
+		destroyed:       make(chan struct{}),
+		garbageInterval: make(chan time.Duration),
+	}
+}
+
+func TestSetGarbageIntervalDisabled(t *testing.T) {
+	r := newYieldDevice()
+	go r.yield()
+	defer r.Destroy()
+
+	// With periodic freeing disabled, the yield goroutine must still send
+	// functions to poll pending queries.
+	r.SetGarbageInterval(0)
+	for len(r.renderExec) > 0 {
+		<-r.renderExec
+	}
+	select {
+	case <-r.renderExec:
+	case <-time.After(10 * queryPollInterval):
+		t.Fatal("queries not polled with garbage interval disabled")
+	}
+}
+
+func TestSetGarbageIntervalDestroyed(t *testing.T) {
+	r := newYieldDevice()
+	go r.yield()
+	r.Destroy()
+
+	done := make(chan struct{})
+	go func() {
+		r.SetGarbageInterval(time.Second)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("SetGarbageInterval blocked after Destroy")
+	}
+}
+
+// newRenderDevice returns a device with just enough state to call
+// RenderContext, without an OpenGL context. Nothing executes it's execution
+// channel.
+func newRenderDevice() *device {
+	r := newYieldDevice()
+	r.BaseCanvas = &util.BaseCanvas{}
+	r.clock = clock.New()
+	return r
+}
+
+func TestRenderContextCanceled(t *testing.T) {
+	r := newRenderDevice()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := r.RenderContext(ctx); err != context.DeadlineExceeded {
+		t.Fatal("got", err, "want", context.DeadlineExceeded)
+	}
+
+	// The abandoned render is skipped once it is executed, it must not end
+	// the frame or tick the clock.
+	if len(r.renderExec) != 1 {
+		t.Fatal("expected the render to be queued")
+	}
+	if f := <-r.renderExec; f() {
+		t.Fatal("abandoned render signaled a rendered frame")
+	}
+	if n := r.clock.FrameCount(); n != 0 {
+		t.Fatal("abandoned render ticked the clock", n, "times")
+	}
+}
+
+func TestRenderContextDestroyed(t *testing.T) {
+	r := newRenderDevice()
+	go r.yield()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		r.Destroy()
+	}()
+	if err := r.RenderContext(context.Background()); err != ErrDestroyed {
+		t.Fatal("got", err, "want", ErrDestroyed)
+	}
+
+	// Once destroyed, it returns immediately even when the execution channel
+	// is full.
+	for len(r.renderExec) < cap(r.renderExec) {
+		r.renderExec <- func() bool { return false }
+	}
+	if err := r.RenderContext(context.Background()); err != ErrDestroyed {
+		t.Fatal("got", err, "want", ErrDestroyed)
+	}
+}
+
+func TestRenderState(t *testing.T) {
+	// A render abandoned before it begins is skipped.
+	var s renderState
+	if begun := s.abandon(); begun {
+		t.Fatal("abandon reported a render which did not begin")
+	}
+	if s.begin() {
+		t.Fatal("abandoned render began")
+	}
+
+	// A render which began is not abandoned, such that RenderContext still
+	// ends the frame when waiting for it stops.
+	s = renderState{}
+	if !s.begin() {
+		t.Fatal("render did not begin")
+	}
+	if begun := s.abandon(); !begun {
+		t.Fatal("abandon did not report the render which began")
+	}
+}
+
+func TestDestroyedNoBlock(t *testing.T) {
+	r := newRenderDevice()
+	r.BaseCanvas.VBounds = image.Rect(0, 0, 8, 8)
+	r.rsrcManager = &rsrcManager{}
+	r.glArbFramebufferObject = true
+	r.glArbSync = true
+	r.glArbTimerQuery = true
+	r.glNvxGpuMemoryInfo = true
+	go r.yield()
+	r.Destroy()
+
+	// Nothing executes the (full) execution channel any longer.
+	for len(r.renderExec) < cap(r.renderExec) {
+		r.renderExec <- func() bool { return false }
+	}
+
+	o := gfx.NewObject()
+	o.State = gfx.NewState()
+	o.Shader = gfx.NewShader("loaded")
+	o.Shader.Loaded = true
+	m := gfx.NewMesh()
+	m.Vertices = []gfx.Vec3{{}, {X: 1}, {Y: 1}}
+	o.Meshes = []*gfx.Mesh{m}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.SetWireframe(true)
+		r.OnPreRender(func() {})
+		r.OnPostRender(func() {})
+		r.RenderDo(func() {})
+		r.Flush()
+		r.FreeNow()
+		r.QueryWait()
+		r.QueryPoll()
+		r.MemoryUsage()
+		r.BeginGPUTimer()
+		r.Fence().Wait(time.Second)
+		r.LoadMesh(gfx.NewMesh(), nil)
+		if err := r.Blit(r, r.Bounds(), r.Bounds(), gfx.Nearest); err != ErrDestroyed {
+			t.Error("Blit: got", err, "want", ErrDestroyed)
+		}
+		if err := r.DrawContext(context.Background(), r.Bounds(), o, nil); err != ErrDestroyed {
+			t.Error("DrawContext: got", err, "want", ErrDestroyed)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("device method blocked after Destroy")
+	}
+}
+
+func TestDestroyConcurrent(t *testing.T) {
+	r := newYieldDevice()
+	go r.yield()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			r.Destroy()
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStats(t *testing.T) {
+	r := newYieldDevice()
+	a, b := gfx.NewState(), gfx.NewState()
+	b.DepthTest = !a.DepthTest
+
+	// Only objects whose state differs from the one before count as a state
+	// change.
+	for _, s := range []*gfx.State{a, a, b, b, a} {
+		r.countState(s)
+		r.stats.DrawCalls++
+	}
+	r.stats.Triangles = 12
+	r.stats.TextureBinds = 2
+	if s := r.Stats(); s != (gfx.Stats{}) {
+		t.Fatal("statistics published before the frame ended", s)
+	}
+
+	r.publishStats()
+	want := gfx.Stats{DrawCalls: 5, Triangles: 12, StateChanges: 3, TextureBinds: 2}
+	if s := r.Stats(); s != want {
+		t.Fatal("got", s, "want", want)
+	}
+
+	// The next frame starts over, and the first object's state counts as a
+	// change again.
+	r.countState(a)
+	r.publishStats()
+	want = gfx.Stats{StateChanges: 1}
+	if s := r.Stats(); s != want {
+		t.Fatal("got", s, "want", want)
+	}
+}
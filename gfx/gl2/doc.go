@@ -39,8 +39,8 @@
 // small caveat:
 //
 // Mipmapping can only be toggled post-load in the event that the texture was
-// first loaded with a mipmapped filter, or else the request is ignored and the
-// texture filter is not changed.
+// first loaded with a mipmapped filter (and without NoAutoMipmap set), or
+// else the request is ignored and the texture filter is not changed.
 //
 // This is done for performance reasons, turning on mipmapping post-load time
 // would require a full texture reload (and having it on by default would use
@@ -56,11 +56,16 @@
 //
 // The default uniforms are:
 //
-//	uniform mat4 Model;       -> Model matrix from gfx.Object.Transform
-//	uniform mat4 View;        -> View matrix from gfx.Camera.Transform
-//	uniform mat4 Projection;  -> Projection matrix from gfx.Camera.Projection
-//	uniform mat4 MVP;         -> Premultiplied Model/View/Projection matrix.
-//	uniform bool BinaryAlpha; -> See below.
+//	uniform mat4 Model;           -> Model matrix from gfx.Object.Transform
+//	uniform mat4 View;            -> View matrix from gfx.Camera.Transform
+//	uniform mat4 Projection;      -> Projection matrix from gfx.Camera.Projection
+//	uniform mat4 MVP;             -> Premultiplied Model/View/Projection matrix.
+//	uniform bool BinaryAlpha;     -> See below.
+//	uniform mat4 Bones[N];        -> Bone matrices from gfx.Object.Skeleton, if any.
+//	uniform sampler2D Texture[N]; -> [N] is the nth index of gfx.Object.Textures
+//
+// A texture whose gfx.Texture.SamplerName is set is bound to the sampler of
+// that name instead of Texture[N].
 //
 // BinaryAlpha is a boolean uniform value that informs the shader of the chosen
 // alpha transparency mode of an object. It is set to true if the gfx.Object
@@ -80,6 +85,9 @@
 //	attribute vec3 Vertex;      -> from gfx.Mesh.Vertices and gfx.Mesh.Indices
 //	attribute vec4 Color;       -> from gfx.Mesh.Colors
 //	attribute vec3 Bary;        -> from gfx.Mesh.Bary
+//	attribute vec4 Tangent;     -> from gfx.Mesh.Tangents
+//	attribute vec4 BoneIndices; -> from gfx.Mesh.BoneIndices
+//	attribute vec4 BoneWeights; -> from gfx.Mesh.BoneWeights
 //	attribute vec2 TexCoord[N]; -> [N] is the nth index of gfx.Mesh.TexCoords
 //
 // # Uniform And Attribute Types
@@ -5,6 +5,7 @@
 package gl2
 
 import (
+	"context"
 	"fmt"
 	"image"
 	"reflect"
@@ -39,18 +40,44 @@ func (n *nativeObject) SampleCount() int {
 // Implements the gfx.Destroyable interface.
 func (n *nativeObject) Destroy() {}
 
+// objectScissor returns the scissor rectangle for drawing an object with the
+// given state to the given rectangle, i.e. the rectangle restricted further
+// by the state's ScissorRect, if any.
+func objectScissor(rect image.Rectangle, s *gfx.State) image.Rectangle {
+	if s.ScissorRect != nil {
+		return rect.Intersect(*s.ScissorRect)
+	}
+	return rect
+}
+
 func (r *device) hookedDraw(rect image.Rectangle, o *gfx.Object, c gfx.Camera, pre, post func()) {
-	doDraw, err := util.PreDraw(r, rect, o, c)
+	r.hookedDrawRanges(context.Background(), rect, o, nil, c, pre, post)
+}
+
+// hookedDrawRanges is like hookedDraw, except if ranges is non-nil then only
+// those ranges of the object's meshes are drawn (instead of their own Ranges).
+//
+// It stops waiting for the object's resources to load once the context is
+// done or the device is destroyed, returning ctx.Err() or ErrDestroyed
+// respectively, see DrawContext.
+func (r *device) hookedDrawRanges(ctx context.Context, rect image.Rectangle, o *gfx.Object, ranges []gfx.MeshRange, c gfx.Camera, pre, post func()) error {
+	doDraw, err := util.PreDraw(ctx, r, rect, o, c, r.destroyed)
+	if err == util.ErrDestroyed {
+		return ErrDestroyed
+	}
+	if err != nil && err == ctx.Err() {
+		return err
+	}
 	if err != nil {
 		r.warner.Warnf("%v\n", err)
-		return
+		return nil
 	}
 	if !doDraw {
-		return
+		return nil
 	}
 
 	// Ask the render loop to perform drawing.
-	r.renderExec <- func() bool {
+	ok := r.exec(func() bool {
 		// Give the object a native object.
 		if o.NativeObject == nil {
 			o.NativeObject = &nativeObject{
@@ -66,7 +93,7 @@ func (r *device) hookedDraw(rect image.Rectangle, o *gfx.Object, c gfx.Camera, p
 		r.graphicsState.Begin(r)
 
 		// Update the scissor region (effects drawing).
-		r.performScissor(rect)
+		r.performScissor(objectScissor(rect, o.State))
 
 		var ns *nativeShader
 		if o.NativeShader != nil {
@@ -75,10 +102,17 @@ func (r *device) hookedDraw(rect image.Rectangle, o *gfx.Object, c gfx.Camera, p
 
 		// Use the object's state.
 		r.useState(ns, o, c)
+		r.countState(o.State)
 
 		// Draw each mesh.
+		if r.wireframe {
+			gl.PolygonMode(gl.FRONT_AND_BACK, gl.LINE)
+		}
 		for _, m := range o.Meshes {
-			r.drawMesh(ns, m)
+			r.drawMesh(ns, m, ranges)
+		}
+		if r.wireframe {
+			gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
 		}
 
 		// Clear the object's state.
@@ -91,7 +125,11 @@ func (r *device) hookedDraw(rect image.Rectangle, o *gfx.Object, c gfx.Camera, p
 			post()
 		}
 		return false
+	})
+	if !ok {
+		return ErrDestroyed
 	}
+	return nil
 }
 
 type texSlot int32
@@ -198,11 +236,22 @@ func (r *device) useState(ns *nativeShader, obj *gfx.Object, c gfx.Camera) {
 	r.graphicsState.stencilMaskSeparate(obj.StencilFront.WriteMask, obj.StencilBack.WriteMask)
 	if r.devInfo.DepthClamp {
 		r.graphicsState.depthClamp(obj.DepthClamp)
+	} else if obj.DepthClamp {
+		r.warner.Warn("DepthClamp is not supported (GL_ARB_depth_clamp), ignoring.\n")
+	}
+	if !obj.DepthTest && obj.DepthWrite {
+		// OpenGL never writes to the depth buffer while depth testing is
+		// disabled, so instead use a depth test that always passes.
+		r.graphicsState.DepthCmp(gfx.Always)
+		r.graphicsState.DepthTest(true)
+	} else {
+		r.graphicsState.DepthCmp(obj.DepthCmp)
+		r.graphicsState.DepthTest(obj.DepthTest)
 	}
-	r.graphicsState.DepthCmp(obj.DepthCmp)
-	r.graphicsState.DepthTest(obj.DepthTest)
 	r.graphicsState.DepthWrite(obj.DepthWrite)
+	r.graphicsState.depthBias(obj.DepthBias)
 	r.graphicsState.FaceCulling(obj.FaceCulling)
+	r.graphicsState.frontFace(obj.FrontFace)
 
 	// Begin using the shader.
 	shader := obj.Shader
@@ -211,6 +260,10 @@ func (r *device) useState(ns *nativeShader, obj *gfx.Object, c gfx.Camera) {
 	// Update shader inputs.
 	for name := range shader.Inputs {
 		value := shader.Inputs[name]
+		if b, ok := value.(*gfx.UniformBlock); ok {
+			r.useUniformBlock(ns, name, b)
+			continue
+		}
 		r.updateUniform(ns, name, value)
 	}
 
@@ -224,6 +277,11 @@ func (r *device) useState(ns *nativeShader, obj *gfx.Object, c gfx.Camera) {
 	r.updateUniform(ns, "Projection", nativeObj.MVPCache.Projection)
 	r.updateUniform(ns, "MVP", nativeObj.MVPCache.MVP)
 
+	// Add the bone matrices for the object, if it is animated.
+	if obj.Skeleton != nil {
+		r.updateUniform(ns, "Bones", obj.Skeleton.Bones)
+	}
+
 	// Set alpha mode.
 	if r.devInfo.AlphaToCoverage {
 		r.graphicsState.SampleAlphaToCoverage(obj.AlphaMode == gfx.AlphaToCoverage)
@@ -260,34 +318,74 @@ func (r *device) useState(ns *nativeShader, obj *gfx.Object, c gfx.Camera) {
 		nt := t.NativeTexture.(*nativeTexture)
 
 		gl.ActiveTexture(gl.TEXTURE0 + uint32(i))
-		gl.BindTexture(gl.TEXTURE_2D, nt.id)
+		gl.BindTexture(nt.target, nt.id)
+		r.stats.TextureBinds++
 
 		// Load wrap mode.
 		uWrap := int32(r.common.ConvertTexWrap(t.WrapU))
 		vWrap := int32(r.common.ConvertTexWrap(t.WrapV))
 		if t.WrapU == gfx.BorderColor || t.WrapV == gfx.BorderColor {
 			// We must specify the actual border color then.
-			gl.TexParameterfv(gl.TEXTURE_2D, gl.TEXTURE_BORDER_COLOR, &t.BorderColor.R)
+			gl.TexParameterfv(nt.target, gl.TEXTURE_BORDER_COLOR, &t.BorderColor.R)
+		}
+		gl.TexParameteri(nt.target, gl.TEXTURE_WRAP_S, uWrap)
+		gl.TexParameteri(nt.target, gl.TEXTURE_WRAP_T, vWrap)
+		if nt.target == gl.TEXTURE_CUBE_MAP {
+			gl.TexParameteri(nt.target, gl.TEXTURE_WRAP_R, uWrap)
 		}
-		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, uWrap)
-		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, vWrap)
 
-		// Load filter.
-		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, int32(r.common.ConvertTexFilter(t.MinFilter)))
-		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, int32(r.common.ConvertTexFilter(t.MagFilter)))
+		// Load filter. The filters are independent of each other, but the
+		// magnification filter can never be mipmapped (OpenGL would generate
+		// GL_INVALID_ENUM and leave the previous filter in place).
+		gl.TexParameteri(nt.target, gl.TEXTURE_MIN_FILTER, int32(r.common.ConvertTexFilter(t.MinFilter)))
+		gl.TexParameteri(nt.target, gl.TEXTURE_MAG_FILTER, int32(r.common.ConvertTexFilter(t.MagFilter.NonMipmapped())))
 
 		// If we do not want mipmapping, turn it off. Note that only the
 		// minification filter can be mipmapped (mag filter can never be).
-		if t.MinFilter.Mipmapped() {
-			gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_BASE_LEVEL, 0)
-			gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAX_LEVEL, 1000)
+		if t.Mipmapped() {
+			gl.TexParameteri(nt.target, gl.TEXTURE_BASE_LEVEL, 0)
+			gl.TexParameteri(nt.target, gl.TEXTURE_MAX_LEVEL, 1000)
+		} else {
+			gl.TexParameteri(nt.target, gl.TEXTURE_BASE_LEVEL, 0)
+			gl.TexParameteri(nt.target, gl.TEXTURE_MAX_LEVEL, 0)
+		}
+
+		// Load level-of-detail bias and range (zero means the full range,
+		// which is OpenGL's default of -1000 to 1000).
+		minLOD, maxLOD := t.MinLOD, t.MaxLOD
+		if minLOD == 0 && maxLOD == 0 {
+			minLOD, maxLOD = -1000, 1000
+		}
+		gl.TexParameterfv(nt.target, gl.TEXTURE_LOD_BIAS, &t.LODBias)
+		gl.TexParameterfv(nt.target, gl.TEXTURE_MIN_LOD, &minLOD)
+		gl.TexParameterfv(nt.target, gl.TEXTURE_MAX_LOD, &maxLOD)
+
+		// Load depth comparison mode (which is ignored by OpenGL for textures
+		// without a depth format).
+		if t.Compare {
+			gl.TexParameteri(nt.target, gl.TEXTURE_COMPARE_MODE, gl.COMPARE_R_TO_TEXTURE)
+			gl.TexParameteri(nt.target, gl.TEXTURE_COMPARE_FUNC, int32(r.common.ConvertCmp(t.CompareCmp)))
 		} else {
-			gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_BASE_LEVEL, 0)
-			gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAX_LEVEL, 0)
+			gl.TexParameteri(nt.target, gl.TEXTURE_COMPARE_MODE, gl.NONE)
 		}
 
-		// Add uniform input.
-		r.updateUniform(ns, textureIndex.Name(i), texSlot(i))
+		// Load component swizzling.
+		if r.glTextureSwizzle {
+			swizzle := [4]int32{gl.RED, gl.GREEN, gl.BLUE, gl.ALPHA}
+			for c, sc := range t.Swizzle {
+				swizzle[c] = convertSwizzle(sc, swizzle[c])
+			}
+			gl.TexParameteriv(nt.target, gl.TEXTURE_SWIZZLE_RGBA, &swizzle[0])
+		} else if t.Swizzle != [4]gfx.SwizzleComponent{} {
+			r.warner.Warn("Texture.Swizzle is not supported (GL_ARB_texture_swizzle), ignoring.\n")
+		}
+
+		// Add uniform input, by the texture's sampler name if it has one.
+		name := t.SamplerName
+		if name == "" {
+			name = textureIndex.Name(i)
+		}
+		r.updateUniform(ns, name, texSlot(i))
 	}
 
 	// Begin occlusion query.
@@ -300,10 +398,13 @@ func (r *device) clearState(ns *nativeShader, obj *gfx.Object) {
 
 	// Use no texture.
 	gl.BindTexture(gl.TEXTURE_2D, 0)
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, 0)
 	gl.ActiveTexture(gl.TEXTURE0)
 }
 
-func (r *device) drawMesh(ns *nativeShader, m *gfx.Mesh) {
+// drawMesh draws the given mesh, or only the given ranges of it if ranges is
+// non-nil (see gfx.Mesh.Ranges, which are used if it is nil).
+func (r *device) drawMesh(ns *nativeShader, m *gfx.Mesh, ranges []gfx.MeshRange) {
 	// Grab the native mesh.
 	native := m.NativeMesh.(*nativeMesh)
 
@@ -353,10 +454,42 @@ func (r *device) drawMesh(ns *nativeShader, m *gfx.Mesh) {
 		}
 	}
 
+	// Enable primitive restart, if the mesh wants it.
+	restart := m.RestartIndex != nil && native.indicesCount > 0 && r.glPrimitiveRestart
+	if restart {
+		gl.Enable(gl.PRIMITIVE_RESTART)
+		defer gl.Disable(gl.PRIMITIVE_RESTART)
+		gl.PrimitiveRestartIndex(*m.RestartIndex)
+	}
+
+	// Triangles are counted only if each three indices form one, which is not
+	// the case with primitive restart.
+	countTris := m.Primitive == gfx.Triangles && !restart
+
+	if ranges == nil {
+		ranges = m.Ranges
+	}
+	if len(ranges) > 0 {
+		// Draw only the given ranges of the mesh.
+		r.drawRanges(m, native, ranges, countTris)
+		gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+		return
+	}
+
+	// Update statistics.
+	r.stats.DrawCalls++
+	if countTris {
+		if native.indicesCount > 0 {
+			r.stats.Triangles += int(native.indicesCount) / 3
+		} else {
+			r.stats.Triangles += int(native.verticesCount) / 3
+		}
+	}
+
 	if native.indicesCount > 0 {
 		// Draw indexed mesh.
 		gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, native.indices)
-		gl.DrawElements(uint32(r.common.ConvertPrimitive(m.Primitive)), native.indicesCount, gl.UNSIGNED_INT, nil)
+		gl.DrawElements(uint32(r.common.ConvertPrimitive(m.Primitive)), native.indicesCount, native.indexType, nil)
 	} else {
 		// Draw regular mesh.
 		gl.DrawArrays(uint32(r.common.ConvertPrimitive(m.Primitive)), 0, native.verticesCount)
@@ -365,3 +498,82 @@ func (r *device) drawMesh(ns *nativeShader, m *gfx.Mesh) {
 	// Unbind buffer to avoid carrying OpenGL state.
 	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
 }
+
+// meshRanges appends the first element and the number of elements of each of
+// the given ranges which lies within the first total elements of a mesh (i.e.
+// it's indices, or it's vertices if it is not indexed) to first and count. It
+// returns the extended slices and the number of triangles in those ranges,
+// assuming each three elements form one.
+//
+// Primitive restart indices are elements like any other (just like OpenGL
+// treats them), so they count towards the bounds of a range.
+func meshRanges(first, count []int32, ranges []gfx.MeshRange, total int) ([]int32, []int32, int) {
+	var tris int
+	for _, rng := range ranges {
+		if rng.Start < 0 || rng.Count <= 0 || rng.Start >= total || rng.Count > total-rng.Start {
+			continue
+		}
+		first = append(first, int32(rng.Start))
+		count = append(count, int32(rng.Count))
+		tris += rng.Count / 3
+	}
+	return first, count, tris
+}
+
+// drawRanges draws the given ranges of the mesh, with a single multi-draw call
+// if supported. Ranges which lie outside of the mesh are ignored. Triangles
+// are counted in the statistics only if countTris is true.
+func (r *device) drawRanges(m *gfx.Mesh, native *nativeMesh, ranges []gfx.MeshRange, countTris bool) {
+	indexed := native.indicesCount > 0
+	total := int(native.verticesCount)
+	if indexed {
+		total = int(native.indicesCount)
+	}
+
+	// Collect the valid ranges, and update statistics.
+	var tris int
+	r.multiFirst, r.multiCount, tris = meshRanges(r.multiFirst[:0], r.multiCount[:0], ranges, total)
+	if countTris {
+		r.stats.Triangles += tris
+	}
+	n := len(r.multiCount)
+	if n == 0 {
+		return
+	}
+	mode := uint32(r.common.ConvertPrimitive(m.Primitive))
+
+	if !indexed {
+		if r.glMultiDraw {
+			r.stats.DrawCalls++
+			gl.MultiDrawArrays(mode, &r.multiFirst[0], &r.multiCount[0], int32(n))
+			return
+		}
+		r.stats.DrawCalls += n
+		for i, first := range r.multiFirst {
+			gl.DrawArrays(mode, first, r.multiCount[i])
+		}
+		return
+	}
+
+	// Convert the first index of each range into a byte offset into the
+	// index buffer.
+	indexSize := 4
+	if native.indexType == gl.UNSIGNED_SHORT {
+		indexSize = 2
+	}
+	r.multiOffsets = r.multiOffsets[:0]
+	for _, first := range r.multiFirst {
+		r.multiOffsets = append(r.multiOffsets, gl.PtrOffset(int(first)*indexSize))
+	}
+
+	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, native.indices)
+	if r.glMultiDraw {
+		r.stats.DrawCalls++
+		gl.MultiDrawElements(mode, &r.multiCount[0], native.indexType, &r.multiOffsets[0], int32(n))
+		return
+	}
+	r.stats.DrawCalls += n
+	for i, offset := range r.multiOffsets {
+		gl.DrawElements(mode, r.multiCount[i], native.indexType, offset)
+	}
+}
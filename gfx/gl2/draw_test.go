@@ -0,0 +1,84 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/internal/glutil"
+)
+
+func TestObjectScissor(t *testing.T) {
+	var (
+		bounds  = image.Rect(0, 0, 8, 8)
+		clip    = image.Rect(2, 1, 5, 4)
+		white   = color.RGBA{255, 255, 255, 255}
+		s       = gfx.NewState()
+		scissor = objectScissor(bounds, s)
+	)
+	if scissor != bounds {
+		t.Fatal("got", scissor, "want", bounds, "without a ScissorRect")
+	}
+	s.ScissorRect = &clip
+	scissor = objectScissor(image.Rect(3, 0, 8, 8), s)
+	if want := image.Rect(3, 1, 5, 4); scissor != want {
+		t.Fatal("got", scissor, "want", want)
+	}
+
+	// Fill the whole canvas while emulating the OpenGL scissor test with the
+	// box it is given, then read the pixels back top-down like Download does.
+	// Only the pixels inside the ScissorRect may be drawn.
+	scissor = objectScissor(bounds, s)
+	x, y, w, h := glutil.ConvertRect(scissor, bounds)
+	img := image.NewRGBA(bounds)
+	for glY := 0; glY < bounds.Dy(); glY++ {
+		for glX := 0; glX < bounds.Dx(); glX++ {
+			if glX >= x && glX < x+w && glY >= y && glY < y+h {
+				img.SetRGBA(glX, bounds.Dy()-1-glY, white)
+			}
+		}
+	}
+	for py := 0; py < bounds.Dy(); py++ {
+		for px := 0; px < bounds.Dx(); px++ {
+			drawn := img.RGBAAt(px, py) == white
+			if want := image.Pt(px, py).In(clip); drawn != want {
+				t.Errorf("pixel (%d,%d) drawn=%v, want %v", px, py, drawn, want)
+			}
+		}
+	}
+}
+
+func TestMeshRanges(t *testing.T) {
+	// A mesh of 12 indices (four triangles), where index 6 may be a primitive
+	// restart index: it is an element of the index buffer like any other.
+	ranges := []gfx.MeshRange{
+		{Start: 0, Count: 6},
+		{Start: 6, Count: 6},                  // Ends exactly at the last index.
+		{Start: 9, Count: 6},                  // Past the end.
+		{Start: -3, Count: 6},                 // Before the start.
+		{Start: 3, Count: 0},                  // Empty.
+		{Start: 12, Count: 1},                 // Starts at the end.
+		{Start: 1, Count: int(^uint(0) >> 1)}, // Overflows Start+Count.
+	}
+	first, count, tris := meshRanges(nil, nil, ranges, 12)
+	if len(first) != 2 || first[0] != 0 || first[1] != 6 {
+		t.Fatal("got first", first)
+	}
+	if len(count) != 2 || count[0] != 6 || count[1] != 6 {
+		t.Fatal("got count", count)
+	}
+	if tris != 4 {
+		t.Fatal("got", tris, "triangles, want 4")
+	}
+
+	// The slices are appended to, such that the device can reuse them.
+	first, count, _ = meshRanges(first[:0], count[:0], ranges[1:2], 12)
+	if len(first) != 1 || first[0] != 6 || count[0] != 6 {
+		t.Fatal("got first", first, "count", count)
+	}
+}
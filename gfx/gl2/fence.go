@@ -0,0 +1,129 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"log"
+	"runtime"
+	"time"
+	"unsafe"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/internal/gl/2.0/gl"
+	"github.com/qmcloud/engine/gfx/internal/tag"
+)
+
+// fence implements the gfx.Fence interface.
+type fence struct {
+	r *device
+
+	// The sync object of the fence, or nil if the fence is signaled or the
+	// GL_ARB_sync extension is not present. It is only touched inside
+	// renderExec (or by the finalizer).
+	sync unsafe.Pointer
+
+	// Whether or not the fence is signaled. It is only touched inside
+	// renderExec.
+	signaled bool
+}
+
+// Wait implements the gfx.Fence interface.
+func (f *fence) Wait(timeout time.Duration) bool {
+	result := make(chan bool, 1)
+	ok := f.r.exec(func() bool {
+		result <- f.wait(timeout)
+		return false
+	})
+	if !ok {
+		return false
+	}
+	select {
+	case signaled := <-result:
+		return signaled
+	case <-f.r.destroyed:
+		return false
+	}
+}
+
+// wait waits for the fence to become signaled, see the Wait method.
+//
+// It may only be called inside renderExec.
+func (f *fence) wait(timeout time.Duration) bool {
+	if f.signaled {
+		return true
+	}
+	if f.sync == nil {
+		// No sync object, so wait for all operations to complete instead.
+		gl.Finish()
+		f.signaled = true
+		return true
+	}
+
+	if timeout < 0 {
+		timeout = 0
+	}
+	switch gl.ClientWaitSync(f.sync, gl.SYNC_FLUSH_COMMANDS_BIT, uint64(timeout)) {
+	case gl.TIMEOUT_EXPIRED:
+		return false
+	case gl.WAIT_FAILED:
+		f.r.warner.Warn("glClientWaitSync failed, waiting for all operations to complete instead.\n")
+		gl.Finish()
+	}
+
+	// The fence is signaled, we no longer need the sync object.
+	gl.DeleteSync(f.sync)
+	f.sync = nil
+	f.signaled = true
+	runtime.SetFinalizer(f, nil)
+	return true
+}
+
+// Fence implements the gfx.Device interface.
+func (r *device) Fence() gfx.Fence {
+	f := &fence{r: r}
+	if !r.glArbSync {
+		return f
+	}
+	r.exec(func() bool {
+		f.sync = gl.FenceSync(gl.SYNC_GPU_COMMANDS_COMPLETE, 0)
+
+		// Attach a finalizer to the fence that will later free the sync
+		// object, if the fence is never waited on until signaled.
+		runtime.SetFinalizer(f, finalizeFence)
+		return false
+	})
+	return f
+}
+
+// finalizeFence is the finalizer called to free the sync object of a fence.
+// It must be free'd in the presence of the OpenGL context, and thus we queue
+// it to be free'd at the next available time (next frame).
+func finalizeFence(f *fence) {
+	if f.sync == nil {
+		return
+	}
+	m := f.r.rsrcManager
+	m.Lock()
+	m.syncs = append(m.syncs, f.sync)
+	m.Unlock()
+	f.sync = nil
+}
+
+func (r *rsrcManager) freeSyncs() {
+	// Lock the list.
+	r.Lock()
+
+	if tag.Gfxdebug && len(r.syncs) > 0 {
+		log.Printf("gfx: free %d sync objects\n", len(r.syncs))
+	}
+	for i, sync := range r.syncs {
+		gl.DeleteSync(sync)
+		r.syncs[i] = nil
+	}
+
+	// Slice to zero, and unlock.
+	r.syncs = r.syncs[:0]
+	r.Unlock()
+}
@@ -5,9 +5,11 @@
 package gl2
 
 import (
+	"context"
 	"errors"
 	"image"
 	"io"
+	"time"
 
 	"github.com/qmcloud/engine/gfx"
 )
@@ -16,6 +18,14 @@ import (
 // device in a lesser version OpenGL context.
 var ErrInvalidVersion = errors.New("invalid OpenGL version; must be at least OpenGL 2.0")
 
+// ErrQueueSize is returned by New if the QueueSize option was given a size
+// less than one.
+var ErrQueueSize = errors.New("gl2: QueueSize must be at least one")
+
+// ErrDestroyed is returned by RenderContext, DrawContext, and Blit when the
+// device is destroyed before the operation completes.
+var ErrDestroyed = errors.New("gl2: device was destroyed")
+
 // Device is a OpenGL 2 based graphics device.
 //
 // It runs independant of the window management library being used (GLFW, SDL,
@@ -42,6 +52,139 @@ type Device interface {
 	// window resize).
 	UpdateBounds(bounds image.Rectangle)
 
+	// BeginGPUTimer begins measuring the time that the graphics hardware
+	// takes to execute all of the operations submitted to the device from now
+	// until EndGPUTimer is called. Only one GPU timer may be active at once.
+	//
+	// If the device does not support timer queries (see DeviceInfo's
+	// TimerQuery field) then this function is no-op.
+	BeginGPUTimer()
+
+	// EndGPUTimer ends the GPU timer started by BeginGPUTimer. The result is
+	// retrieved asynchronously (like occlusion query results are) and, once
+	// the graphics hardware has finished executing the measured operations,
+	// the elapsed time is sent over the done channel. The send is
+	// non-blocking, so the channel should be buffered.
+	//
+	// If the device does not support timer queries, or no GPU timer is
+	// active, nothing is ever sent over the done channel.
+	EndGPUTimer(done chan time.Duration)
+
+	// SetWireframe sets whether or not the device draws all objects as
+	// wireframes (i.e. only the edges of their polygons), regardless of each
+	// object's own state, which is useful for debugging geometry. Clear and
+	// blit operations are not affected. The default is false.
+	//
+	// It returns whether or not the device supports wireframe drawing. It is
+	// always supported by this package's OpenGL 2 devices, but not by e.g.
+	// OpenGL ES 2 or WebGL.
+	SetWireframe(enabled bool) bool
+
+	// OnPreRender registers a function to be called at the start of each
+	// frame that the device renders (i.e. each time it's Render method is
+	// called), before the pending operations of the frame are executed.
+	//
+	// OnPostRender registers a function to be called at the end of each frame
+	// that the device renders, after the pending operations of the frame are
+	// executed and flushed (but before the frame is reported as complete).
+	//
+	// Multiple functions may be registered, and they are called in the order
+	// they were registered. They are called on the goroutine executing the
+	// device's execution channel (see Exec) under the presence of the OpenGL
+	// context, and thus must not call device methods that wait on the
+	// execution channel (e.g. Render). Render-to-texture canvases do not call
+	// them.
+	OnPreRender(f func())
+	OnPostRender(f func())
+
+	// DrawMulti draws only the given ranges of the mesh's indices (or of it's
+	// vertices, if the mesh is not indexed) to the entire device, using the
+	// given state, shader, and camera. All of the ranges are drawn with a
+	// single draw call (via glMultiDrawElements or glMultiDrawArrays) where
+	// supported (see the MultiDraw field of gfx.DeviceInfo), such that many
+	// parts of a single large mesh (e.g. the visible chunks of a terrain) can
+	// be drawn without a draw call for each one.
+	//
+	// The mesh is drawn without a transform (i.e. it's vertices are in world
+	// space) and without textures. To draw ranges of a mesh with those, or to
+	// another canvas, set the Ranges field of the mesh and use Draw instead.
+	// Ranges which lie outside of the mesh are ignored, and the ranges slice
+	// may be reused once DrawMulti returns.
+	DrawMulti(m *gfx.Mesh, ranges []gfx.MeshRange, s *gfx.State, shader *gfx.Shader, c gfx.Camera)
+
+	// RenderDo executes f on the goroutine executing the device's execution
+	// channel (see Exec), under the presence of the OpenGL context, and waits
+	// for it to complete. It is useful for interoperating with other OpenGL
+	// code (e.g. to use the native OpenGL objects of loaded textures).
+	//
+	// Operations submitted to the device before RenderDo are executed before
+	// f. The function must restore any OpenGL state that it changes (or call
+	// RestoreState), and it must not call device methods that wait on the
+	// execution channel (e.g. Render, or RenderDo itself), or else it
+	// deadlocks. For the same reason RenderDo must not be called from a
+	// function registered via OnPreRender or OnPostRender.
+	RenderDo(f func())
+
+	// RenderContext is like Render, except that it stops waiting for the
+	// frame to be rendered once the given context is done or the device is
+	// destroyed, returning ctx.Err() or ErrDestroyed respectively. This
+	// prevents the caller from waiting forever if the goroutine executing the
+	// device's execution channel (see Exec) stops, e.g.:
+	//
+	//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	//	err := device.RenderContext(ctx)
+	//	cancel()
+	//
+	// When an error is returned the frame is abandoned: unless rendering of it
+	// had already begun, it is not rendered (e.g. the clock is not ticked) and
+	// anything drawn to it is instead rendered as part of the next frame.
+	RenderContext(ctx context.Context) error
+
+	// DrawContext is like Draw, except that it stops waiting for the shader,
+	// meshes, and textures of the object to load once the given context is
+	// done or the device is destroyed, returning ctx.Err() or ErrDestroyed
+	// respectively, in which case the object is not drawn. Other reasons for
+	// not drawing the object (e.g. it has no shader) are only warned about,
+	// just like with Draw, and nil is returned.
+	DrawContext(ctx context.Context, rect image.Rectangle, o *gfx.Object, c gfx.Camera) error
+
+	// Flush submits all of the operations submitted to the device so far to
+	// the graphics hardware (via glFlush), and waits until they have been
+	// submitted (not until they have completed). Unlike Render it does not
+	// end the frame, i.e. the clock is not ticked and (on a window) the
+	// buffers are not swapped.
+	//
+	// It is useful for progressive rendering, e.g. to let the graphics
+	// hardware begin work on a long-running render-to-texture bake before the
+	// frame ends.
+	Flush()
+
+	// SetGarbageInterval sets the interval at which the device frees the
+	// graphics resources (meshes, textures, etc) whose finalizers have run, in
+	// addition to freeing them upon each call to Render. The default interval
+	// is 200ms. A non-positive interval disables periodic freeing, such that
+	// resources are only freed upon Render or FreeNow. Pending occlusion
+	// query results are polled for periodically regardless of the interval.
+	//
+	// Calling it after the device is destroyed has no effect.
+	SetGarbageInterval(d time.Duration)
+
+	// FreeNow asks the device to free all of the graphics resources whose
+	// finalizers have run as soon as possible, rather than waiting for the
+	// next garbage interval or call to Render. Like other operations, it is
+	// performed asynchronously through the device's execution channel.
+	FreeNow()
+
+	// MemoryUsage returns the estimated amount of graphics memory in use by
+	// the textures, meshes, and render buffers loaded by the device.
+	//
+	// If the GL_NVX_gpu_memory_info or GL_ATI_meminfo extension is present,
+	// the video memory of the graphics hardware is reported as well (the ATI
+	// extension only reports the available memory). Querying it requires the
+	// device's execution channel to be processed, so MemoryUsage blocks until
+	// it is.
+	MemoryUsage() gfx.MemoryStats
+
 	// SetDebugOutput sets the writer, w, to write debug output to. It will
 	// mostly contain just shader debug information, but other information may
 	// be written in future versions as well.
@@ -71,6 +214,9 @@ type Device interface {
 	RestoreState()
 
 	// Destroy immediately destroys this device and it's associated assets.
+	// Operations submitted to the device afterwards are discarded, and methods
+	// which wait on the execution channel (e.g. RenderDo, Flush, or
+	// MemoryUsage) return instead of waiting forever.
 	//
 	// This function must be called under the presence of an OpenGL context.
 	Destroy()
@@ -82,11 +228,21 @@ type Option func(d *device)
 // Share is an option that specifies that this device should request the other
 // device to perform loading of all assets.
 //
-// The given other device must be from this package specifically, or else a
-// panic will occur.
+// The given other device must be from this package specifically, or else New
+// will return ErrShareDevice. Both devices must also use the same renderer
+// (i.e. the same graphics card), or else New will return an error describing
+// both of them. A nil other device is equivalent to not sharing at all.
 func Share(other Device) Option {
 	return func(d *device) {
-		d.shared.device = other.(*device)
+		if other == nil {
+			return
+		}
+		o, ok := other.(*device)
+		if !ok {
+			d.shareErr = ErrShareDevice
+			return
+		}
+		d.shared.device = o
 	}
 }
 
@@ -101,6 +257,65 @@ func DebugOutput(w io.Writer) Option {
 	}
 }
 
+// DepthRange specifies the mapping of depth values from normalized device
+// coordinates to window coordinates, as with glDepthRange. The default is the
+// standard range of near=0, far=1.
+//
+// For example, reversed-Z depth (which has better depth precision in large
+// scenes) can be achieved by using DepthRange(1, 0) along with the
+// gfx.Greater DepthCmp state on objects, and clearing the depth buffer to
+// zero instead of one.
+func DepthRange(near, far float64) Option {
+	return func(d *device) {
+		d.depthRange = [2]float64{near, far}
+	}
+}
+
+// OcclusionQueries specifies whether or not the device should perform
+// occlusion queries for objects whose OcclusionTest field is set. The default
+// is true.
+//
+// Waiting for query results to arrive is a busy-wait which, on some drivers,
+// noticeably raises CPU usage. Applications that never read an object's
+// SampleCount may disable occlusion queries entirely, in which case no queries
+// are issued, QueryWait and QueryPoll are no-op, and the OcclusionQuery field
+// of DeviceInfo is false.
+func OcclusionQueries(enabled bool) Option {
+	return func(d *device) {
+		d.noOcclusionQueries = !enabled
+	}
+}
+
+// SeamlessCubeMaps specifies whether or not the device should filter cube maps
+// seamlessly (i.e. across the edges of their faces), which avoids visible
+// seams at the edges of e.g. reflection cube maps. The default is false.
+//
+// It requires OpenGL 3.2 or the GL_ARB_seamless_cube_map extension, see the
+// SeamlessCubeMaps field of DeviceInfo. If not supported, the option is
+// ignored.
+func SeamlessCubeMaps(enabled bool) Option {
+	return func(d *device) {
+		d.seamlessCubeMaps = enabled
+	}
+}
+
+// QueueSize specifies the number of functions that the device's execution
+// channel (see the Exec method) can buffer. The default is 1024. If n is less
+// than one, New returns ErrQueueSize.
+//
+// Nearly every operation (e.g. Clear, Draw, and loading assets) is sent to the
+// execution channel, and once it is full such operations block the calling
+// goroutine until the functions sent previously are executed (e.g. by the
+// window library executing them under the OpenGL context). A larger queue lets
+// more operations be submitted ahead of the execution without blocking, at the
+// cost of memory for the pending functions; a smaller queue applies this
+// backpressure sooner, which can be desirable on low-memory targets.
+func QueueSize(n int) Option {
+	return func(d *device) {
+		d.queueSize = n
+	}
+}
+
 // New returns a new OpenGL 2 graphics device. If any error occurs it is
 // returned along with a nil device.
 //
@@ -28,7 +28,25 @@ type nativeMesh struct {
 	texCoords                   []uint32
 	attribs                     map[string]*nativeAttrib
 	verticesCount, indicesCount int32
+	indexType                   uint32 // gl.UNSIGNED_SHORT or gl.UNSIGNED_INT
 	r                           *rsrcManager
+
+	// The data store of each VBO (for in-place updates, see updateVBO).
+	stores map[uint32]vboStore
+}
+
+// vboStore describes the data store of a VBO, as last allocated via
+// glBufferData.
+type vboStore struct {
+	size  int   // Size in bytes.
+	usage int32 // Usage hint, e.g. gl.STATIC_DRAW.
+}
+
+// inPlace tells if the data store can be updated in-place (via glBufferSubData)
+// with size bytes of data, instead of being reallocated with the given usage
+// hint. Static data stores are always reallocated.
+func (s vboStore) inPlace(usageHint int32, size int) bool {
+	return usageHint != gl.STATIC_DRAW && s.usage == usageHint && s.size == size
 }
 
 // Destroy implements the gfx.Destroyable interface.
@@ -36,6 +54,11 @@ func (n *nativeMesh) Destroy() {
 	finalizeMesh(n)
 }
 
+// GLID implements the interface used by gfx.Mesh.GLID.
+func (n *nativeMesh) GLID() (vertices, indices uint32) {
+	return n.vertices, n.indices
+}
+
 // finalizeMesh is the finalizer called to free the native mesh object. It must
 // be free'd in the presence of the OpenGL context, and thus we queue it to be
 // free'd at the next available time (next frame).
@@ -54,6 +77,11 @@ func finalizeMesh(n *nativeMesh) {
 // free literally frees the native mesh object right now. It may only be
 // called under the presence of the OpenGL context.
 func (n *nativeMesh) free() {
+	// Account for the freed memory.
+	for _, store := range n.stores {
+		n.r.mem.meshes.Add(-int64(store.size))
+	}
+
 	// Delete indices VBO.
 	gl.DeleteBuffers(1, &n.indices)
 
@@ -82,24 +110,39 @@ func (r *device) createVBO() (vboID uint32) {
 	return
 }
 
-func (r *device) updateVBO(usageHint int32, dataSize uintptr, dataLength int, data unsafe.Pointer, vboID uint32) {
+// updateVBO fills the given VBO with data. If the VBO is non-static (as
+// determined by the usage hint) and neither it's size nor it's usage hint has
+// changed since it was last filled (according to the stores map) then it is
+// updated in-place instead of reallocating it's data store.
+func (r *device) updateVBO(usageHint int32, dataSize uintptr, dataLength int, data unsafe.Pointer, vboID uint32, stores map[uint32]vboStore) {
 	// Bind the VBO now.
 	gl.BindBuffer(gl.ARRAY_BUFFER, vboID)
 
+	size := int(dataSize * uintptr(dataLength))
+	if stores[vboID].inPlace(usageHint, size) {
+		// Update the VBO's data in-place.
+		gl.BufferSubData(gl.ARRAY_BUFFER, 0, size, data)
+		return
+	}
+
 	// Fill the VBO with the data.
 	gl.BufferData(
 		gl.ARRAY_BUFFER,
-		int(dataSize*uintptr(dataLength)),
+		size,
 		data,
 		uint32(usageHint),
 	)
+	r.rsrcManager.mem.meshes.Add(int64(size - stores[vboID].size))
+	stores[vboID] = vboStore{size: size, usage: usageHint}
 }
 
-func (r *device) deleteVBO(vboID *uint32) {
+func (r *device) deleteVBO(vboID *uint32, stores map[uint32]vboStore) {
 	// Delete the VBO.
 	if *vboID == 0 {
 		return
 	}
+	r.rsrcManager.mem.meshes.Add(-int64(stores[*vboID].size))
+	delete(stores, *vboID)
 	gl.DeleteBuffers(1, vboID)
 	*vboID = 0 // Just for safety.
 }
@@ -134,7 +177,7 @@ func attribSize(x interface{}) (rows uint32, size int32, ok bool) {
 	return 0, 0, false
 }
 
-func (r *device) updateCustomAttribVBO(usageHint int32, name string, attrib gfx.VertexAttrib, n *nativeAttrib) {
+func (r *device) updateCustomAttribVBO(usageHint int32, name string, attrib gfx.VertexAttrib, n *nativeAttrib, stores map[uint32]vboStore) {
 	v := reflect.ValueOf(attrib.Data)
 
 	// If it's not a slice, or it's length is zero, then it is invalid.
@@ -189,6 +232,7 @@ func (r *device) updateCustomAttribVBO(usageHint int32, name string, attrib gfx.
 				vIndexZero.Len(),
 				data,
 				n.vbos[i],
+				stores,
 			)
 		}
 	} else {
@@ -199,10 +243,34 @@ func (r *device) updateCustomAttribVBO(usageHint int32, name string, attrib gfx.
 			v.Len(),
 			data,
 			n.vbos[0],
+			stores,
 		)
 	}
 }
 
+// splitTexCoords splits the texture coordinate set VBO's of a native mesh into
+// the ones kept for a mesh with n sets, and the ones of removed sets, which
+// should be deleted.
+func splitTexCoords(vbos []uint32, n int) (kept, deleted []uint32) {
+	if n > len(vbos) {
+		n = len(vbos)
+	}
+	return vbos[:n], vbos[n:]
+}
+
+// changedTexCoords returns the indices of the texture coordinate sets whose
+// (non-empty) data must be uploaded, and marks every set as no longer changed.
+func changedTexCoords(sets []gfx.TexCoordSet) []int {
+	var changed []int
+	for i := range sets {
+		if sets[i].Changed && len(sets[i].Slice) > 0 {
+			changed = append(changed, i)
+		}
+		sets[i].Changed = false
+	}
+	return changed
+}
+
 // LoadMesh implements the gfx.Renderer interface.
 func (r *device) LoadMesh(m *gfx.Mesh, done chan *gfx.Mesh) {
 	// If we are sharing assets with another renderer, allow it to load the
@@ -226,13 +294,14 @@ func (r *device) LoadMesh(m *gfx.Mesh, done chan *gfx.Mesh) {
 		return
 	}
 
-	r.renderExec <- func() bool {
+	r.exec(func() bool {
 		// Find the native mesh, creating a new one if the mesh is not loaded.
 		var native *nativeMesh
 		if !m.Loaded {
 			native = &nativeMesh{
 				r:       r.rsrcManager,
 				attribs: make(map[string]*nativeAttrib),
+				stores:  make(map[uint32]vboStore),
 			}
 		} else {
 			native = m.NativeMesh.(*nativeMesh)
@@ -240,28 +309,50 @@ func (r *device) LoadMesh(m *gfx.Mesh, done chan *gfx.Mesh) {
 
 		// Determine usage hint.
 		usageHint := int32(gl.STATIC_DRAW)
-		if m.Dynamic {
+		switch m.EffectiveUsage() {
+		case gfx.Dynamic:
 			usageHint = gl.DYNAMIC_DRAW
+		case gfx.Stream:
+			usageHint = gl.STREAM_DRAW
 		}
 
 		// Update Indices VBO.
 		if !m.Loaded || m.IndicesChanged {
 			if len(m.Indices) == 0 {
 				// Delete indices VBO.
-				r.deleteVBO(&native.indices)
+				r.deleteVBO(&native.indices, native.stores)
+				native.indicesCount = 0
 			} else {
 				if native.indices == 0 {
 					// Create indices VBO.
 					native.indices = r.createVBO()
 				}
-				// Update indices VBO.
-				r.updateVBO(
-					usageHint,
-					unsafe.Sizeof(m.Indices[0]),
-					len(m.Indices),
-					unsafe.Pointer(&m.Indices[0]),
-					native.indices,
-				)
+				// Update indices VBO, using 16-bit indices when possible.
+				if m.EffectiveIndexType() == gfx.Uint16Index {
+					indices := make([]uint16, len(m.Indices))
+					for i, index := range m.Indices {
+						indices[i] = uint16(index)
+					}
+					r.updateVBO(
+						usageHint,
+						unsafe.Sizeof(indices[0]),
+						len(indices),
+						unsafe.Pointer(&indices[0]),
+						native.indices,
+						native.stores,
+					)
+					native.indexType = gl.UNSIGNED_SHORT
+				} else {
+					r.updateVBO(
+						usageHint,
+						unsafe.Sizeof(m.Indices[0]),
+						len(m.Indices),
+						unsafe.Pointer(&m.Indices[0]),
+						native.indices,
+						native.stores,
+					)
+					native.indexType = gl.UNSIGNED_INT
+				}
 				native.indicesCount = int32(len(m.Indices))
 			}
 			m.IndicesChanged = false
@@ -271,7 +362,7 @@ func (r *device) LoadMesh(m *gfx.Mesh, done chan *gfx.Mesh) {
 		if !m.Loaded || m.VerticesChanged {
 			if len(m.Vertices) == 0 {
 				// Delete vertices VBO.
-				r.deleteVBO(&native.vertices)
+				r.deleteVBO(&native.vertices, native.stores)
 				native.verticesCount = 0
 			} else {
 				if native.vertices == 0 {
@@ -285,6 +376,7 @@ func (r *device) LoadMesh(m *gfx.Mesh, done chan *gfx.Mesh) {
 					len(m.Vertices),
 					unsafe.Pointer(&m.Vertices[0]),
 					native.vertices,
+					native.stores,
 				)
 				native.verticesCount = int32(len(m.Vertices))
 			}
@@ -307,51 +399,68 @@ func (r *device) LoadMesh(m *gfx.Mesh, done chan *gfx.Mesh) {
 				Changed: m.BaryChanged,
 			}
 		}
+		if len(m.Tangents) != 0 {
+			allAttribs["Tangent"] = gfx.VertexAttrib{
+				Data:    m.Tangents,
+				Changed: m.TangentsChanged,
+			}
+		}
+		if len(m.BoneIndices) != 0 {
+			allAttribs["BoneIndices"] = gfx.VertexAttrib{
+				Data:    m.BoneIndices,
+				Changed: m.BoneIndicesChanged,
+			}
+		}
+		if len(m.BoneWeights) != 0 {
+			allAttribs["BoneWeights"] = gfx.VertexAttrib{
+				Data:    m.BoneWeights,
+				Changed: m.BoneWeightsChanged,
+			}
+		}
 
 		// Any texture coordinate sets that were removed should have their
 		// VBO's deleted.
-		deletedMax := len(m.TexCoords)
-		if deletedMax > len(native.texCoords) {
-			deletedMax = len(native.texCoords)
-		}
-		deleted := native.texCoords[:deletedMax]
-		native.texCoords = native.texCoords[:deletedMax]
+		var deleted []uint32
+		native.texCoords, deleted = splitTexCoords(native.texCoords, len(m.TexCoords))
 		for _, vbo := range deleted {
-			r.deleteVBO(&vbo)
+			r.deleteVBO(&vbo, native.stores)
 		}
 
 		// Any texture coordinate sets that were added should have VBO's
 		// created.
 		added := m.TexCoords[len(native.texCoords):]
-		toUpdate := m.TexCoords
+		toUpdate := changedTexCoords(m.TexCoords)
 		for _, set := range added {
 			vbo := r.createVBO()
 			native.texCoords = append(native.texCoords, vbo)
 
 			// Update the VBO.
+			if len(set.Slice) == 0 {
+				continue
+			}
 			r.updateVBO(
 				usageHint,
 				unsafe.Sizeof(set.Slice[0]),
 				len(set.Slice),
 				unsafe.Pointer(&set.Slice[0]),
 				vbo,
+				native.stores,
 			)
 		}
 
 		// And finally, any texture coordinate sets that were changed need to
 		// have their VBO's updated.
-		for index, set := range toUpdate {
-			if set.Changed {
-				// Update the VBO.
-				r.updateVBO(
-					usageHint,
-					unsafe.Sizeof(set.Slice[0]),
-					len(set.Slice),
-					unsafe.Pointer(&set.Slice[0]),
-					native.texCoords[index],
-				)
-				set.Changed = false
-			}
+		for _, index := range toUpdate {
+			// Update the VBO.
+			set := m.TexCoords[index]
+			r.updateVBO(
+				usageHint,
+				unsafe.Sizeof(set.Slice[0]),
+				len(set.Slice),
+				unsafe.Pointer(&set.Slice[0]),
+				native.texCoords[index],
+				native.stores,
+			)
 		}
 
 		// Any custom attributes that were removed should have their VBO's
@@ -363,7 +472,7 @@ func (r *device) LoadMesh(m *gfx.Mesh, done chan *gfx.Mesh) {
 				continue
 			}
 			for _, vbo := range attrib.vbos {
-				r.deleteVBO(&vbo)
+				r.deleteVBO(&vbo, native.stores)
 			}
 			delete(native.attribs, name)
 		}
@@ -384,6 +493,7 @@ func (r *device) LoadMesh(m *gfx.Mesh, done chan *gfx.Mesh) {
 				name,
 				attrib,
 				nAttrib,
+				native.stores,
 			)
 		}
 
@@ -398,6 +508,7 @@ func (r *device) LoadMesh(m *gfx.Mesh, done chan *gfx.Mesh) {
 					name,
 					attrib,
 					nAttrib,
+					native.stores,
 				)
 				attrib.Changed = false
 			}
@@ -429,5 +540,5 @@ func (r *device) LoadMesh(m *gfx.Mesh, done chan *gfx.Mesh) {
 		default:
 		}
 		return false // no frame rendered.
-	}
+	})
 }
@@ -0,0 +1,56 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+func TestSplitTexCoords(t *testing.T) {
+	tests := []struct {
+		vbos          []uint32
+		n             int
+		kept, deleted []uint32
+	}{
+		{[]uint32{1, 2, 3}, 3, []uint32{1, 2, 3}, []uint32{}},
+		{[]uint32{1, 2, 3}, 5, []uint32{1, 2, 3}, []uint32{}},
+
+		// Only the VBO's of the removed sets are deleted, not the kept ones.
+		{[]uint32{1, 2, 3}, 1, []uint32{1}, []uint32{2, 3}},
+		{[]uint32{1, 2, 3}, 0, []uint32{}, []uint32{1, 2, 3}},
+	}
+	for _, tst := range tests {
+		kept, deleted := splitTexCoords(tst.vbos, tst.n)
+		if !reflect.DeepEqual(kept, tst.kept) || !reflect.DeepEqual(deleted, tst.deleted) {
+			t.Errorf("splitTexCoords(%v, %d) = %v, %v, want %v, %v", tst.vbos, tst.n, kept, deleted, tst.kept, tst.deleted)
+		}
+	}
+}
+
+func TestChangedTexCoords(t *testing.T) {
+	coords := []gfx.TexCoord{{U: 0, V: 0}, {U: 1, V: 1}}
+	m := gfx.NewMesh()
+	m.TexCoords = []gfx.TexCoordSet{
+		{Slice: coords, Changed: true},
+		{Slice: coords},
+		{Changed: true}, // Empty, so never uploaded.
+		{Slice: coords, Changed: true},
+	}
+	if got, want := changedTexCoords(m.TexCoords), []int{0, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatal("got", got, "want", want)
+	}
+
+	// The sets of the mesh itself (not copies of them) are no longer changed,
+	// so the mesh is not reloaded again.
+	if m.HasChanged() {
+		t.Fatal("mesh has changed after uploading it's texture coordinates")
+	}
+	if got := changedTexCoords(m.TexCoords); len(got) != 0 {
+		t.Fatal("got", got, "want none")
+	}
+}
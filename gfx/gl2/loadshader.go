@@ -6,6 +6,7 @@ package gl2
 
 import (
 	"runtime"
+	"unsafe"
 
 	"github.com/qmcloud/engine/gfx"
 	"github.com/qmcloud/engine/gfx/internal/gl/2.0/gl"
@@ -16,8 +17,75 @@ import (
 // shader IDs.
 type nativeShader struct {
 	*glutil.LocationCache
-	program, vertex, fragment uint32
-	r                         *rsrcManager
+	program, vertex, fragment, geometry uint32
+	r                                   *rsrcManager
+
+	// Uniform block indices and the binding points they are currently
+	// assigned to, by block name. See useUniformBlock.
+	blocks map[string]blockBinding
+
+	// The active uniforms and attributes of the program, queried once the
+	// program is linked.
+	uniforms []gfx.UniformInfo
+	attribs  []gfx.AttribInfo
+}
+
+// ActiveUniforms implements the interface used by gfx.Shader.ActiveUniforms.
+func (n *nativeShader) ActiveUniforms() []gfx.UniformInfo {
+	return n.uniforms
+}
+
+// ActiveAttributes implements the interface used by
+// gfx.Shader.ActiveAttributes.
+func (n *nativeShader) ActiveAttributes() []gfx.AttribInfo {
+	return n.attribs
+}
+
+// GLID implements the interface used by gfx.Shader.GLID.
+func (n *nativeShader) GLID() uint32 {
+	return n.program
+}
+
+// queryActive queries the active uniforms and attributes of the linked shader
+// program. It may only be called under the presence of the OpenGL context.
+func (n *nativeShader) queryActive() {
+	var count, maxLength int32
+
+	// Query the active uniforms.
+	gl.GetProgramiv(n.program, gl.ACTIVE_UNIFORMS, &count)
+	gl.GetProgramiv(n.program, gl.ACTIVE_UNIFORM_MAX_LENGTH, &maxLength)
+	if count > 0 && maxLength > 0 {
+		n.uniforms = make([]gfx.UniformInfo, count)
+		name := make([]byte, maxLength)
+		for i := range n.uniforms {
+			var length, size int32
+			var xtype uint32
+			gl.GetActiveUniform(n.program, uint32(i), maxLength, &length, &size, &xtype, &name[0])
+			n.uniforms[i] = gfx.UniformInfo{
+				Name: string(name[:length]),
+				Type: xtype,
+				Size: int(size),
+			}
+		}
+	}
+
+	// Query the active attributes.
+	gl.GetProgramiv(n.program, gl.ACTIVE_ATTRIBUTES, &count)
+	gl.GetProgramiv(n.program, gl.ACTIVE_ATTRIBUTE_MAX_LENGTH, &maxLength)
+	if count > 0 && maxLength > 0 {
+		n.attribs = make([]gfx.AttribInfo, count)
+		name := make([]byte, maxLength)
+		for i := range n.attribs {
+			var length, size int32
+			var xtype uint32
+			gl.GetActiveAttrib(n.program, uint32(i), maxLength, &length, &size, &xtype, &name[0])
+			n.attribs[i] = gfx.AttribInfo{
+				Name: string(name[:length]),
+				Type: xtype,
+				Size: int(size),
+			}
+		}
+	}
 }
 
 // Implements gfx.Destroyable interface.
@@ -48,6 +116,9 @@ func (n *nativeShader) free() {
 	// them anyway).
 	gl.DeleteShader(n.vertex)
 	gl.DeleteShader(n.fragment)
+	if n.geometry != 0 {
+		gl.DeleteShader(n.geometry)
+	}
 
 	// Delete program.
 	gl.DeleteProgram(n.program)
@@ -80,6 +151,22 @@ func shaderCompilerLog(s uint32) (log []byte, compiled bool) {
 	return log, ok == 1
 }
 
+// See: https://www.opengl.org/registry/specs/ARB/geometry_shader4.txt (the
+// token is the same for the core OpenGL 3.2 version).
+const glGEOMETRY_SHADER = 0x8DD9
+
+// compileSPIRV creates a shader object of the given type from the SPIR-V
+// binary and specializes it's "main" entry point. The result can be checked
+// using shaderCompilerLog, just like with GLSL sources.
+//
+// It may only be called under the presence of the OpenGL context.
+func compileSPIRV(xtype uint32, binary []byte) uint32 {
+	shader := gl.CreateShader(xtype)
+	gl.ShaderBinary(1, &shader, gl.SHADER_BINARY_FORMAT_SPIR_V_ARB, unsafe.Pointer(&binary[0]), int32(len(binary)))
+	gl.SpecializeShaderARB(shader, gl.Str("main\x00"), 0, nil, nil)
+	return shader
+}
+
 // LoadShader implements the gfx.Renderer interface.
 func (r *device) LoadShader(s *gfx.Shader, done chan *gfx.Shader) {
 	// If we are sharing assets with another renderer, allow it to load the
@@ -93,7 +180,7 @@ func (r *device) LoadShader(s *gfx.Shader, done chan *gfx.Shader) {
 	r.shared.RUnlock()
 
 	// Perform pre-load checks on the shader.
-	doLoad, err := glutil.PreLoadShader(s, done)
+	doLoad, err := glutil.PreLoadShader(s, done, r.devInfo.SPIRV)
 	if err != nil {
 		r.warner.Warnf("%v\n", err)
 		return
@@ -102,17 +189,31 @@ func (r *device) LoadShader(s *gfx.Shader, done chan *gfx.Shader) {
 		return
 	}
 
-	r.renderExec <- func() bool {
+	r.exec(func() bool {
 		native := &nativeShader{
 			r: r.rsrcManager,
 		}
 
+		// If the shader is being reloaded (e.g. because it's sources have
+		// changed) then the old shader program must be freed.
+		if s.NativeShader != nil {
+			s.NativeShader.Destroy()
+			s.NativeShader = nil
+		}
+
+		// Prefer the SPIR-V binaries, if the device supports them.
+		useSPIRV := glutil.UseSPIRV(s, r.devInfo.SPIRV)
+
 		// Compile vertex shader.
-		native.vertex = gl.CreateShader(gl.VERTEX_SHADER)
-		sources, free := gl.Strs(string(s.GLSL.Vertex) + "\x00")
-		gl.ShaderSource(native.vertex, 1, sources, nil) // TODO(slimsag): use length parameter instead of null terminator
-		gl.CompileShader(native.vertex)
-		free()
+		if useSPIRV {
+			native.vertex = compileSPIRV(gl.VERTEX_SHADER, s.SPIRV.Vertex)
+		} else {
+			native.vertex = gl.CreateShader(gl.VERTEX_SHADER)
+			sources, free := gl.Strs(string(s.GLSL.Vertex) + "\x00")
+			gl.ShaderSource(native.vertex, 1, sources, nil) // TODO(slimsag): use length parameter instead of null terminator
+			gl.CompileShader(native.vertex)
+			free()
+		}
 
 		// Check if the shader compiled or not.
 		log, compiled := shaderCompilerLog(native.vertex)
@@ -131,11 +232,15 @@ func (r *device) LoadShader(s *gfx.Shader, done chan *gfx.Shader) {
 		}
 
 		// Compile fragment shader.
-		native.fragment = gl.CreateShader(gl.FRAGMENT_SHADER)
-		sources, free = gl.Strs(string(s.GLSL.Fragment) + "\x00")
-		gl.ShaderSource(native.fragment, 1, sources, nil) // TODO(slimsag): use length parameter instead of null terminator
-		gl.CompileShader(native.fragment)
-		free()
+		if useSPIRV {
+			native.fragment = compileSPIRV(gl.FRAGMENT_SHADER, s.SPIRV.Fragment)
+		} else {
+			native.fragment = gl.CreateShader(gl.FRAGMENT_SHADER)
+			sources, free := gl.Strs(string(s.GLSL.Fragment) + "\x00")
+			gl.ShaderSource(native.fragment, 1, sources, nil) // TODO(slimsag): use length parameter instead of null terminator
+			gl.CompileShader(native.fragment)
+			free()
+		}
 
 		// Check if the shader compiled or not.
 		log, compiled = shaderCompilerLog(native.fragment)
@@ -153,12 +258,47 @@ func (r *device) LoadShader(s *gfx.Shader, done chan *gfx.Shader) {
 			r.warner.Warnf(string(log))
 		}
 
-		// Create the shader program if all went well with the vertex and
-		// fragment shaders.
-		if native.vertex != 0 && native.fragment != 0 {
+		// Compile geometry shader, if any.
+		// Geometry shaders are only loaded from GLSL sources.
+		hasGeometry := !useSPIRV && len(s.GLSL.Geometry) > 0
+		if hasGeometry && !r.devInfo.GeometryShaders {
+			s.Error = append(s.Error, []byte(s.Name+" | Geometry shaders are not supported by the device.\n")...)
+			r.warner.Warnf("%s | Geometry shaders are not supported by the device.\n", s.Name)
+		} else if hasGeometry {
+			native.geometry = gl.CreateShader(glGEOMETRY_SHADER)
+			sources, free := gl.Strs(string(s.GLSL.Geometry) + "\x00")
+			gl.ShaderSource(native.geometry, 1, sources, nil) // TODO(slimsag): use length parameter instead of null terminator
+			gl.CompileShader(native.geometry)
+			free()
+
+			// Check if the shader compiled or not.
+			log, compiled = shaderCompilerLog(native.geometry)
+			if !compiled {
+				// Delete the shader object now, as it will never be attached
+				// to a program.
+				gl.DeleteShader(native.geometry)
+				native.geometry = 0
+
+				// Append the errors.
+				s.Error = append(s.Error, []byte(s.Name+" | Geometry shader errors:\n")...)
+				s.Error = append(s.Error, log...)
+			}
+			if len(log) > 0 {
+				// Send the compiler log to the debug writer.
+				r.warner.Warnf("%s | Geometry shader errors:\n", s.Name)
+				r.warner.Warnf(string(log))
+			}
+		}
+
+		// Create the shader program if all went well with the vertex,
+		// fragment, and (optional) geometry shaders.
+		if native.vertex != 0 && native.fragment != 0 && (!hasGeometry || native.geometry != 0) {
 			native.program = gl.CreateProgram()
 			gl.AttachShader(native.program, native.vertex)
 			gl.AttachShader(native.program, native.fragment)
+			if native.geometry != 0 {
+				gl.AttachShader(native.program, native.geometry)
+			}
 			gl.LinkProgram(native.program)
 
 			// Grab the linker's log.
@@ -196,14 +336,24 @@ func (r *device) LoadShader(s *gfx.Shader, done chan *gfx.Shader) {
 
 		// Mark the shader as loaded if there were no errors.
 		if len(s.Error) == 0 {
-			native.LocationCache = &glutil.LocationCache{
-				GetAttribLocation: func(name string) int {
-					return int(gl.GetAttribLocation(native.program, gl.Str(name+"\x00")))
-				},
-				GetUniformLocation: func(name string) int {
-					return int(gl.GetUniformLocation(native.program, gl.Str(name+"\x00")))
-				},
+			if useSPIRV {
+				// SPIR-V programs need not retain the names of their inputs,
+				// so they are bound by their explicit locations instead.
+				native.LocationCache = &glutil.LocationCache{
+					GetAttribLocation:  glutil.LocationMap(s.SPIRV.Attribs),
+					GetUniformLocation: glutil.LocationMap(s.SPIRV.Uniforms),
+				}
+			} else {
+				native.LocationCache = &glutil.LocationCache{
+					GetAttribLocation: func(name string) int {
+						return int(gl.GetAttribLocation(native.program, gl.Str(name+"\x00")))
+					},
+					GetUniformLocation: func(name string) int {
+						return int(gl.GetUniformLocation(native.program, gl.Str(name+"\x00")))
+					},
+				}
 			}
+			native.queryActive()
 
 			s.Loaded = true
 			s.NativeShader = native
@@ -222,5 +372,5 @@ func (r *device) LoadShader(s *gfx.Shader, done chan *gfx.Shader) {
 		default:
 		}
 		return false // no frame rendered.
-	}
+	})
 }
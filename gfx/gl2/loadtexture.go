@@ -6,6 +6,7 @@ package gl2
 
 import (
 	"image"
+	"image/color"
 	"image/draw"
 	"log"
 	"runtime"
@@ -21,18 +22,24 @@ import (
 type nativeTexture struct {
 	r              *device
 	id             uint32
+	target         uint32
 	internalFormat int32
 	width, height  int
 	rttCanvas      *rttCanvas
 	destroyHandler func(n *nativeTexture)
+
+	// The estimated size of the texture in bytes, see account.
+	size int64
 }
 
-// Generates texture ID, binds, and sets BASE/MAX mipmap levels to zero.
+// Generates texture ID, binds it to the given target (e.g. gl.TEXTURE_2D), and
+// sets BASE/MAX mipmap levels.
 //
 // Used by both LoadTexture and RenderToTexture methods.
-func newNativeTexture(r *device, internalFormat int32, width, height int) *nativeTexture {
+func newNativeTexture(r *device, target uint32, internalFormat int32, width, height int) *nativeTexture {
 	tex := &nativeTexture{
 		r:              r,
+		target:         target,
 		internalFormat: internalFormat,
 		width:          width,
 		height:         height,
@@ -40,9 +47,9 @@ func newNativeTexture(r *device, internalFormat int32, width, height int) *nativ
 	}
 	gl.GenTextures(1, &tex.id)
 
-	gl.BindTexture(gl.TEXTURE_2D, tex.id)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_BASE_LEVEL, 0)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAX_LEVEL, 1000)
+	gl.BindTexture(target, tex.id)
+	gl.TexParameteri(target, gl.TEXTURE_BASE_LEVEL, 0)
+	gl.TexParameteri(target, gl.TEXTURE_MAX_LEVEL, 1000)
 	return tex
 }
 
@@ -56,9 +63,16 @@ func (n *nativeTexture) ChosenFormat() gfx.TexFormat {
 	return unconvertTexFormat(n.internalFormat)
 }
 
+// GLID implements the interface used by gfx.Texture.GLID.
+func (n *nativeTexture) GLID() uint32 {
+	return n.id
+}
+
 func finalizeTexture(n *nativeTexture) {
 	n.r.rsrcManager.Lock()
 	n.r.rsrcManager.textures = append(n.r.rsrcManager.textures, n.id)
+	n.r.rsrcManager.mem.textures.Add(-n.size)
+	n.size = 0
 	n.r.rsrcManager.Unlock()
 }
 
@@ -72,13 +86,19 @@ func (n *nativeTexture) Download(rect image.Rectangle, complete chan image.Image
 		return
 	}
 
+	if n.target != gl.TEXTURE_2D {
+		n.r.warner.Warnf("Download(): cube map textures cannot be downloaded; returning nil\n")
+		complete <- nil
+		return
+	}
+
 	if n.internalFormat != gl.RGBA {
 		n.r.warner.Warnf("Download(): invalid (non-RGBA) texture format; returning nil\n")
 		complete <- nil
 		return
 	}
 
-	n.r.renderExec <- func() bool {
+	n.r.exec(func() bool {
 		// Create a FBO, bind it now.
 		var fbo uint32
 		gl.GenFramebuffers(1, &fbo)
@@ -129,7 +149,7 @@ func (n *nativeTexture) Download(rect image.Rectangle, complete chan image.Image
 
 		complete <- img
 		return false // no frame rendered.
-	}
+	})
 }
 
 func prepareImage(npot bool, img image.Image) *image.RGBA {
@@ -153,6 +173,87 @@ func prepareImage(npot bool, img image.Image) *image.RGBA {
 	return rgba
 }
 
+// texturePixels returns the pixels of the given texture for uploading, along
+// with their size and OpenGL pixel format (gl.RGBA or gl.RGB). The raw pixel
+// data of the texture is used directly when OpenGL can read it as-is,
+// otherwise the Source image (or an image of the raw pixel data) is prepared
+// via prepareImage.
+//
+// If the texture's PremultipliedAlpha field is set and it's pixels may have
+// straight alpha (see straightAlpha), the returned pixels are a premultiplied
+// copy.
+func texturePixels(npot bool, t *gfx.Texture) (pix []uint8, size image.Point, format uint32) {
+	pix, size, format = sourcePixels(npot, t)
+	if t.PremultipliedAlpha && format == gl.RGBA {
+		raw := t.Source == nil && t.Raw != nil
+		if raw || straightAlpha(t.Source) {
+			pix = premultiply(pix)
+		}
+	}
+	return
+}
+
+// straightAlpha reports whether the pixels of the given source image are used
+// as-is by prepareImage, and may thus have straight alpha. Images of any other
+// type (e.g. *image.NRGBA) are converted to premultiplied alpha by
+// prepareImage already, and must not be premultiplied a second time.
+func straightAlpha(img image.Image) bool {
+	_, ok := img.(*image.RGBA)
+	return ok
+}
+
+// premultiply returns a copy of the given RGBA pixels, with the RGB components
+// of each pixel multiplied by it's alpha component.
+func premultiply(pix []uint8) []uint8 {
+	cpy := make([]uint8, len(pix))
+	for i := 0; i+3 < len(pix); i += 4 {
+		a := uint32(pix[i+3])
+		cpy[i+0] = uint8((uint32(pix[i+0])*a + 127) / 255)
+		cpy[i+1] = uint8((uint32(pix[i+1])*a + 127) / 255)
+		cpy[i+2] = uint8((uint32(pix[i+2])*a + 127) / 255)
+		cpy[i+3] = pix[i+3]
+	}
+	return cpy
+}
+
+// sourcePixels implements texturePixels, without premultiplying.
+func sourcePixels(npot bool, t *gfx.Texture) (pix []uint8, size image.Point, format uint32) {
+	if t.Source != nil || t.Raw == nil {
+		src := prepareImage(npot, t.Source)
+		return src.Pix, src.Bounds().Size(), gl.RGBA
+	}
+
+	size = t.Bounds.Size()
+	bpp, format := 4, uint32(gl.RGBA)
+	if t.RawFormat == gfx.RGB {
+		bpp, format = 3, gl.RGB
+	}
+	n := size.X * size.Y * bpp
+	if size.X <= 0 || size.Y <= 0 || len(t.Raw) < n {
+		panic("LoadTexture(): Texture's Raw data does not match it's Bounds!")
+	}
+
+	// Rows must be aligned to four bytes (OpenGL's default unpack alignment),
+	// and the size must be a power-of-two if NPOT textures are not supported.
+	isPOT := func(k int) bool { return k&(k-1) == 0 }
+	if (size.X*bpp)%4 == 0 && (npot || (isPOT(size.X) && isPOT(size.Y))) {
+		return t.Raw[:n], size, format
+	}
+
+	// Fallback to preparing an image of the pixels.
+	img := image.NewRGBA(image.Rectangle{Max: size})
+	if bpp == 4 {
+		img.Pix = t.Raw[:n]
+	} else {
+		for i, j := 0, 0; i < n; i, j = i+3, j+4 {
+			copy(img.Pix[j:j+3], t.Raw[i:i+3])
+			img.Pix[j+3] = 0xFF
+		}
+	}
+	src := prepareImage(npot, img)
+	return src.Pix, src.Bounds().Size(), gl.RGBA
+}
+
 // Download implements the gfx.Downloadable interface.
 func (r *device) Download(rect image.Rectangle, complete chan image.Image) {
 	r.hookedDownload(rect, complete, nil, nil)
@@ -160,7 +261,7 @@ func (r *device) Download(rect image.Rectangle, complete chan image.Image) {
 
 // Implements gfx.Downloadable interface.
 func (r *device) hookedDownload(rect image.Rectangle, complete chan image.Image, pre, post func()) {
-	r.renderExec <- func() bool {
+	r.exec(func() bool {
 		if pre != nil {
 			pre()
 		}
@@ -193,7 +294,70 @@ func (r *device) hookedDownload(rect image.Rectangle, complete chan image.Image,
 
 		complete <- img
 		return false
+	})
+}
+
+// depthImage converts the given w*h depth values, read via glReadPixels (i.e.
+// bottom row first), into a 16-bit gray image (top row first). Values outside
+// of the [0, 1] depth range are clamped.
+func depthImage(depth []float32, w, h int) *image.Gray16 {
+	img := image.NewGray16(image.Rect(0, 0, w, h))
+	for py := 0; py < h; py++ {
+		row := depth[(h-py-1)*w : (h-py)*w]
+		for px, d := range row {
+			switch {
+			case d < 0:
+				d = 0
+			case d > 1:
+				d = 1
+			}
+			img.SetGray16(px, py, color.Gray16{Y: uint16(d*0xFFFF + 0.5)})
+		}
 	}
+	return img
+}
+
+// hookedDownloadDepth is like hookedDownload, except it downloads the depth
+// buffer instead of the color buffer, as an *image.Gray16 image where white is
+// furthest away.
+func (r *device) hookedDownloadDepth(rect image.Rectangle, complete chan image.Image, pre, post func()) {
+	r.exec(func() bool {
+		if pre != nil {
+			pre()
+		}
+
+		// Intersect the rectangle with the renderer's bounds.
+		bounds := r.Bounds()
+		rect = bounds.Intersect(rect)
+
+		// Depth values are read as floats, which unlike 16-bit values are
+		// never affected by the (default, 4-byte) row pack alignment.
+		depth := make([]float32, rect.Dx()*rect.Dy())
+		x, y, w, h := glutil.ConvertRect(rect, bounds)
+		if len(depth) > 0 {
+			gl.ReadPixels(
+				int32(x), int32(y), int32(w), int32(h),
+				gl.DEPTH_COMPONENT,
+				gl.FLOAT,
+				unsafe.Pointer(&depth[0]),
+			)
+		}
+
+		if post != nil {
+			post()
+		}
+
+		// Flush OpenGL commands.
+		gl.Flush()
+
+		img := depthImage(depth, w, h)
+
+		// Yield for occlusion query results, if any are available.
+		r.queryYield()
+
+		complete <- img
+		return false
+	})
 }
 
 func (r *rsrcManager) freeTextures() {
@@ -244,6 +408,29 @@ func convertTexFormat(f gfx.TexFormat) int32 {
 	}
 }
 
+// convertSwizzle converts the swizzle component, where identity is the
+// OpenGL channel that SwizzleIdentity represents.
+func convertSwizzle(s gfx.SwizzleComponent, identity int32) int32 {
+	switch s {
+	case gfx.SwizzleIdentity:
+		return identity
+	case gfx.SwizzleRed:
+		return gl.RED
+	case gfx.SwizzleGreen:
+		return gl.GREEN
+	case gfx.SwizzleBlue:
+		return gl.BLUE
+	case gfx.SwizzleAlpha:
+		return gl.ALPHA
+	case gfx.SwizzleZero:
+		return gl.ZERO
+	case gfx.SwizzleOne:
+		return gl.ONE
+	default:
+		panic("unknown swizzle component")
+	}
+}
+
 func unconvertTexFormat(f int32) gfx.TexFormat {
 	switch f {
 	case gl.RGBA8:
@@ -275,7 +462,8 @@ func (r *device) LoadTexture(t *gfx.Texture, done chan *gfx.Texture) {
 	}
 	r.shared.RUnlock()
 
-	if !t.Loaded && t.Source == nil {
+	cubeMap := t.CubeMap()
+	if !t.Loaded && !t.HasData() {
 		panic("LoadTexture(): Texture has a nil source!")
 	}
 	if t.Loaded {
@@ -286,31 +474,28 @@ func (r *device) LoadTexture(t *gfx.Texture, done chan *gfx.Texture) {
 		}
 		return
 	}
+	if cubeMap {
+		r.loadCubeMap(t, done)
+		return
+	}
 
-	// Prepare the image for uploading.
-	src := prepareImage(r.devInfo.NPOT, t.Source)
+	// Prepare the pixels for uploading.
+	pix, size, format := texturePixels(r.devInfo.NPOT, t)
 
-	r.renderExec <- func() bool {
+	r.exec(func() bool {
 		// Determine appropriate internal image format.
-		targetFormat := convertTexFormat(t.Format)
-		internalFormat := int32(gl.RGBA)
-		for _, format := range r.compressedTextureFormats {
-			if format == targetFormat {
-				internalFormat = format
-				break
-			}
-		}
+		internalFormat := r.internalTexFormat(t.Format)
 
 		// Initialize native texture.
-		bounds := src.Bounds()
 		native := newNativeTexture(
 			r,
+			gl.TEXTURE_2D,
 			internalFormat,
-			bounds.Dx(),
-			bounds.Dy(),
+			size.X,
+			size.Y,
 		)
 
-		if t.MinFilter.Mipmapped() {
+		if t.Mipmapped() {
 			gl.TexParameteri(gl.TEXTURE_2D, gl.GENERATE_MIPMAP, int32(gl.TRUE))
 		}
 
@@ -319,13 +504,14 @@ func (r *device) LoadTexture(t *gfx.Texture, done chan *gfx.Texture) {
 			gl.TEXTURE_2D,
 			0,
 			internalFormat,
-			int32(bounds.Dx()),
-			int32(bounds.Dy()),
+			int32(size.X),
+			int32(size.Y),
 			0,
-			gl.RGBA,
+			format,
 			gl.UNSIGNED_BYTE,
-			unsafe.Pointer(&src.Pix[0]),
+			unsafe.Pointer(&pix[0]),
 		)
+		native.account(1, t.Mipmapped())
 
 		// Unbind texture to avoid carrying OpenGL state.
 		gl.BindTexture(gl.TEXTURE_2D, 0)
@@ -347,5 +533,97 @@ func (r *device) LoadTexture(t *gfx.Texture, done chan *gfx.Texture) {
 		default:
 		}
 		return false // no frame rendered.
+	})
+}
+
+// internalTexFormat returns the internal OpenGL format to store a texture of
+// the given format with, which is the format itself if the device supports it
+// as a compressed texture format, or gl.RGBA otherwise.
+func (r *device) internalTexFormat(f gfx.TexFormat) int32 {
+	targetFormat := convertTexFormat(f)
+	for _, format := range r.compressedTextureFormats {
+		if format == targetFormat {
+			return format
+		}
+	}
+	return gl.RGBA
+}
+
+// loadCubeMap loads the six cube map faces of the given texture, it is called
+// by LoadTexture.
+func (r *device) loadCubeMap(t *gfx.Texture, done chan *gfx.Texture) {
+	// Prepare the images for uploading.
+	var faces [6]*image.RGBA
+	for i, face := range t.CubeFaces {
+		faces[i] = prepareImage(r.devInfo.NPOT, face)
+		if t.PremultipliedAlpha && straightAlpha(face) {
+			faces[i] = &image.RGBA{
+				Pix:    premultiply(faces[i].Pix),
+				Stride: faces[i].Stride,
+				Rect:   faces[i].Rect,
+			}
+		}
 	}
+	size := faces[0].Bounds().Size()
+	for _, face := range faces {
+		if s := face.Bounds().Size(); s.X != s.Y || s != size {
+			panic("LoadTexture(): Cube map faces must be square and of equal size!")
+		}
+	}
+
+	r.exec(func() bool {
+		// Determine appropriate internal image format.
+		internalFormat := r.internalTexFormat(t.Format)
+
+		// Initialize native texture.
+		native := newNativeTexture(
+			r,
+			gl.TEXTURE_CUBE_MAP,
+			internalFormat,
+			size.X,
+			size.Y,
+		)
+
+		if t.Mipmapped() {
+			gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.GENERATE_MIPMAP, int32(gl.TRUE))
+		}
+
+		// Upload each face, gfx.CubeFace is in the same order as the OpenGL
+		// cube map face targets.
+		for i, face := range faces {
+			gl.TexImage2D(
+				gl.TEXTURE_CUBE_MAP_POSITIVE_X+uint32(i),
+				0,
+				internalFormat,
+				int32(size.X),
+				int32(size.Y),
+				0,
+				gl.RGBA,
+				gl.UNSIGNED_BYTE,
+				unsafe.Pointer(&face.Pix[0]),
+			)
+		}
+		native.account(len(faces), t.Mipmapped())
+
+		// Unbind texture to avoid carrying OpenGL state.
+		gl.BindTexture(gl.TEXTURE_CUBE_MAP, 0)
+
+		// Mark the texture as loaded.
+		t.Loaded = true
+		t.NativeTexture = native
+		t.ClearData()
+
+		// Attach a finalizer to the texture that will later free it.
+		runtime.SetFinalizer(native, finalizeTexture)
+
+		// Finish not Flush, see http://higherorderfun.com/blog/2011/05/26/multi-thread-opengl-texture-loading/
+		gl.Finish()
+
+		// Signal completion and return.
+		select {
+		case done <- t:
+		default:
+		}
+		return false // no frame rendered.
+	})
 }
@@ -0,0 +1,115 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"image"
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/internal/gl/2.0/gl"
+)
+
+func TestTexturePixelsRaw(t *testing.T) {
+	tex := gfx.NewTexture()
+	tex.Bounds = image.Rect(0, 0, 4, 2)
+	tex.Raw = make([]byte, 4*2*4)
+
+	// Aligned, power-of-two RGBA pixels are uploaded without copying.
+	pix, size, format := texturePixels(false, tex)
+	if &pix[0] != &tex.Raw[0] || size != image.Pt(4, 2) || format != gl.RGBA {
+		t.Fatal("expected the Raw RGBA data to be used directly")
+	}
+
+	// As are aligned RGB pixels.
+	tex.RawFormat = gfx.RGB
+	tex.Raw = make([]byte, 4*2*3)
+	pix, _, format = texturePixels(true, tex)
+	if &pix[0] != &tex.Raw[0] || format != gl.RGB {
+		t.Fatal("expected the Raw RGB data to be used directly")
+	}
+
+	// Unaligned RGB rows are expanded to RGBA.
+	tex.Bounds = image.Rect(0, 0, 3, 1)
+	tex.Raw = []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	pix, size, format = texturePixels(true, tex)
+	want := []byte{1, 2, 3, 255, 4, 5, 6, 255, 7, 8, 9, 255}
+	if format != gl.RGBA || size != image.Pt(3, 1) || string(pix) != string(want) {
+		t.Fatal("got", pix, size, "want", want)
+	}
+
+	// Without NPOT support they are also resized.
+	_, size, _ = texturePixels(false, tex)
+	if size != image.Pt(4, 1) {
+		t.Fatal("got size", size, "want (4,1)")
+	}
+
+	// Premultiplying must not modify the Raw data.
+	tex.Bounds = image.Rect(0, 0, 1, 1)
+	tex.RawFormat = gfx.RGBA
+	tex.Raw = []byte{255, 128, 0, 128}
+	tex.PremultipliedAlpha = true
+	pix, _, _ = texturePixels(true, tex)
+	want = []byte{128, 64, 0, 128}
+	if string(pix) != string(want) || tex.Raw[0] != 255 {
+		t.Fatal("got", pix, "want", want)
+	}
+
+	// NRGBA sources are already premultiplied by prepareImage, they must not
+	// be premultiplied a second time.
+	nrgba := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	copy(nrgba.Pix, []byte{255, 128, 0, 128})
+	tex.Source = nrgba
+	pix, _, _ = texturePixels(true, tex)
+	if string(pix) != string(want) {
+		t.Fatal("got", pix, "want", want)
+	}
+
+	// Whereas RGBA sources are used as-is, and must be premultiplied.
+	rgba := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	copy(rgba.Pix, []byte{255, 128, 0, 128})
+	tex.Source = rgba
+	pix, _, _ = texturePixels(true, tex)
+	if string(pix) != string(want) || rgba.Pix[0] != 255 {
+		t.Fatal("got", pix, "want", want)
+	}
+	tex.Source = nil
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for Raw data smaller than the Bounds")
+		}
+	}()
+	tex.Bounds = image.Rect(0, 0, 8, 8)
+	texturePixels(true, tex)
+}
+
+func TestDepthImage(t *testing.T) {
+	// A 3x2 depth buffer as read by glReadPixels, bottom row first.
+	depth := []float32{
+		0, 0.5, 1, // Bottom row.
+		-1, 0.25, 2, // Top row, with out of range values.
+	}
+	img := depthImage(depth, 3, 2)
+	if img.Bounds() != image.Rect(0, 0, 3, 2) {
+		t.Fatal("got bounds", img.Bounds())
+	}
+	want := [2][3]uint16{
+		{0, 0x4000, 0xFFFF}, // Top row, clamped.
+		{0, 0x8000, 0xFFFF},
+	}
+	for y, row := range want {
+		for x, v := range row {
+			if got := img.Gray16At(x, y).Y; got != v {
+				t.Errorf("pixel (%d, %d) = %#x, want %#x", x, y, got, v)
+			}
+		}
+	}
+
+	// Empty rectangles give empty images.
+	if img := depthImage(nil, 0, 0); !img.Bounds().Empty() {
+		t.Fatal("got bounds", img.Bounds(), "want empty")
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"sync/atomic"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/internal/gl/2.0/gl"
+)
+
+// See: https://www.opengl.org/registry/specs/NVX/gpu_memory_info.txt
+const (
+	glGPU_MEMORY_INFO_TOTAL_AVAILABLE_MEMORY_NVX   = 0x9048
+	glGPU_MEMORY_INFO_CURRENT_AVAILABLE_VIDMEM_NVX = 0x9049
+)
+
+// See: https://www.opengl.org/registry/specs/ATI/meminfo.txt
+const glTEXTURE_FREE_MEMORY_ATI = 0x87FC
+
+// memoryUsage tracks the estimated number of bytes allocated by resources. It
+// is embedded in the rsrcManager.
+type memoryUsage struct {
+	textures, meshes, renderbuffers atomic.Int64
+}
+
+// formatSize returns the estimated size in bytes of a width x height image
+// stored in the given internal OpenGL format.
+func formatSize(internalFormat int32, width, height int) int64 {
+	n := int64(width) * int64(height)
+	switch internalFormat {
+	case gl.RGB8:
+		return n * 3
+	case gl.DEPTH_COMPONENT16:
+		return n * 2
+	case glCOMPRESSED_RGB_S3TC_DXT1_EXT, glCOMPRESSED_RGBA_S3TC_DXT1_EXT:
+		return n / 2
+	case glCOMPRESSED_RGBA_S3TC_DXT3_EXT, glCOMPRESSED_RGBA_S3TC_DXT5_EXT:
+		return n
+	default:
+		// RGBA8, 24/32-bit depth, and combined depth/stencil formats are all
+		// four bytes per pixel (24-bit depth is padded).
+		return n * 4
+	}
+}
+
+// account records the memory used by the native texture, whose image data has
+// just been uploaded. It may be called only once per native texture.
+func (n *nativeTexture) account(faces int, mipmapped bool) {
+	size := formatSize(n.internalFormat, n.width, n.height) * int64(faces)
+	if mipmapped {
+		// A full mipmap chain adds one third of the base level's size.
+		size += size / 3
+	}
+	n.size = size
+	n.r.rsrcManager.mem.textures.Add(size)
+}
+
+// MemoryUsage implements the Device interface.
+func (r *device) MemoryUsage() gfx.MemoryStats {
+	mem := &r.rsrcManager.mem
+	s := gfx.MemoryStats{
+		Textures:      mem.textures.Load(),
+		Meshes:        mem.meshes.Load(),
+		Renderbuffers: mem.renderbuffers.Load(),
+		VRAMTotal:     -1,
+		VRAMAvailable: -1,
+	}
+	s.Total = s.Textures + s.Meshes + s.Renderbuffers
+
+	if !r.glNvxGpuMemoryInfo && !r.glAtiMeminfo {
+		return s
+	}
+
+	// Query the video memory information, which must be done in the presence
+	// of the OpenGL context.
+	result := make(chan [2]int64, 1)
+	ok := r.exec(func() bool {
+		// Both extensions report sizes in kilobytes.
+		vram := [2]int64{-1, -1}
+		if r.glNvxGpuMemoryInfo {
+			var total, avail int32
+			gl.GetIntegerv(glGPU_MEMORY_INFO_TOTAL_AVAILABLE_MEMORY_NVX, &total)
+			gl.GetIntegerv(glGPU_MEMORY_INFO_CURRENT_AVAILABLE_VIDMEM_NVX, &avail)
+			vram = [2]int64{int64(total) * 1024, int64(avail) * 1024}
+		} else {
+			// The ATI extension only reports the free memory, the first value
+			// being the total free memory in the texture pool.
+			var free [4]int32
+			gl.GetIntegerv(glTEXTURE_FREE_MEMORY_ATI, &free[0])
+			vram[1] = int64(free[0]) * 1024
+		}
+		result <- vram
+		return false
+	})
+	if !ok {
+		return s
+	}
+	select {
+	case vram := <-result:
+		s.VRAMTotal, s.VRAMAvailable = vram[0], vram[1]
+	case <-r.destroyed:
+	}
+	return s
+}
@@ -0,0 +1,67 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/gfx/internal/gl/2.0/gl"
+)
+
+func TestTextureMemoryAccounting(t *testing.T) {
+	r := &device{rsrcManager: &rsrcManager{}}
+	tests := []struct {
+		format    int32
+		faces     int
+		mipmapped bool
+		want      int64
+	}{
+		{gl.RGBA8, 1, false, 256 * 256 * 4},
+		{gl.RGB8, 1, false, 256 * 256 * 3},
+		{gl.RGBA8, 6, false, 6 * 256 * 256 * 4},
+		{gl.RGBA8, 1, true, 256 * 256 * 4 * 4 / 3},
+		{glCOMPRESSED_RGB_S3TC_DXT1_EXT, 1, false, 256 * 256 / 2},
+		{glCOMPRESSED_RGBA_S3TC_DXT5_EXT, 1, false, 256 * 256},
+	}
+	for _, tst := range tests {
+		n := &nativeTexture{r: r, internalFormat: tst.format, width: 256, height: 256}
+		n.account(tst.faces, tst.mipmapped)
+		if got := r.rsrcManager.mem.textures.Load(); got != tst.want {
+			t.Errorf("format %#x: got %d bytes, want %d", tst.format, got, tst.want)
+		}
+
+		// Freeing the texture (even twice) must account for it's memory once.
+		finalizeTexture(n)
+		finalizeTexture(n)
+		if got := r.rsrcManager.mem.textures.Load(); got != 0 {
+			t.Errorf("format %#x: got %d bytes after free, want 0", tst.format, got)
+		}
+	}
+}
+
+func TestVBOStoreInPlace(t *testing.T) {
+	tests := []struct {
+		store vboStore
+		usage int32
+		size  int
+		want  bool
+	}{
+		{vboStore{}, gl.DYNAMIC_DRAW, 64, false},
+		{vboStore{64, gl.DYNAMIC_DRAW}, gl.DYNAMIC_DRAW, 64, true},
+		{vboStore{64, gl.STREAM_DRAW}, gl.STREAM_DRAW, 64, true},
+		{vboStore{64, gl.DYNAMIC_DRAW}, gl.DYNAMIC_DRAW, 32, false},
+		{vboStore{64, gl.STATIC_DRAW}, gl.STATIC_DRAW, 64, false},
+
+		// A changed usage hint reallocates the data store, keeping it's
+		// accounted size up to date.
+		{vboStore{64, gl.STATIC_DRAW}, gl.DYNAMIC_DRAW, 64, false},
+		{vboStore{64, gl.DYNAMIC_DRAW}, gl.STREAM_DRAW, 64, false},
+	}
+	for _, tst := range tests {
+		if got := tst.store.inPlace(tst.usage, tst.size); got != tst.want {
+			t.Errorf("%+v.inPlace(%#x, %d) = %v, want %v", tst.store, tst.usage, tst.size, got, tst.want)
+		}
+	}
+}
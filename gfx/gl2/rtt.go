@@ -13,6 +13,7 @@ import (
 	"github.com/qmcloud/engine/gfx"
 	"github.com/qmcloud/engine/gfx/internal/gl/2.0/gl"
 	"github.com/qmcloud/engine/gfx/internal/glc"
+	"github.com/qmcloud/engine/gfx/internal/glutil"
 	"github.com/qmcloud/engine/gfx/internal/tag"
 	"github.com/qmcloud/engine/gfx/internal/util"
 )
@@ -66,12 +67,22 @@ type rttCanvas struct {
 	// Frame buffer ID.
 	fbo uint32
 
+	// If multisampling is used along with textures, rendering occurs into the
+	// multisampled render buffers of fbo and the results are then resolved
+	// into the textures attached to resolveFBO. resolveMask is the mask of
+	// buffers to resolve.
+	resolveFBO  uint32
+	resolveMask uint32
+
 	// Render buffer ID's (rbColor is only a valid render buffer if e.g. the
 	// cfg.Color field is nil).
 	//
 	// rbDepthAndStencil is only set if cfg.DepthFormat.IsCombined()
 	rbColor, rbDepth, rbStencil, rbDepthAndStencil uint32
 
+	// The estimated size in bytes of all of the render buffers.
+	rbSize int64
+
 	// Decremented until zero, then all textures are free'd and all of the
 	// canvas methods are no-op.
 	textureCount struct {
@@ -99,12 +110,17 @@ func (r *rttCanvas) freeTexture(n *nativeTexture) {
 			finalizeTexture(r.cfg.Stencil.NativeTexture.(*nativeTexture))
 		}
 
-		// Add the FBO to the free list.
-		if r.fbo != 0 {
+		// Add the FBOs to the free list.
+		freeFBO := func(id uint32) {
+			if id == 0 {
+				return
+			}
 			r.r.rsrcManager.Lock()
-			r.r.rsrcManager.fbos = append(r.r.rsrcManager.fbos, r.fbo)
+			r.r.rsrcManager.fbos = append(r.r.rsrcManager.fbos, id)
 			r.r.rsrcManager.Unlock()
 		}
+		freeFBO(r.fbo)
+		freeFBO(r.resolveFBO)
 
 		// Add the render buffers to the free list.
 		freeRb := func(id uint32) {
@@ -119,6 +135,8 @@ func (r *rttCanvas) freeTexture(n *nativeTexture) {
 		freeRb(r.rbDepth)
 		freeRb(r.rbStencil)
 		freeRb(r.rbDepthAndStencil)
+		r.r.rsrcManager.mem.renderbuffers.Add(-r.rbSize)
+		r.rbSize = 0
 	}
 	r.textureCount.Unlock()
 }
@@ -147,36 +165,60 @@ func (r *rttCanvas) Clear(rect image.Rectangle, bg gfx.Color) {
 	if r.noop() {
 		return
 	}
+	r.r.autoClear(r.BaseCanvas, r.rttBegin, r.rttEnd)
 	r.r.hookedClear(rect, bg, r.rttBegin, r.rttEnd)
 }
 
 // Implements gfx.Canvas interface.
 func (r *rttCanvas) ClearDepth(rect image.Rectangle, depth float64) {
+	r.r.autoClear(r.BaseCanvas, r.rttBegin, r.rttEnd)
 	r.r.hookedClearDepth(rect, depth, r.rttBegin, r.rttEnd)
 }
 
 // Implements gfx.Canvas interface.
 func (r *rttCanvas) ClearStencil(rect image.Rectangle, stencil int) {
+	r.r.autoClear(r.BaseCanvas, r.rttBegin, r.rttEnd)
 	r.r.hookedClearStencil(rect, stencil, r.rttBegin, r.rttEnd)
 }
 
+// Implements gfx.Canvas interface.
+func (r *rttCanvas) ClearRects(rects []gfx.ClearRect) {
+	r.r.autoClear(r.BaseCanvas, r.rttBegin, r.rttEnd)
+	r.r.hookedClearRects(rects, r.rttBegin, r.rttEnd)
+}
+
 // Implements gfx.Canvas interface.
 func (r *rttCanvas) Draw(rect image.Rectangle, o *gfx.Object, c gfx.Camera) {
+	r.r.autoClear(r.BaseCanvas, r.rttBegin, r.rttEnd)
 	r.r.hookedDraw(rect, o, c, r.rttBegin, r.rttEnd)
 }
 
+// Implements gfx.Canvas interface.
+func (r *rttCanvas) Blit(dst gfx.Canvas, src, dstRect image.Rectangle, filter gfx.TexFilter) error {
+	return r.r.hookedBlit(r, dst, src, dstRect, filter)
+}
+
 // Implements gfx.Canvas interface.
 func (r *rttCanvas) QueryWait() {
 	r.r.hookedQueryWait(r.rttBegin, r.rttEnd)
 }
 
+// Implements gfx.Canvas interface.
+func (r *rttCanvas) QueryPoll() (pending int) {
+	return r.r.hookedQueryPoll(r.rttBegin, r.rttEnd)
+}
+
 // Implements gfx.Canvas interface.
 func (r *rttCanvas) Render() {
 	r.r.hookedRender(nil, func() {
-		// Generate mipmaps for any texture with a mipmapped format. This must
-		// be done here because the texture has just been rendered to.
+		// Resolve multisampled buffers into the textures, such that they can
+		// be sampled from.
+		r.resolve()
+
+		// Generate mipmaps for any texture that wants them. This must be done
+		// here because the texture has just been rendered to.
 		do := func(t *gfx.Texture) {
-			if t == nil || !t.MinFilter.Mipmapped() {
+			if t == nil || !t.Mipmapped() {
 				return
 			}
 			n := t.NativeTexture.(*nativeTexture)
@@ -188,11 +230,83 @@ func (r *rttCanvas) Render() {
 		do(r.cfg.Stencil)
 		gl.BindTexture(gl.TEXTURE_2D, 0)
 	})
+	r.EndFrame()
 }
 
 // Implements gfx.Downloadable interface.
+//
+// Canvases without a color buffer but with a depth buffer download the depth
+// buffer instead, as an *image.Gray16 image.
 func (r *rttCanvas) Download(rect image.Rectangle, complete chan image.Image) {
-	r.r.hookedDownload(rect, complete, r.rttBegin, r.rttEnd)
+	download := r.r.hookedDownload
+	if r.cfg.ColorFormat == gfx.ZeroTexFormat && r.cfg.DepthFormat != gfx.ZeroDSFormat {
+		download = r.r.hookedDownloadDepth
+		if r.resolveFBO != 0 && r.resolveMask&gl.DEPTH_BUFFER_BIT == 0 {
+			// The depth buffer is multisampled but isn't resolved into a
+			// texture, so it cannot be read from.
+			r.r.warner.Warnf("Download(): multisampled depth buffer without a Depth texture; returning nil\n")
+			complete <- nil
+			return
+		}
+	}
+	if r.resolveFBO != 0 {
+		// Pixels cannot be read from multisampled buffers directly, so
+		// resolve them first and read from the textures instead.
+		download(rect, complete, r.rttBeginResolved, r.rttEnd)
+		return
+	}
+	download(rect, complete, r.rttBegin, r.rttEnd)
+}
+
+// resolve resolves the multisampled render buffers into the textures, if the
+// canvas is multisampled. It may only be called inside renderExec.
+func (r *rttCanvas) resolve() {
+	if r.resolveFBO == 0 {
+		return
+	}
+	r.r.graphicsState.Begin(r.r)
+
+	// The color write mask and scissor test both effect blitting.
+	r.r.graphicsState.ColorWrite(true, true, true, true)
+	bounds := r.Bounds()
+	r.r.graphicsState.Scissor(bounds, bounds)
+
+	x, y, w, h := glutil.ConvertRect(bounds, bounds)
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, r.fbo)
+	gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, r.resolveFBO)
+	gl.BlitFramebuffer(
+		int32(x), int32(y), int32(x+w), int32(y+h),
+		int32(x), int32(y), int32(x+w), int32(y+h),
+		r.resolveMask, gl.NEAREST,
+	)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+}
+
+// rttResolveMask returns the mask of the buffers that are resolved into the
+// textures of a render-to-texture canvas with the given configuration, or zero
+// if the canvas is not multisampled. Only color and non-combined depth
+// textures are rendered into, and thus resolved.
+func rttResolveMask(cfg gfx.RTTConfig) uint32 {
+	if cfg.Samples <= 0 {
+		return 0
+	}
+	var mask uint32
+	if cfg.Color != nil && cfg.ColorFormat != gfx.ZeroTexFormat {
+		mask |= gl.COLOR_BUFFER_BIT
+	}
+	dsCombined := cfg.DepthFormat == cfg.StencilFormat && cfg.DepthFormat.IsCombined()
+	if !dsCombined && cfg.Depth != nil && cfg.DepthFormat != gfx.ZeroDSFormat {
+		mask |= gl.DEPTH_BUFFER_BIT
+	}
+	return mask
+}
+
+func (r *rttCanvas) rttBeginResolved() {
+	r.resolve()
+	r.r.rttCanvas = r
+
+	// Bind the resolved framebuffer object.
+	gl.BindFramebuffer(gl.FRAMEBUFFER, r.resolveFBO)
 }
 
 func (r *rttCanvas) rttBegin() {
@@ -239,7 +353,8 @@ func (r *device) RenderToTexture(cfg gfx.RTTConfig) gfx.Canvas {
 	cr, cg, cb, ca := cfg.ColorFormat.Bits()
 	canvas := &rttCanvas{
 		BaseCanvas: &util.BaseCanvas{
-			VMSAA: true,
+			VMSAA:       true,
+			VClearDepth: 1.0,
 			VPrecision: gfx.Precision{
 				RedBits: cr, GreenBits: cg, BlueBits: cb, AlphaBits: ca,
 				DepthBits:   cfg.DepthFormat.DepthBits(),
@@ -254,8 +369,9 @@ func (r *device) RenderToTexture(cfg gfx.RTTConfig) gfx.Canvas {
 	var (
 		nTexColor, nTexDepth, nTexStencil *nativeTexture
 		fbError                           error
+		created                           = make(chan struct{})
 	)
-	r.renderExec <- func() bool {
+	create := func() bool {
 		width := int32(cfg.Bounds.Dx())
 		height := int32(cfg.Bounds.Dy())
 
@@ -266,62 +382,106 @@ func (r *device) RenderToTexture(cfg gfx.RTTConfig) gfx.Canvas {
 		// Create an OpenGL render buffer for each nil cfg texture. This allows
 		// the driver a chance to optimize storage for e.g. a depth buffer when
 		// you don't intend to use it as a texture.
+		//
+		// Textures cannot be multisampled, so when multisampling we render
+		// into render buffers only and attach the textures to a second FBO
+		// which the render buffers are resolved into.
 		samples := int32(cfg.Samples)
-		if cfg.Color == nil && cfg.ColorFormat != gfx.ZeroTexFormat {
+		msaa := samples > 0 && (cfg.Color != nil || cfg.Depth != nil)
+		colorTex, depthTex, stencilTex := cfg.Color, cfg.Depth, cfg.Stencil
+		if msaa {
+			colorTex, depthTex, stencilTex = nil, nil, nil
+		}
+
+		// rbSize returns the estimated size of a render buffer of the given
+		// format, for memory accounting.
+		rbSize := func(format int32) int64 {
+			size := formatSize(format, int(width), int(height))
+			if samples > 1 {
+				size *= int64(samples)
+			}
+			return size
+		}
+		if colorTex == nil && cfg.ColorFormat != gfx.ZeroTexFormat {
 			// We do not want a color texture, but we do want a color buffer.
 			gl.GenRenderbuffers(1, &canvas.rbColor)
 			gl.BindRenderbuffer(gl.RENDERBUFFER, canvas.rbColor)
 			gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, samples, uint32(colorFormat), width, height)
+			canvas.rbSize += rbSize(colorFormat)
 			gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.RENDERBUFFER, canvas.rbColor)
 		}
 		dsCombined := cfg.DepthFormat == cfg.StencilFormat && cfg.DepthFormat.IsCombined()
-		if cfg.Depth == nil && cfg.Stencil == nil && dsCombined {
+		if depthTex == nil && stencilTex == nil && dsCombined {
 			// We do not want a depth or stencil texture, but we do want a
 			// combined depth/stencil buffer.
 			gl.GenRenderbuffers(1, &canvas.rbDepthAndStencil)
 			gl.BindRenderbuffer(gl.RENDERBUFFER, canvas.rbDepthAndStencil)
 			gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, samples, uint32(depthFormat), width, height)
+			canvas.rbSize += rbSize(depthFormat)
 			gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_ATTACHMENT, gl.RENDERBUFFER, canvas.rbDepthAndStencil)
 			gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.STENCIL_ATTACHMENT, gl.RENDERBUFFER, canvas.rbDepthAndStencil)
 		} else {
-			if cfg.Depth == nil && cfg.DepthFormat != gfx.ZeroDSFormat {
+			if depthTex == nil && cfg.DepthFormat != gfx.ZeroDSFormat {
 				// We do not want a depth texture, but we do want a depth buffer.
 				gl.GenRenderbuffers(1, &canvas.rbDepth)
 				gl.BindRenderbuffer(gl.RENDERBUFFER, canvas.rbDepth)
 				gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, samples, uint32(depthFormat), width, height)
+				canvas.rbSize += rbSize(depthFormat)
 				gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_ATTACHMENT, gl.RENDERBUFFER, canvas.rbDepth)
 			}
-			if cfg.Stencil == nil && cfg.StencilFormat != gfx.ZeroDSFormat {
+			if stencilTex == nil && cfg.StencilFormat != gfx.ZeroDSFormat {
 				// We do not want a stencil texture, but we do want a stencil buffer.
 				gl.GenRenderbuffers(1, &canvas.rbStencil)
 				gl.BindRenderbuffer(gl.RENDERBUFFER, canvas.rbStencil)
 				gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, samples, uint32(stencilFormat), width, height)
+				canvas.rbSize += rbSize(stencilFormat)
 				gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.STENCIL_ATTACHMENT, gl.RENDERBUFFER, canvas.rbStencil)
 			}
 		}
 
+		r.rsrcManager.mem.renderbuffers.Add(canvas.rbSize)
+
+		if msaa {
+			// Check the multisampled FBO for errors, then create the FBO
+			// that the textures are attached to.
+			status := int(gl.CheckFramebufferStatus(gl.FRAMEBUFFER))
+			fbError = r.common.FramebufferStatus(status)
+
+			gl.GenFramebuffers(1, &canvas.resolveFBO)
+			gl.BindFramebuffer(gl.FRAMEBUFFER, canvas.resolveFBO)
+			canvas.resolveMask = rttResolveMask(cfg)
+		}
+
 		// Create an OpenGL texture for every non-nil cfg texture.
 		if cfg.Color != nil && cfg.ColorFormat != gfx.ZeroTexFormat {
 			// We want a color texture, not a color buffer.
-			nTexColor = newNativeTexture(r, colorFormat, int(width), int(height))
+			nTexColor = newNativeTexture(r, gl.TEXTURE_2D, colorFormat, int(width), int(height))
 			gl.TexImage2D(gl.TEXTURE_2D, 0, colorFormat, width, height, 0, gl.BGRA, gl.UNSIGNED_BYTE, nil)
-			gl.GenerateMipmap(gl.TEXTURE_2D)
+			if cfg.Color.Mipmapped() {
+				gl.GenerateMipmap(gl.TEXTURE_2D)
+			}
+			nTexColor.account(1, cfg.Color.Mipmapped())
 			gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, nTexColor.id, 0)
 		}
 		// Only non-combined depth/stencil formats can render into a texture.
 		if !dsCombined {
 			if cfg.Depth != nil && cfg.DepthFormat != gfx.ZeroDSFormat {
 				// We want a depth texture, not a depth buffer.
-				nTexDepth = newNativeTexture(r, depthFormat, int(width), int(height))
+				nTexDepth = newNativeTexture(r, gl.TEXTURE_2D, depthFormat, int(width), int(height))
 				gl.TexImage2D(gl.TEXTURE_2D, 0, depthFormat, width, height, 0, gl.DEPTH_COMPONENT, gl.UNSIGNED_BYTE, nil)
-				gl.GenerateMipmap(gl.TEXTURE_2D)
+				if cfg.Depth.Mipmapped() {
+					gl.GenerateMipmap(gl.TEXTURE_2D)
+				}
+				nTexDepth.account(1, cfg.Depth.Mipmapped())
 				gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.DEPTH_ATTACHMENT, gl.TEXTURE_2D, nTexDepth.id, 0)
 			}
 		}
 
 		// Check for errors.
-		status := int(gl.CheckFramebufferStatus(gl.FRAMEBUFFER))
-		fbError = r.common.FramebufferStatus(status)
+		if fbError == nil {
+			status := int(gl.CheckFramebufferStatus(gl.FRAMEBUFFER))
+			fbError = r.common.FramebufferStatus(status)
+		}
 
 		// Unbind textures, render buffers, and the FBO.
 		gl.BindTexture(gl.TEXTURE_2D, 0)
@@ -329,10 +489,22 @@ func (r *device) RenderToTexture(cfg gfx.RTTConfig) gfx.Canvas {
 		gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
 
 		// Signal render completion.
-		r.renderComplete <- struct{}{}
+		close(created)
 		return false // No frame was rendered.
 	}
-	<-r.renderComplete
+
+	// Stop waiting if the device is destroyed, as the execution channel may
+	// no longer be executed.
+	select {
+	case r.renderExec <- create:
+	case <-r.destroyed:
+		return nil
+	}
+	select {
+	case <-created:
+	case <-r.destroyed:
+		return nil
+	}
 
 	if fbError != nil {
 		if fbError == glc.FramebufferUnsupported {
@@ -0,0 +1,54 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/internal/gl/2.0/gl"
+)
+
+func TestRTTResolveMask(t *testing.T) {
+	tex := gfx.NewTexture()
+	tests := []struct {
+		name string
+		cfg  gfx.RTTConfig
+		want uint32
+	}{
+		{
+			name: "not multisampled",
+			cfg:  gfx.RTTConfig{Color: tex, ColorFormat: gfx.RGBA},
+		},
+		{
+			name: "no textures",
+			cfg:  gfx.RTTConfig{ColorFormat: gfx.RGBA, DepthFormat: gfx.Depth24, Samples: 4},
+		},
+		{
+			name: "color",
+			cfg:  gfx.RTTConfig{Color: tex, ColorFormat: gfx.RGBA, Samples: 4},
+			want: gl.COLOR_BUFFER_BIT,
+		},
+		{
+			name: "color and depth",
+			cfg:  gfx.RTTConfig{Color: tex, ColorFormat: gfx.RGBA, Depth: tex, DepthFormat: gfx.Depth24, Samples: 4},
+			want: gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT,
+		},
+		{
+			name: "combined depth and stencil",
+			cfg: gfx.RTTConfig{
+				Color: tex, ColorFormat: gfx.RGBA,
+				Depth: tex, DepthFormat: gfx.Depth24AndStencil8, StencilFormat: gfx.Depth24AndStencil8,
+				Samples: 4,
+			},
+			want: gl.COLOR_BUFFER_BIT,
+		},
+	}
+	for _, tst := range tests {
+		if got := rttResolveMask(tst.cfg); got != tst.want {
+			t.Errorf("%s: got mask %#x, want %#x", tst.name, got, tst.want)
+		}
+	}
+}
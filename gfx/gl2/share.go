@@ -0,0 +1,39 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrShareDevice is returned by New when the device given to the Share option
+// was not created by this package.
+var ErrShareDevice = errors.New("gl2: Share device was not created by this package")
+
+// checkShare verifies that this device may share OpenGL objects with the
+// device given to the Share option, if any.
+//
+// OpenGL objects can only be shared between contexts of the same renderer, on
+// systems with multiple graphics cards (e.g. laptops with integrated and
+// discrete GPUs) the windowing library may create each context on a different
+// one. Without this check the failure would only show up later, when a mesh
+// or texture loaded by the shared device is missing from this one.
+//
+// It must be called after the device information has been queried.
+func (r *device) checkShare() error {
+	if r.shareErr != nil {
+		return r.shareErr
+	}
+	other := r.shared.device
+	if other == nil {
+		return nil
+	}
+	a, b := other.devInfo, r.devInfo
+	if a.Vendor != b.Vendor || a.Name != b.Name {
+		return fmt.Errorf("gl2: cannot share with a context of a different renderer (shared %q by %q, new %q by %q)", a.Name, a.Vendor, b.Name, b.Vendor)
+	}
+	return nil
+}
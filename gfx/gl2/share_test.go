@@ -0,0 +1,51 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+// otherDevice is a Device not created by this package.
+type otherDevice struct {
+	Device
+}
+
+func TestCheckShare(t *testing.T) {
+	intel := gfx.DeviceInfo{Name: "Intel HD Graphics", Vendor: "Intel"}
+	nvidia := gfx.DeviceInfo{Name: "GeForce GTX", Vendor: "NVIDIA Corporation"}
+	tests := []struct {
+		name   string
+		share  Device
+		a, b   gfx.DeviceInfo
+		err    bool
+		shared bool
+	}{
+		{name: "none", a: intel, b: intel},
+		{name: "same renderer", share: &device{}, a: intel, b: intel, shared: true},
+		{name: "different renderer", share: &device{}, a: intel, b: nvidia, err: true, shared: true},
+		{name: "foreign device", share: otherDevice{}, a: intel, b: intel, err: true},
+	}
+	for _, tst := range tests {
+		if d, ok := tst.share.(*device); ok {
+			d.devInfo = tst.a
+		}
+		r := &device{devInfo: tst.b}
+		Share(tst.share)(r)
+		if got := r.shared.device != nil; got != tst.shared {
+			t.Errorf("%s: shared = %v, want %v", tst.name, got, tst.shared)
+		}
+		if err := r.checkShare(); (err != nil) != tst.err {
+			t.Errorf("%s: checkShare() = %v, want error %v", tst.name, err, tst.err)
+		}
+	}
+	r := &device{}
+	Share(otherDevice{})(r)
+	if err := r.checkShare(); err != ErrShareDevice {
+		t.Errorf("checkShare() = %v, want %v", err, ErrShareDevice)
+	}
+}
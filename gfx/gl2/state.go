@@ -18,6 +18,16 @@ const noStateGuard = tag.Gsgdebug
 type graphicsState struct {
 	*glc.GraphicsState
 	lastProgramPointSizeExt bool
+
+	// The current front face winding order, and the one saved by Begin for
+	// restoration.
+	lastFrontFace, savedFrontFace gfx.FrontFace
+
+	// The current depth bias, and the one saved by Begin for restoration.
+	lastDepthBias, savedDepthBias gfx.DepthBias
+
+	// The current depth range, and the one saved by Begin for restoration.
+	lastDepthRange, savedDepthRange [2]float64
 }
 
 func (g *graphicsState) Begin(d *device) {
@@ -36,6 +46,9 @@ func (g *graphicsState) Begin(d *device) {
 	// Enable setting point size in shader programs.
 	g.programPointSizeExt(true)
 
+	// Use the device's depth range.
+	g.depthRange(d.depthRange)
+
 	// Enable multisampling, if available and wanted.
 	if d.glArbMultisample {
 		if d.BaseCanvas.MSAA() {
@@ -60,9 +73,32 @@ func (g *graphicsState) beginCustom() {
 	// depthClamp
 	gl.GetBooleanv(gl.DEPTH_CLAMP, &g.S.DepthClamp)
 
+	// frontFace
+	var frontFace int32
+	gl.GetIntegerv(gl.FRONT_FACE, &frontFace)
+	g.lastFrontFace = gfx.CounterClockwise
+	if frontFace == gl.CW {
+		g.lastFrontFace = gfx.Clockwise
+	}
+	g.savedFrontFace = g.lastFrontFace
+
+	// depthBias
+	var polygonOffset bool
+	gl.GetBooleanv(gl.POLYGON_OFFSET_FILL, &polygonOffset)
+	g.lastDepthBias = gfx.DepthBias{}
+	if polygonOffset {
+		gl.GetFloatv(gl.POLYGON_OFFSET_FACTOR, &g.lastDepthBias.Slope)
+		gl.GetFloatv(gl.POLYGON_OFFSET_UNITS, &g.lastDepthBias.Constant)
+	}
+	g.savedDepthBias = g.lastDepthBias
+
 	// programPointSizeExt
 	gl.GetBooleanv(gl.PROGRAM_POINT_SIZE_EXT, &g.lastProgramPointSizeExt)
 
+	// depthRange
+	gl.GetDoublev(gl.DEPTH_RANGE, &g.lastDepthRange[0])
+	g.savedDepthRange = g.lastDepthRange
+
 	// stencilMaskSeparate
 	g.getStencilMaskSeparate(&g.S.StencilFront, &g.S.StencilBack)
 
@@ -73,7 +109,10 @@ func (g *graphicsState) beginCustom() {
 func (g *graphicsState) restoreCustom() {
 	g.useProgram(g.S.ShaderProgram)
 	g.depthClamp(g.S.DepthClamp)
+	g.frontFace(g.savedFrontFace)
+	g.depthBias(g.savedDepthBias)
 	g.programPointSizeExt(g.lastProgramPointSizeExt)
+	g.depthRange(g.savedDepthRange)
 	g.stencilMaskSeparate(g.S.StencilFront.WriteMask, g.S.StencilBack.WriteMask)
 	g.stencilFuncSeparate(g.S.StencilFront, g.S.StencilBack)
 }
@@ -95,6 +134,29 @@ func (g *graphicsState) depthClamp(v bool) {
 	}
 }
 
+// Uncommon because glc doesn't expose glFrontFace.
+func (g *graphicsState) frontFace(f gfx.FrontFace) {
+	if noStateGuard || g.lastFrontFace != f {
+		g.lastFrontFace = f
+		if f == gfx.Clockwise {
+			gl.FrontFace(gl.CW)
+			return
+		}
+		gl.FrontFace(gl.CCW)
+	}
+}
+
+// Uncommon because glc doesn't expose glPolygonOffset.
+func (g *graphicsState) depthBias(b gfx.DepthBias) {
+	if noStateGuard || g.lastDepthBias != b {
+		g.lastDepthBias = b
+		g.C.Feature(gl.POLYGON_OFFSET_FILL, b.Enabled())
+		if b.Enabled() {
+			gl.PolygonOffset(b.Slope, b.Constant)
+		}
+	}
+}
+
 // Specific to OpenGL 2 (OpenGL ES 2 and WebGL 1.0 both have shader program
 // point size enabled by default).
 func (g *graphicsState) programPointSizeExt(v bool) {
@@ -104,6 +166,14 @@ func (g *graphicsState) programPointSizeExt(v bool) {
 	}
 }
 
+// Specific to OpenGL 2 (OpenGL ES 2 and WebGL 1.0 use glDepthRangef).
+func (g *graphicsState) depthRange(r [2]float64) {
+	if noStateGuard || g.lastDepthRange != r {
+		g.lastDepthRange = r
+		gl.DepthRange(r[0], r[1])
+	}
+}
+
 // Uncommon because WebGL doesn't support seperate stencil masks:
 //
 // https://www.khronos.org/registry/webgl/specs/latest/1.0/#6.10
@@ -0,0 +1,132 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/internal/gl/2.0/gl"
+)
+
+// streamable tells if the native texture can be updated in-place (via
+// glTexSubImage2D) with an image of the given size, rather than allocating a
+// new texture. Render-to-texture textures are never updated in-place.
+func (n *nativeTexture) streamable(width, height int) bool {
+	if n == nil || n.id == 0 || n.rttCanvas != nil {
+		return false
+	}
+	return n.target == gl.TEXTURE_2D && n.internalFormat == gl.RGBA && n.width == width && n.height == height
+}
+
+// streamTexture returns the native texture that a frame of the given size is
+// uploaded into, and whether or not it is a new texture (i.e. the frame must be
+// uploaded with glTexImage2D rather than glTexSubImage2D). If the existing
+// native texture (which may be nil) is not streamable, it is destroyed and a
+// new one is created using alloc.
+func streamTexture(native *nativeTexture, width, height int, alloc func() *nativeTexture) (*nativeTexture, bool) {
+	if native.streamable(width, height) {
+		return native, false
+	}
+
+	// Free the old texture (e.g. the image size has changed), the finalizer
+	// is removed so that it is not free'd twice.
+	if native != nil {
+		runtime.SetFinalizer(native, nil)
+		native.Destroy()
+	}
+	return alloc(), true
+}
+
+// UpdateStream implements the gfx.Device interface.
+func (r *device) UpdateStream(t *gfx.StreamTexture, done chan *gfx.StreamTexture) {
+	// If we are sharing assets with another renderer, allow it to update the
+	// texture instead.
+	r.shared.RLock()
+	if r.shared.device != nil {
+		r.shared.device.UpdateStream(t, done)
+		r.shared.RUnlock()
+		return
+	}
+	r.shared.RUnlock()
+
+	if t.Source == nil && t.Raw == nil {
+		panic("UpdateStream(): Texture has a nil source!")
+	}
+
+	// Prepare the pixels for uploading.
+	pix, size, format := texturePixels(r.devInfo.NPOT, t.Texture)
+
+	var native *nativeTexture
+	if t.Loaded && t.NativeTexture != nil {
+		native, _ = t.NativeTexture.(*nativeTexture)
+	}
+
+	r.exec(func() bool {
+		width, height := size.X, size.Y
+
+		// Stream textures are always stored uncompressed.
+		var isNew bool
+		native, isNew = streamTexture(native, width, height, func() *nativeTexture {
+			return newNativeTexture(r, gl.TEXTURE_2D, gl.RGBA, width, height)
+		})
+		if !isNew {
+			// Upload the image into the existing texture.
+			gl.BindTexture(gl.TEXTURE_2D, native.id)
+			gl.TexSubImage2D(
+				gl.TEXTURE_2D,
+				0,
+				0, 0,
+				int32(width),
+				int32(height),
+				format,
+				gl.UNSIGNED_BYTE,
+				unsafe.Pointer(&pix[0]),
+			)
+		} else {
+			// The new texture is bound by newNativeTexture.
+			if t.Mipmapped() {
+				gl.TexParameteri(gl.TEXTURE_2D, gl.GENERATE_MIPMAP, int32(gl.TRUE))
+			}
+
+			// Upload the image.
+			gl.TexImage2D(
+				gl.TEXTURE_2D,
+				0,
+				gl.RGBA,
+				int32(width),
+				int32(height),
+				0,
+				format,
+				gl.UNSIGNED_BYTE,
+				unsafe.Pointer(&pix[0]),
+			)
+			native.account(1, t.Mipmapped())
+
+			// Attach a finalizer to the texture that will later free it.
+			runtime.SetFinalizer(native, finalizeTexture)
+		}
+
+		// Unbind texture to avoid carrying OpenGL state.
+		gl.BindTexture(gl.TEXTURE_2D, 0)
+
+		// Mark the texture as loaded.
+		t.Loaded = true
+		t.NativeTexture = native
+		t.ClearData()
+
+		// The texture is only ever used by this context, so unlike
+		// LoadTexture there is no need to wait for the upload to finish.
+		gl.Flush()
+
+		// Signal completion and return.
+		select {
+		case done <- t:
+		default:
+		}
+		return false // no frame rendered.
+	})
+}
@@ -0,0 +1,96 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/internal/gl/2.0/gl"
+)
+
+func TestStreamable(t *testing.T) {
+	var (
+		stream    = gfx.NewStreamTexture(image.NewRGBA(image.Rect(0, 0, 64, 32)))
+		nextID    uint32
+		destroyed []uint32
+	)
+	alloc := func(width, height int) func() *nativeTexture {
+		return func() *nativeTexture {
+			nextID++
+			return &nativeTexture{
+				id:             nextID,
+				target:         gl.TEXTURE_2D,
+				internalFormat: gl.RGBA,
+				width:          width,
+				height:         height,
+				destroyHandler: func(n *nativeTexture) {
+					destroyed = append(destroyed, n.id)
+				},
+			}
+		}
+	}
+
+	// update performs the native texture bookkeeping of UpdateStream for the
+	// stream's current frame, returning whether a new texture was allocated.
+	update := func() bool {
+		_, size, _ := texturePixels(true, stream.Texture)
+		var native *nativeTexture
+		if stream.Loaded && stream.NativeTexture != nil {
+			native, _ = stream.NativeTexture.(*nativeTexture)
+		}
+		native, isNew := streamTexture(native, size.X, size.Y, alloc(size.X, size.Y))
+		stream.Loaded = true
+		stream.NativeTexture = native
+		return isNew
+	}
+
+	// The first frame allocates the texture.
+	var native *nativeTexture
+	if native.streamable(64, 32) {
+		t.Fatal("a nil native texture must not be streamable")
+	}
+	if !update() {
+		t.Fatal("the first frame must allocate a texture")
+	}
+	first := stream.NativeTexture.(*nativeTexture)
+
+	// Upload 100 more frames of the same size, the texture must be re-used.
+	for frame := 0; frame < 100; frame++ {
+		img := image.NewRGBA(image.Rect(0, 0, 64, 32))
+		img.Set(frame%64, 0, color.White)
+		stream.SetFrame(img)
+		if update() {
+			t.Fatal("frame", frame, "reallocated the texture")
+		}
+		if id, _ := stream.GLID(); id != first.id {
+			t.Fatal("frame", frame, "changed the texture ID from", first.id, "to", id)
+		}
+	}
+	if nextID != 1 || len(destroyed) != 0 {
+		t.Fatal("got", nextID, "allocations and", len(destroyed), "destroyed textures, want 1 and 0")
+	}
+
+	// A frame of a different size allocates a new texture, and destroys the
+	// old one.
+	stream.SetFrame(image.NewRGBA(image.Rect(0, 0, 32, 32)))
+	if !update() {
+		t.Fatal("frame of a different size must not re-use the texture")
+	}
+	if id, _ := stream.GLID(); id == first.id || len(destroyed) != 1 || destroyed[0] != first.id {
+		t.Fatal("got texture ID", id, "and destroyed", destroyed)
+	}
+
+	// Compressed and render-to-texture textures are never streamable.
+	compressed := *first
+	compressed.internalFormat = glCOMPRESSED_RGB_S3TC_DXT1_EXT
+	rtt := *first
+	rtt.rttCanvas = &rttCanvas{}
+	if compressed.streamable(64, 32) || rtt.streamable(64, 32) {
+		t.Fatal("compressed and render-to-texture textures must not be streamable")
+	}
+}
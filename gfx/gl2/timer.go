@@ -0,0 +1,92 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"time"
+
+	"github.com/qmcloud/engine/gfx/internal/gl/2.0/gl"
+)
+
+type pendingTimer struct {
+	// The ID of the pending timer query.
+	id uint32
+
+	// The channel to send the elapsed time over.
+	done chan time.Duration
+}
+
+// BeginGPUTimer implements the Device interface.
+func (r *device) BeginGPUTimer() {
+	if !r.glArbTimerQuery {
+		return
+	}
+	r.exec(func() bool {
+		if r.gpuTimer != 0 {
+			r.warner.Warnf("BeginGPUTimer called while a GPU timer is already active; ignoring.\n")
+			return false
+		}
+		gl.GenQueries(1, &r.gpuTimer)
+		gl.BeginQuery(gl.TIME_ELAPSED, r.gpuTimer)
+		return false
+	})
+}
+
+// EndGPUTimer implements the Device interface.
+func (r *device) EndGPUTimer(done chan time.Duration) {
+	if !r.glArbTimerQuery {
+		return
+	}
+	r.exec(func() bool {
+		if r.gpuTimer == 0 {
+			r.warner.Warnf("EndGPUTimer called without an active GPU timer; ignoring.\n")
+			return false
+		}
+		gl.EndQuery(gl.TIME_ELAPSED)
+
+		// Add the pending timer.
+		r.pending.Lock()
+		r.pending.timers = append(r.pending.timers, pendingTimer{r.gpuTimer, done})
+		r.pending.Unlock()
+		r.gpuTimer = 0
+		return false
+	})
+}
+
+// Tries to receive pending timer query results, returns immediately if none
+// are available yet. Returns the number of timers still pending.
+//
+// It may only be called inside renderExec.
+func (r *device) timerYield() int {
+	if !r.glArbTimerQuery {
+		return 0
+	}
+	r.pending.Lock()
+	var (
+		available int32
+		elapsed   uint64
+	)
+	remaining := r.pending.timers[:0]
+	for _, timer := range r.pending.timers {
+		gl.GetQueryObjectiv(timer.id, gl.QUERY_RESULT_AVAILABLE, &available)
+		if available != gl.TRUE {
+			remaining = append(remaining, timer)
+			continue
+		}
+
+		// Get the result, then delete the query.
+		gl.GetQueryObjectui64v(timer.id, gl.QUERY_RESULT, &elapsed)
+		gl.DeleteQueries(1, &timer.id)
+
+		select {
+		case timer.done <- time.Duration(elapsed):
+		default:
+		}
+	}
+	r.pending.timers = remaining
+	length := len(r.pending.timers)
+	r.pending.Unlock()
+	return length
+}
@@ -0,0 +1,160 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl2
+
+import (
+	"log"
+	"runtime"
+	"unsafe"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/internal/gl/2.0/gl"
+	"github.com/qmcloud/engine/gfx/internal/tag"
+)
+
+// blockBinding is a uniform block index of a shader program, and the binding
+// point that it is assigned to.
+type blockBinding struct {
+	index   uint32
+	binding int
+}
+
+// nativeUniformBlock is stored inside the *UniformBlock.NativeUniformBlock
+// interface and stores the uniform buffer object ID.
+type nativeUniformBlock struct {
+	id uint32
+	r  *rsrcManager
+}
+
+// Implements gfx.Destroyable interface.
+func (n *nativeUniformBlock) Destroy() {
+	finalizeUniformBlock(n)
+}
+
+// finalizeUniformBlock is the finalizer called to free the native uniform
+// block. It must be free'd in the presence of the OpenGL context, and thus we
+// queue it to be free'd at the next available time (next frame).
+func finalizeUniformBlock(n *nativeUniformBlock) {
+	n.r.Lock()
+
+	// If the buffer ID is zero, it has already been free'd.
+	if n.id == 0 {
+		n.r.Unlock()
+		return
+	}
+	n.r.uniformBuffers = append(n.r.uniformBuffers, n.id)
+	n.id = 0
+	n.r.Unlock()
+}
+
+func (r *rsrcManager) freeUniformBuffers() {
+	// Lock the list.
+	r.Lock()
+
+	if tag.Gfxdebug && len(r.uniformBuffers) > 0 {
+		log.Printf("gfx: free %d uniform buffers\n", len(r.uniformBuffers))
+	}
+	if len(r.uniformBuffers) > 0 {
+		// Free the uniform buffers.
+		gl.DeleteBuffers(int32(len(r.uniformBuffers)), &r.uniformBuffers[0])
+
+		// Flush OpenGL commands.
+		gl.Flush()
+	}
+
+	// Slice to zero, and unlock.
+	r.uniformBuffers = r.uniformBuffers[:0]
+	r.Unlock()
+}
+
+// updateUniformBlock uploads the data of the given uniform block to it's
+// uniform buffer object, creating the buffer if needed. It returns the buffer
+// object ID.
+//
+// It may only be called inside renderExec.
+func (r *device) updateUniformBlock(b *gfx.UniformBlock) uint32 {
+	var native *nativeUniformBlock
+	if b.NativeUniformBlock != nil {
+		native = b.NativeUniformBlock.(*nativeUniformBlock)
+	}
+	if native == nil || native.id == 0 {
+		native = &nativeUniformBlock{
+			r: r.rsrcManager,
+		}
+		gl.GenBuffers(1, &native.id)
+
+		// The buffer ID may be a re-used one, which is no longer bound to any
+		// binding point (deleting a buffer unbinds it).
+		for binding, id := range r.uniformBindings {
+			if id == native.id {
+				delete(r.uniformBindings, binding)
+			}
+		}
+
+		b.NativeUniformBlock = native
+		b.Changed = true
+
+		// Attach a finalizer to the uniform block that will later free it.
+		runtime.SetFinalizer(native, finalizeUniformBlock)
+	}
+
+	if b.Changed {
+		var data unsafe.Pointer
+		if len(b.Data) > 0 {
+			data = unsafe.Pointer(&b.Data[0])
+		}
+		gl.BindBuffer(gl.UNIFORM_BUFFER, native.id)
+		gl.BufferData(gl.UNIFORM_BUFFER, len(b.Data), data, gl.DYNAMIC_DRAW)
+		gl.BindBuffer(gl.UNIFORM_BUFFER, 0)
+		b.Changed = false
+	}
+	return native.id
+}
+
+// useUniformBlock binds the given uniform block to it's binding point, and
+// assigns the uniform block of the shader program with the given name to the
+// same binding point.
+//
+// It may only be called inside renderExec.
+func (r *device) useUniformBlock(ns *nativeShader, name string, b *gfx.UniformBlock) {
+	if !r.glArbUniformBufferObject {
+		r.warner.Warnf("Shader input %q is a uniform block, but GL_ARB_uniform_buffer_object is not supported; ignoring.\n", name)
+		return
+	}
+	if b.Binding < 0 || b.Binding >= r.devInfo.MaxUniformBlockBindings {
+		r.warner.Warnf("Uniform block %q uses binding point %d, but the device only has %d; ignoring.\n", name, b.Binding, r.devInfo.MaxUniformBlockBindings)
+		return
+	}
+
+	// Find the uniform block index for the shader program.
+	if ns.blocks == nil {
+		ns.blocks = make(map[string]blockBinding)
+	}
+	bb, ok := ns.blocks[name]
+	if !ok {
+		bb.index = gl.GetUniformBlockIndex(ns.program, gl.Str(name+"\x00"))
+		bb.binding = -1
+		ns.blocks[name] = bb
+	}
+	if bb.index == gl.INVALID_INDEX {
+		// The uniform block is not used by the shader program and should just
+		// be dropped.
+		return
+	}
+
+	// Assign the shader program's uniform block to the binding point.
+	if bb.binding != b.Binding {
+		gl.UniformBlockBinding(ns.program, bb.index, uint32(b.Binding))
+		bb.binding = b.Binding
+		ns.blocks[name] = bb
+	}
+
+	// Upload the uniform data and bind the buffer (once per frame).
+	id := r.updateUniformBlock(b)
+	if bound, ok := r.uniformBindings[b.Binding]; !ok || bound != id {
+		gl.BindBufferBase(gl.UNIFORM_BUFFER, uint32(b.Binding), id)
+		r.uniformBindings[b.Binding] = id
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+// IndexType is a hint to the graphics device on the size of each index of a
+// mesh as stored in graphics memory. Smaller indices use less memory, but can
+// only address a limited number of vertices.
+type IndexType uint8
+
+const (
+	// AutoIndex lets the device choose the smallest index type that can hold
+	// the largest index of the mesh.
+	AutoIndex IndexType = iota
+
+	// Uint16Index is a hint to store indices as 16-bit unsigned integers. It
+	// is ignored if the mesh has an index larger than 65535.
+	Uint16Index
+
+	// Uint32Index is a hint to store indices as 32-bit unsigned integers.
+	//
+	// Some devices (e.g. OpenGL ES 2 ones without the GL_OES_element_index_uint
+	// extension) do not support 32-bit indices, see the Uint32Indices field of
+	// DeviceInfo.
+	Uint32Index
+)
+
+// maxUint16Index is the largest index that a Uint16Index can hold.
+const maxUint16Index = 1<<16 - 1
@@ -121,19 +121,23 @@ package gl
 // typedef void  (APIENTRYP GPATTACHSHADER)(GLuint  program, GLuint  shader);
 // typedef void  (APIENTRYP GPBEGINQUERY)(GLenum  target, GLuint  id);
 // typedef void  (APIENTRYP GPBINDBUFFER)(GLenum  target, GLuint  buffer);
+// typedef void  (APIENTRYP GPBINDBUFFERBASE)(GLenum  target, GLuint  index, GLuint  buffer);
 // typedef void  (APIENTRYP GPBINDFRAMEBUFFER)(GLenum  target, GLuint  framebuffer);
 // typedef void  (APIENTRYP GPBINDRENDERBUFFER)(GLenum  target, GLuint  renderbuffer);
 // typedef void  (APIENTRYP GPBINDTEXTURE)(GLenum  target, GLuint  texture);
 // typedef void  (APIENTRYP GPBLENDCOLOR)(GLfloat  red, GLfloat  green, GLfloat  blue, GLfloat  alpha);
 // typedef void  (APIENTRYP GPBLENDEQUATIONSEPARATE)(GLenum  modeRGB, GLenum  modeAlpha);
 // typedef void  (APIENTRYP GPBLENDFUNCSEPARATE)(GLenum  sfactorRGB, GLenum  dfactorRGB, GLenum  sfactorAlpha, GLenum  dfactorAlpha);
+// typedef void  (APIENTRYP GPBLITFRAMEBUFFER)(GLint  srcX0, GLint  srcY0, GLint  srcX1, GLint  srcY1, GLint  dstX0, GLint  dstY0, GLint  dstX1, GLint  dstY1, GLbitfield  mask, GLenum  filter);
 // typedef void  (APIENTRYP GPBUFFERDATA)(GLenum  target, GLsizeiptr  size, const void * data, GLenum  usage);
+// typedef void  (APIENTRYP GPBUFFERSUBDATA)(GLenum  target, GLintptr  offset, GLsizeiptr  size, const void * data);
 // typedef GLenum  (APIENTRYP GPCHECKFRAMEBUFFERSTATUS)(GLenum  target);
 // typedef void  (APIENTRYP GPCLEAR)(GLbitfield  mask);
 // typedef void  (APIENTRYP GPCLEARCOLOR)(GLfloat  red, GLfloat  green, GLfloat  blue, GLfloat  alpha);
 // typedef void  (APIENTRYP GPCLEARDEPTH)(GLdouble  depth);
 // typedef void  (APIENTRYP GPCLEARDEPTHF)(GLfloat  d);
 // typedef void  (APIENTRYP GPCLEARSTENCIL)(GLint  s);
+// typedef GLenum  (APIENTRYP GPCLIENTWAITSYNC)(GLsync  sync, GLbitfield  flags, GLuint64  timeout);
 // typedef void  (APIENTRYP GPCOLORMASK)(GLboolean  red, GLboolean  green, GLboolean  blue, GLboolean  alpha);
 // typedef void  (APIENTRYP GPCOMPILESHADER)(GLuint  shader);
 // typedef GLuint  (APIENTRYP GPCREATEPROGRAM)();
@@ -146,9 +150,11 @@ package gl
 // typedef void  (APIENTRYP GPDELETEQUERIES)(GLsizei  n, const GLuint * ids);
 // typedef void  (APIENTRYP GPDELETERENDERBUFFERS)(GLsizei  n, const GLuint * renderbuffers);
 // typedef void  (APIENTRYP GPDELETESHADER)(GLuint  shader);
+// typedef void  (APIENTRYP GPDELETESYNC)(GLsync  sync);
 // typedef void  (APIENTRYP GPDELETETEXTURES)(GLsizei  n, const GLuint * textures);
 // typedef void  (APIENTRYP GPDEPTHFUNC)(GLenum  func);
 // typedef void  (APIENTRYP GPDEPTHMASK)(GLboolean  flag);
+// typedef void  (APIENTRYP GPDEPTHRANGE)(GLdouble  near, GLdouble  far);
 // typedef void  (APIENTRYP GPDISABLE)(GLenum  cap);
 // typedef void  (APIENTRYP GPDISABLEVERTEXATTRIBARRAY)(GLuint  index);
 // typedef void  (APIENTRYP GPDRAWARRAYS)(GLenum  mode, GLint  first, GLsizei  count);
@@ -156,16 +162,20 @@ package gl
 // typedef void  (APIENTRYP GPENABLE)(GLenum  cap);
 // typedef void  (APIENTRYP GPENABLEVERTEXATTRIBARRAY)(GLuint  index);
 // typedef void  (APIENTRYP GPENDQUERY)(GLenum  target);
+// typedef GLsync  (APIENTRYP GPFENCESYNC)(GLenum  condition, GLbitfield  flags);
 // typedef void  (APIENTRYP GPFINISH)();
 // typedef void  (APIENTRYP GPFLUSH)();
 // typedef void  (APIENTRYP GPFRAMEBUFFERRENDERBUFFER)(GLenum  target, GLenum  attachment, GLenum  renderbuffertarget, GLuint  renderbuffer);
 // typedef void  (APIENTRYP GPFRAMEBUFFERTEXTURE2D)(GLenum  target, GLenum  attachment, GLenum  textarget, GLuint  texture, GLint  level);
+// typedef void  (APIENTRYP GPFRONTFACE)(GLenum  mode);
 // typedef void  (APIENTRYP GPGENBUFFERS)(GLsizei  n, GLuint * buffers);
 // typedef void  (APIENTRYP GPGENFRAMEBUFFERS)(GLsizei  n, GLuint * framebuffers);
 // typedef void  (APIENTRYP GPGENQUERIES)(GLsizei  n, GLuint * ids);
 // typedef void  (APIENTRYP GPGENRENDERBUFFERS)(GLsizei  n, GLuint * renderbuffers);
 // typedef void  (APIENTRYP GPGENTEXTURES)(GLsizei  n, GLuint * textures);
 // typedef void  (APIENTRYP GPGENERATEMIPMAP)(GLenum  target);
+// typedef void  (APIENTRYP GPGETACTIVEATTRIB)(GLuint  program, GLuint  index, GLsizei  bufSize, GLsizei * length, GLint * size, GLenum * type, GLchar * name);
+// typedef void  (APIENTRYP GPGETACTIVEUNIFORM)(GLuint  program, GLuint  index, GLsizei  bufSize, GLsizei * length, GLint * size, GLenum * type, GLchar * name);
 // typedef GLint  (APIENTRYP GPGETATTRIBLOCATION)(GLuint  program, const GLchar * name);
 // typedef void  (APIENTRYP GPGETBOOLEANV)(GLenum  pname, GLboolean * data);
 // typedef void  (APIENTRYP GPGETDOUBLEV)(GLenum  pname, GLdouble * data);
@@ -175,28 +185,40 @@ package gl
 // typedef void  (APIENTRYP GPGETPROGRAMINFOLOG)(GLuint  program, GLsizei  bufSize, GLsizei * length, GLchar * infoLog);
 // typedef void  (APIENTRYP GPGETPROGRAMIV)(GLuint  program, GLenum  pname, GLint * params);
 // typedef void  (APIENTRYP GPGETQUERYOBJECTIV)(GLuint  id, GLenum  pname, GLint * params);
+// typedef void  (APIENTRYP GPGETQUERYOBJECTUI64V)(GLuint  id, GLenum  pname, GLuint64 * params);
 // typedef void  (APIENTRYP GPGETQUERYIV)(GLenum  target, GLenum  pname, GLint * params);
 // typedef void  (APIENTRYP GPGETSHADERINFOLOG)(GLuint  shader, GLsizei  bufSize, GLsizei * length, GLchar * infoLog);
 // typedef void  (APIENTRYP GPGETSHADERIV)(GLuint  shader, GLenum  pname, GLint * params);
 // typedef const GLubyte * (APIENTRYP GPGETSTRING)(GLenum  name);
+// typedef GLuint  (APIENTRYP GPGETUNIFORMBLOCKINDEX)(GLuint  program, const GLchar * uniformBlockName);
 // typedef GLint  (APIENTRYP GPGETUNIFORMLOCATION)(GLuint  program, const GLchar * name);
 // typedef void  (APIENTRYP GPLINKPROGRAM)(GLuint  program);
+// typedef void  (APIENTRYP GPMULTIDRAWARRAYS)(GLenum  mode, const GLint * first, const GLsizei * count, GLsizei  drawcount);
+// typedef void  (APIENTRYP GPMULTIDRAWELEMENTS)(GLenum  mode, const GLsizei * count, GLenum  type, const void *const* indices, GLsizei  drawcount);
+// typedef void  (APIENTRYP GPPOLYGONMODE)(GLenum  face, GLenum  mode);
+// typedef void  (APIENTRYP GPPOLYGONOFFSET)(GLfloat  factor, GLfloat  units);
+// typedef void  (APIENTRYP GPPRIMITIVERESTARTINDEX)(GLuint  index);
 // typedef void  (APIENTRYP GPREADPIXELS)(GLint  x, GLint  y, GLsizei  width, GLsizei  height, GLenum  format, GLenum  type, void * pixels);
 // typedef void  (APIENTRYP GPRENDERBUFFERSTORAGEMULTISAMPLE)(GLenum  target, GLsizei  samples, GLenum  internalformat, GLsizei  width, GLsizei  height);
 // typedef void  (APIENTRYP GPSCISSOR)(GLint  x, GLint  y, GLsizei  width, GLsizei  height);
+// typedef void  (APIENTRYP GPSHADERBINARY)(GLsizei  count, const GLuint * shaders, GLenum  binaryformat, const void * binary, GLsizei  length);
 // typedef void  (APIENTRYP GPSHADERSOURCE)(GLuint  shader, GLsizei  count, const GLchar *const* string, const GLint * length);
+// typedef void  (APIENTRYP GPSPECIALIZESHADERARB)(GLuint  shader, const GLchar * pEntryPoint, GLuint  numSpecializationConstants, const GLuint * pConstantIndex, const GLuint * pConstantValue);
 // typedef void  (APIENTRYP GPSTENCILFUNCSEPARATE)(GLenum  face, GLenum  func, GLint  ref, GLuint  mask);
 // typedef void  (APIENTRYP GPSTENCILMASKSEPARATE)(GLenum  face, GLuint  mask);
 // typedef void  (APIENTRYP GPSTENCILOPSEPARATE)(GLenum  face, GLenum  sfail, GLenum  dpfail, GLenum  dppass);
 // typedef void  (APIENTRYP GPTEXIMAGE2D)(GLenum  target, GLint  level, GLint  internalformat, GLsizei  width, GLsizei  height, GLint  border, GLenum  format, GLenum  type, const void * pixels);
 // typedef void  (APIENTRYP GPTEXPARAMETERFV)(GLenum  target, GLenum  pname, const GLfloat * params);
 // typedef void  (APIENTRYP GPTEXPARAMETERI)(GLenum  target, GLenum  pname, GLint  param);
+// typedef void  (APIENTRYP GPTEXPARAMETERIV)(GLenum  target, GLenum  pname, const GLint * params);
+// typedef void  (APIENTRYP GPTEXSUBIMAGE2D)(GLenum  target, GLint  level, GLint  xoffset, GLint  yoffset, GLsizei  width, GLsizei  height, GLenum  format, GLenum  type, const void * pixels);
 // typedef void  (APIENTRYP GPUNIFORM1FV)(GLint  location, GLsizei  count, const GLfloat * value);
 // typedef void  (APIENTRYP GPUNIFORM1I)(GLint  location, GLint  v0);
 // typedef void  (APIENTRYP GPUNIFORM1IV)(GLint  location, GLsizei  count, const GLint * value);
 // typedef void  (APIENTRYP GPUNIFORM2FV)(GLint  location, GLsizei  count, const GLfloat * value);
 // typedef void  (APIENTRYP GPUNIFORM3FV)(GLint  location, GLsizei  count, const GLfloat * value);
 // typedef void  (APIENTRYP GPUNIFORM4FV)(GLint  location, GLsizei  count, const GLfloat * value);
+// typedef void  (APIENTRYP GPUNIFORMBLOCKBINDING)(GLuint  program, GLuint  uniformBlockIndex, GLuint  uniformBlockBinding);
 // typedef void  (APIENTRYP GPUNIFORMMATRIX4FV)(GLint  location, GLsizei  count, GLboolean  transpose, const GLfloat * value);
 // typedef void  (APIENTRYP GPUSEPROGRAM)(GLuint  program);
 // typedef void  (APIENTRYP GPVERTEXATTRIBPOINTER)(GLuint  index, GLint  size, GLenum  type, GLboolean  normalized, GLsizei  stride, const void * pointer);
@@ -213,6 +235,9 @@ package gl
 // static void  glowBindBuffer(GPBINDBUFFER fnptr, GLenum  target, GLuint  buffer) {
 //   (*fnptr)(target, buffer);
 // }
+// static void  glowBindBufferBase(GPBINDBUFFERBASE fnptr, GLenum  target, GLuint  index, GLuint  buffer) {
+//   (*fnptr)(target, index, buffer);
+// }
 // static void  glowBindFramebuffer(GPBINDFRAMEBUFFER fnptr, GLenum  target, GLuint  framebuffer) {
 //   (*fnptr)(target, framebuffer);
 // }
@@ -231,9 +256,15 @@ package gl
 // static void  glowBlendFuncSeparate(GPBLENDFUNCSEPARATE fnptr, GLenum  sfactorRGB, GLenum  dfactorRGB, GLenum  sfactorAlpha, GLenum  dfactorAlpha) {
 //   (*fnptr)(sfactorRGB, dfactorRGB, sfactorAlpha, dfactorAlpha);
 // }
+// static void  glowBlitFramebuffer(GPBLITFRAMEBUFFER fnptr, GLint  srcX0, GLint  srcY0, GLint  srcX1, GLint  srcY1, GLint  dstX0, GLint  dstY0, GLint  dstX1, GLint  dstY1, GLbitfield  mask, GLenum  filter) {
+//   (*fnptr)(srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1, mask, filter);
+// }
 // static void  glowBufferData(GPBUFFERDATA fnptr, GLenum  target, GLsizeiptr  size, const void * data, GLenum  usage) {
 //   (*fnptr)(target, size, data, usage);
 // }
+// static void  glowBufferSubData(GPBUFFERSUBDATA fnptr, GLenum  target, GLintptr  offset, GLsizeiptr  size, const void * data) {
+//   (*fnptr)(target, offset, size, data);
+// }
 // static GLenum  glowCheckFramebufferStatus(GPCHECKFRAMEBUFFERSTATUS fnptr, GLenum  target) {
 //   return (*fnptr)(target);
 // }
@@ -252,6 +283,9 @@ package gl
 // static void  glowClearStencil(GPCLEARSTENCIL fnptr, GLint  s) {
 //   (*fnptr)(s);
 // }
+// static GLenum  glowClientWaitSync(GPCLIENTWAITSYNC fnptr, GLsync  sync, GLbitfield  flags, GLuint64  timeout) {
+//   return (*fnptr)(sync, flags, timeout);
+// }
 // static void  glowColorMask(GPCOLORMASK fnptr, GLboolean  red, GLboolean  green, GLboolean  blue, GLboolean  alpha) {
 //   (*fnptr)(red, green, blue, alpha);
 // }
@@ -288,6 +322,9 @@ package gl
 // static void  glowDeleteShader(GPDELETESHADER fnptr, GLuint  shader) {
 //   (*fnptr)(shader);
 // }
+// static void  glowDeleteSync(GPDELETESYNC fnptr, GLsync  sync) {
+//   (*fnptr)(sync);
+// }
 // static void  glowDeleteTextures(GPDELETETEXTURES fnptr, GLsizei  n, const GLuint * textures) {
 //   (*fnptr)(n, textures);
 // }
@@ -297,6 +334,9 @@ package gl
 // static void  glowDepthMask(GPDEPTHMASK fnptr, GLboolean  flag) {
 //   (*fnptr)(flag);
 // }
+// static void  glowDepthRange(GPDEPTHRANGE fnptr, GLdouble  near, GLdouble  far) {
+//   (*fnptr)(near, far);
+// }
 // static void  glowDisable(GPDISABLE fnptr, GLenum  cap) {
 //   (*fnptr)(cap);
 // }
@@ -318,6 +358,9 @@ package gl
 // static void  glowEndQuery(GPENDQUERY fnptr, GLenum  target) {
 //   (*fnptr)(target);
 // }
+// static GLsync  glowFenceSync(GPFENCESYNC fnptr, GLenum  condition, GLbitfield  flags) {
+//   return (*fnptr)(condition, flags);
+// }
 // static void  glowFinish(GPFINISH fnptr) {
 //   (*fnptr)();
 // }
@@ -330,6 +373,9 @@ package gl
 // static void  glowFramebufferTexture2D(GPFRAMEBUFFERTEXTURE2D fnptr, GLenum  target, GLenum  attachment, GLenum  textarget, GLuint  texture, GLint  level) {
 //   (*fnptr)(target, attachment, textarget, texture, level);
 // }
+// static void  glowFrontFace(GPFRONTFACE fnptr, GLenum  mode) {
+//   (*fnptr)(mode);
+// }
 // static void  glowGenBuffers(GPGENBUFFERS fnptr, GLsizei  n, GLuint * buffers) {
 //   (*fnptr)(n, buffers);
 // }
@@ -348,6 +394,12 @@ package gl
 // static void  glowGenerateMipmap(GPGENERATEMIPMAP fnptr, GLenum  target) {
 //   (*fnptr)(target);
 // }
+// static void  glowGetActiveAttrib(GPGETACTIVEATTRIB fnptr, GLuint  program, GLuint  index, GLsizei  bufSize, GLsizei * length, GLint * size, GLenum * type, GLchar * name) {
+//   (*fnptr)(program, index, bufSize, length, size, type, name);
+// }
+// static void  glowGetActiveUniform(GPGETACTIVEUNIFORM fnptr, GLuint  program, GLuint  index, GLsizei  bufSize, GLsizei * length, GLint * size, GLenum * type, GLchar * name) {
+//   (*fnptr)(program, index, bufSize, length, size, type, name);
+// }
 // static GLint  glowGetAttribLocation(GPGETATTRIBLOCATION fnptr, GLuint  program, const GLchar * name) {
 //   return (*fnptr)(program, name);
 // }
@@ -375,6 +427,9 @@ package gl
 // static void  glowGetQueryObjectiv(GPGETQUERYOBJECTIV fnptr, GLuint  id, GLenum  pname, GLint * params) {
 //   (*fnptr)(id, pname, params);
 // }
+// static void  glowGetQueryObjectui64v(GPGETQUERYOBJECTUI64V fnptr, GLuint  id, GLenum  pname, GLuint64 * params) {
+//   (*fnptr)(id, pname, params);
+// }
 // static void  glowGetQueryiv(GPGETQUERYIV fnptr, GLenum  target, GLenum  pname, GLint * params) {
 //   (*fnptr)(target, pname, params);
 // }
@@ -387,12 +442,30 @@ package gl
 // static const GLubyte * glowGetString(GPGETSTRING fnptr, GLenum  name) {
 //   return (*fnptr)(name);
 // }
+// static GLuint  glowGetUniformBlockIndex(GPGETUNIFORMBLOCKINDEX fnptr, GLuint  program, const GLchar * uniformBlockName) {
+//   return (*fnptr)(program, uniformBlockName);
+// }
 // static GLint  glowGetUniformLocation(GPGETUNIFORMLOCATION fnptr, GLuint  program, const GLchar * name) {
 //   return (*fnptr)(program, name);
 // }
 // static void  glowLinkProgram(GPLINKPROGRAM fnptr, GLuint  program) {
 //   (*fnptr)(program);
 // }
+// static void  glowMultiDrawArrays(GPMULTIDRAWARRAYS fnptr, GLenum  mode, const GLint * first, const GLsizei * count, GLsizei  drawcount) {
+//   (*fnptr)(mode, first, count, drawcount);
+// }
+// static void  glowMultiDrawElements(GPMULTIDRAWELEMENTS fnptr, GLenum  mode, const GLsizei * count, GLenum  type, const void *const* indices, GLsizei  drawcount) {
+//   (*fnptr)(mode, count, type, indices, drawcount);
+// }
+// static void  glowPolygonMode(GPPOLYGONMODE fnptr, GLenum  face, GLenum  mode) {
+//   (*fnptr)(face, mode);
+// }
+// static void  glowPolygonOffset(GPPOLYGONOFFSET fnptr, GLfloat  factor, GLfloat  units) {
+//   (*fnptr)(factor, units);
+// }
+// static void  glowPrimitiveRestartIndex(GPPRIMITIVERESTARTINDEX fnptr, GLuint  index) {
+//   (*fnptr)(index);
+// }
 // static void  glowReadPixels(GPREADPIXELS fnptr, GLint  x, GLint  y, GLsizei  width, GLsizei  height, GLenum  format, GLenum  type, void * pixels) {
 //   (*fnptr)(x, y, width, height, format, type, pixels);
 // }
@@ -402,9 +475,15 @@ package gl
 // static void  glowScissor(GPSCISSOR fnptr, GLint  x, GLint  y, GLsizei  width, GLsizei  height) {
 //   (*fnptr)(x, y, width, height);
 // }
+// static void  glowShaderBinary(GPSHADERBINARY fnptr, GLsizei  count, const GLuint * shaders, GLenum  binaryformat, const void * binary, GLsizei  length) {
+//   (*fnptr)(count, shaders, binaryformat, binary, length);
+// }
 // static void  glowShaderSource(GPSHADERSOURCE fnptr, GLuint  shader, GLsizei  count, const GLchar *const* string, const GLint * length) {
 //   (*fnptr)(shader, count, string, length);
 // }
+// static void  glowSpecializeShaderARB(GPSPECIALIZESHADERARB fnptr, GLuint  shader, const GLchar * pEntryPoint, GLuint  numSpecializationConstants, const GLuint * pConstantIndex, const GLuint * pConstantValue) {
+//   (*fnptr)(shader, pEntryPoint, numSpecializationConstants, pConstantIndex, pConstantValue);
+// }
 // static void  glowStencilFuncSeparate(GPSTENCILFUNCSEPARATE fnptr, GLenum  face, GLenum  func, GLint  ref, GLuint  mask) {
 //   (*fnptr)(face, func, ref, mask);
 // }
@@ -423,6 +502,12 @@ package gl
 // static void  glowTexParameteri(GPTEXPARAMETERI fnptr, GLenum  target, GLenum  pname, GLint  param) {
 //   (*fnptr)(target, pname, param);
 // }
+// static void  glowTexParameteriv(GPTEXPARAMETERIV fnptr, GLenum  target, GLenum  pname, const GLint * params) {
+//   (*fnptr)(target, pname, params);
+// }
+// static void  glowTexSubImage2D(GPTEXSUBIMAGE2D fnptr, GLenum  target, GLint  level, GLint  xoffset, GLint  yoffset, GLsizei  width, GLsizei  height, GLenum  format, GLenum  type, const void * pixels) {
+//   (*fnptr)(target, level, xoffset, yoffset, width, height, format, type, pixels);
+// }
 // static void  glowUniform1fv(GPUNIFORM1FV fnptr, GLint  location, GLsizei  count, const GLfloat * value) {
 //   (*fnptr)(location, count, value);
 // }
@@ -441,6 +526,9 @@ package gl
 // static void  glowUniform4fv(GPUNIFORM4FV fnptr, GLint  location, GLsizei  count, const GLfloat * value) {
 //   (*fnptr)(location, count, value);
 // }
+// static void  glowUniformBlockBinding(GPUNIFORMBLOCKBINDING fnptr, GLuint  program, GLuint  uniformBlockIndex, GLuint  uniformBlockBinding) {
+//   (*fnptr)(program, uniformBlockIndex, uniformBlockBinding);
+// }
 // static void  glowUniformMatrix4fv(GPUNIFORMMATRIX4FV fnptr, GLint  location, GLsizei  count, GLboolean  transpose, const GLfloat * value) {
 //   (*fnptr)(location, count, transpose, value);
 // }
@@ -460,7 +548,13 @@ import (
 )
 
 const (
+	ACTIVE_ATTRIBUTES                         = 0x8B89
+	ACTIVE_ATTRIBUTE_MAX_LENGTH               = 0x8B8A
+	ACTIVE_UNIFORMS                           = 0x8B86
+	ACTIVE_UNIFORM_MAX_LENGTH                 = 0x8B87
+	ALPHA                                     = 0x1906
 	ALPHA_BITS                                = 0x0D55
+	ALREADY_SIGNALED                          = 0x911A
 	ALWAYS                                    = 0x0207
 	ARRAY_BUFFER                              = 0x8892
 	BACK                                      = 0x0405
@@ -473,20 +567,25 @@ const (
 	BLEND_EQUATION_RGB                        = 0x8009
 	BLEND_SRC_ALPHA                           = 0x80CB
 	BLEND_SRC_RGB                             = 0x80C9
+	BLUE                                      = 0x1905
 	BLUE_BITS                                 = 0x0D54
+	CCW                                       = 0x0901
 	CLAMP_TO_BORDER                           = 0x812D
 	CLAMP_TO_EDGE                             = 0x812F
 	COLOR_ATTACHMENT0                         = 0x8CE0
 	COLOR_BUFFER_BIT                          = 0x00004000
 	COLOR_CLEAR_VALUE                         = 0x0C22
 	COLOR_WRITEMASK                           = 0x0C23
+	COMPARE_R_TO_TEXTURE                      = 0x884E
 	COMPILE_STATUS                            = 0x8B81
 	COMPRESSED_TEXTURE_FORMATS                = 0x86A3
+	CONDITION_SATISFIED                       = 0x911C
 	CONSTANT_ALPHA                            = 0x8003
 	CONSTANT_COLOR                            = 0x8001
 	CULL_FACE                                 = 0x0B44
 	CULL_FACE_MODE                            = 0x0B45
 	CURRENT_PROGRAM                           = 0x8B8D
+	CW                                        = 0x0900
 	DEBUG_OUTPUT_SYNCHRONOUS_ARB              = 0x8242
 	DEBUG_SEVERITY_HIGH                       = 0x9146
 	DEBUG_SEVERITY_LOW                        = 0x9148
@@ -510,15 +609,18 @@ const (
 	DEPTH_COMPONENT24                         = 0x81A6
 	DEPTH_COMPONENT32                         = 0x81A7
 	DEPTH_FUNC                                = 0x0B74
+	DEPTH_RANGE                               = 0x0B70
 	DEPTH_TEST                                = 0x0B71
 	DEPTH_WRITEMASK                           = 0x0B72
 	DITHER                                    = 0x0BD0
+	DRAW_FRAMEBUFFER                          = 0x8CA9
 	DST_ALPHA                                 = 0x0304
 	DST_COLOR                                 = 0x0306
 	DYNAMIC_DRAW                              = 0x88E8
 	ELEMENT_ARRAY_BUFFER                      = 0x8893
 	EQUAL                                     = 0x0202
 	EXTENSIONS                                = 0x1F03
+	FILL                                      = 0x1B02
 	FLOAT                                     = 0x1406
 	FRAGMENT_SHADER                           = 0x8B30
 	FRAMEBUFFER                               = 0x8D40
@@ -532,35 +634,44 @@ const (
 	FRAMEBUFFER_UNSUPPORTED                   = 0x8CDD
 	FRONT                                     = 0x0404
 	FRONT_AND_BACK                            = 0x0408
+	FRONT_FACE                                = 0x0B46
 	FUNC_ADD                                  = 0x8006
 	FUNC_REVERSE_SUBTRACT                     = 0x800B
 	FUNC_SUBTRACT                             = 0x800A
 	GENERATE_MIPMAP                           = 0x8191
 	GEQUAL                                    = 0x0206
 	GREATER                                   = 0x0204
+	GREEN                                     = 0x1904
 	GREEN_BITS                                = 0x0D53
 	INCR                                      = 0x1E02
 	INCR_WRAP                                 = 0x8507
 	INFO_LOG_LENGTH                           = 0x8B84
 	INVALID_ENUM                              = 0x0500
 	INVALID_FRAMEBUFFER_OPERATION             = 0x0506
+	INVALID_INDEX                             = 0xFFFFFFFF
 	INVALID_OPERATION                         = 0x0502
 	INVALID_VALUE                             = 0x0501
 	INVERT                                    = 0x150A
 	KEEP                                      = 0x1E00
 	LEQUAL                                    = 0x0203
 	LESS                                      = 0x0201
+	LINE                                      = 0x1B01
 	LINEAR                                    = 0x2601
 	LINEAR_MIPMAP_LINEAR                      = 0x2703
 	LINEAR_MIPMAP_NEAREST                     = 0x2701
 	LINES                                     = 0x0001
 	LINK_STATUS                               = 0x8B82
+	MAX_COMBINED_TEXTURE_IMAGE_UNITS          = 0x8B4D
+	MAX_CUBE_MAP_TEXTURE_SIZE                 = 0x851C
 	MAX_FRAGMENT_UNIFORM_COMPONENTS           = 0x8B49
 	MAX_FRAGMENT_UNIFORM_VECTORS              = 0x8DFD
 	MAX_SAMPLES                               = 0x8D57
+	MAX_TEXTURE_IMAGE_UNITS                   = 0x8872
 	MAX_TEXTURE_SIZE                          = 0x0D33
+	MAX_UNIFORM_BUFFER_BINDINGS               = 0x8A2F
 	MAX_VARYING_FLOATS                        = 0x8B4B
 	MAX_VARYING_VECTORS                       = 0x8DFC
+	MAX_VERTEX_ATTRIBS                        = 0x8869
 	MAX_VERTEX_UNIFORM_COMPONENTS             = 0x8B4A
 	MAX_VERTEX_UNIFORM_VECTORS                = 0x8DFB
 	MIRRORED_REPEAT                           = 0x8370
@@ -569,6 +680,7 @@ const (
 	NEAREST_MIPMAP_LINEAR                     = 0x2702
 	NEAREST_MIPMAP_NEAREST                    = 0x2700
 	NEVER                                     = 0x0200
+	NONE                                      = 0
 	NOTEQUAL                                  = 0x0205
 	NO_ERROR                                  = 0
 	NUM_COMPRESSED_TEXTURE_FORMATS            = 0x86A2
@@ -581,10 +693,16 @@ const (
 	ONE_MINUS_SRC_COLOR                       = 0x0301
 	OUT_OF_MEMORY                             = 0x0505
 	POINTS                                    = 0x0000
+	POLYGON_OFFSET_FACTOR                     = 0x8038
+	POLYGON_OFFSET_FILL                       = 0x8037
+	POLYGON_OFFSET_UNITS                      = 0x2A00
+	PRIMITIVE_RESTART                         = 0x8F9D
 	PROGRAM_POINT_SIZE_EXT                    = 0x8642
 	QUERY_COUNTER_BITS                        = 0x8864
 	QUERY_RESULT                              = 0x8866
 	QUERY_RESULT_AVAILABLE                    = 0x8867
+	READ_FRAMEBUFFER                          = 0x8CA8
+	RED                                       = 0x1903
 	RED_BITS                                  = 0x0D52
 	RENDERBUFFER                              = 0x8D41
 	RENDERER                                  = 0x1F01
@@ -600,6 +718,7 @@ const (
 	SAMPLE_BUFFERS                            = 0x80A8
 	SCISSOR_BOX                               = 0x0C10
 	SCISSOR_TEST                              = 0x0C11
+	SHADER_BINARY_FORMAT_SPIR_V_ARB           = 0x9551
 	SHADING_LANGUAGE_VERSION                  = 0x8B8C
 	SRC_ALPHA                                 = 0x0302
 	SRC_ALPHA_SATURATE                        = 0x0308
@@ -626,23 +745,46 @@ const (
 	STENCIL_TEST                              = 0x0B90
 	STENCIL_VALUE_MASK                        = 0x0B93
 	STENCIL_WRITEMASK                         = 0x0B98
+	STREAM_DRAW                               = 0x88E0
+	SYNC_FLUSH_COMMANDS_BIT                   = 0x00000001
+	SYNC_GPU_COMMANDS_COMPLETE                = 0x9117
 	TEXTURE0                                  = 0x84C0
 	TEXTURE_2D                                = 0x0DE1
 	TEXTURE_BASE_LEVEL                        = 0x813C
 	TEXTURE_BORDER_COLOR                      = 0x1004
+	TEXTURE_COMPARE_FUNC                      = 0x884D
+	TEXTURE_COMPARE_MODE                      = 0x884C
+	TEXTURE_CUBE_MAP                          = 0x8513
+	TEXTURE_CUBE_MAP_NEGATIVE_X               = 0x8516
+	TEXTURE_CUBE_MAP_NEGATIVE_Y               = 0x8518
+	TEXTURE_CUBE_MAP_NEGATIVE_Z               = 0x851A
+	TEXTURE_CUBE_MAP_POSITIVE_X               = 0x8515
+	TEXTURE_CUBE_MAP_POSITIVE_Y               = 0x8517
+	TEXTURE_CUBE_MAP_POSITIVE_Z               = 0x8519
+	TEXTURE_CUBE_MAP_SEAMLESS                 = 0x884F
+	TEXTURE_LOD_BIAS                          = 0x8501
 	TEXTURE_MAG_FILTER                        = 0x2800
 	TEXTURE_MAX_LEVEL                         = 0x813D
+	TEXTURE_MAX_LOD                           = 0x813B
 	TEXTURE_MIN_FILTER                        = 0x2801
+	TEXTURE_MIN_LOD                           = 0x813A
+	TEXTURE_SWIZZLE_RGBA                      = 0x8E46
+	TEXTURE_WRAP_R                            = 0x8072
 	TEXTURE_WRAP_S                            = 0x2802
 	TEXTURE_WRAP_T                            = 0x2803
+	TIMEOUT_EXPIRED                           = 0x911B
+	TIME_ELAPSED                              = 0x88BF
 	TRIANGLES                                 = 0x0004
 	TRUE                                      = 1
+	UNIFORM_BUFFER                            = 0x8A11
 	UNSIGNED_BYTE                             = 0x1401
 	UNSIGNED_INT                              = 0x1405
+	UNSIGNED_SHORT                            = 0x1403
 	VENDOR                                    = 0x1F00
 	VERSION                                   = 0x1F02
 	VERTEX_SHADER                             = 0x8B31
 	VIEWPORT                                  = 0x0BA2
+	WAIT_FAILED                               = 0x911D
 	ZERO                                      = 0
 )
 
@@ -651,19 +793,23 @@ var (
 	gpAttachShader                   C.GPATTACHSHADER
 	gpBeginQuery                     C.GPBEGINQUERY
 	gpBindBuffer                     C.GPBINDBUFFER
+	gpBindBufferBase                 C.GPBINDBUFFERBASE
 	gpBindFramebuffer                C.GPBINDFRAMEBUFFER
 	gpBindRenderbuffer               C.GPBINDRENDERBUFFER
 	gpBindTexture                    C.GPBINDTEXTURE
 	gpBlendColor                     C.GPBLENDCOLOR
 	gpBlendEquationSeparate          C.GPBLENDEQUATIONSEPARATE
 	gpBlendFuncSeparate              C.GPBLENDFUNCSEPARATE
+	gpBlitFramebuffer                C.GPBLITFRAMEBUFFER
 	gpBufferData                     C.GPBUFFERDATA
+	gpBufferSubData                  C.GPBUFFERSUBDATA
 	gpCheckFramebufferStatus         C.GPCHECKFRAMEBUFFERSTATUS
 	gpClear                          C.GPCLEAR
 	gpClearColor                     C.GPCLEARCOLOR
 	gpClearDepth                     C.GPCLEARDEPTH
 	gpClearDepthf                    C.GPCLEARDEPTHF
 	gpClearStencil                   C.GPCLEARSTENCIL
+	gpClientWaitSync                 C.GPCLIENTWAITSYNC
 	gpColorMask                      C.GPCOLORMASK
 	gpCompileShader                  C.GPCOMPILESHADER
 	gpCreateProgram                  C.GPCREATEPROGRAM
@@ -676,9 +822,11 @@ var (
 	gpDeleteQueries                  C.GPDELETEQUERIES
 	gpDeleteRenderbuffers            C.GPDELETERENDERBUFFERS
 	gpDeleteShader                   C.GPDELETESHADER
+	gpDeleteSync                     C.GPDELETESYNC
 	gpDeleteTextures                 C.GPDELETETEXTURES
 	gpDepthFunc                      C.GPDEPTHFUNC
 	gpDepthMask                      C.GPDEPTHMASK
+	gpDepthRange                     C.GPDEPTHRANGE
 	gpDisable                        C.GPDISABLE
 	gpDisableVertexAttribArray       C.GPDISABLEVERTEXATTRIBARRAY
 	gpDrawArrays                     C.GPDRAWARRAYS
@@ -686,16 +834,20 @@ var (
 	gpEnable                         C.GPENABLE
 	gpEnableVertexAttribArray        C.GPENABLEVERTEXATTRIBARRAY
 	gpEndQuery                       C.GPENDQUERY
+	gpFenceSync                      C.GPFENCESYNC
 	gpFinish                         C.GPFINISH
 	gpFlush                          C.GPFLUSH
 	gpFramebufferRenderbuffer        C.GPFRAMEBUFFERRENDERBUFFER
 	gpFramebufferTexture2D           C.GPFRAMEBUFFERTEXTURE2D
+	gpFrontFace                      C.GPFRONTFACE
 	gpGenBuffers                     C.GPGENBUFFERS
 	gpGenFramebuffers                C.GPGENFRAMEBUFFERS
 	gpGenQueries                     C.GPGENQUERIES
 	gpGenRenderbuffers               C.GPGENRENDERBUFFERS
 	gpGenTextures                    C.GPGENTEXTURES
 	gpGenerateMipmap                 C.GPGENERATEMIPMAP
+	gpGetActiveAttrib                C.GPGETACTIVEATTRIB
+	gpGetActiveUniform               C.GPGETACTIVEUNIFORM
 	gpGetAttribLocation              C.GPGETATTRIBLOCATION
 	gpGetBooleanv                    C.GPGETBOOLEANV
 	gpGetDoublev                     C.GPGETDOUBLEV
@@ -705,28 +857,40 @@ var (
 	gpGetProgramInfoLog              C.GPGETPROGRAMINFOLOG
 	gpGetProgramiv                   C.GPGETPROGRAMIV
 	gpGetQueryObjectiv               C.GPGETQUERYOBJECTIV
+	gpGetQueryObjectui64v            C.GPGETQUERYOBJECTUI64V
 	gpGetQueryiv                     C.GPGETQUERYIV
 	gpGetShaderInfoLog               C.GPGETSHADERINFOLOG
 	gpGetShaderiv                    C.GPGETSHADERIV
 	gpGetString                      C.GPGETSTRING
+	gpGetUniformBlockIndex           C.GPGETUNIFORMBLOCKINDEX
 	gpGetUniformLocation             C.GPGETUNIFORMLOCATION
 	gpLinkProgram                    C.GPLINKPROGRAM
+	gpMultiDrawArrays                C.GPMULTIDRAWARRAYS
+	gpMultiDrawElements              C.GPMULTIDRAWELEMENTS
+	gpPolygonMode                    C.GPPOLYGONMODE
+	gpPolygonOffset                  C.GPPOLYGONOFFSET
+	gpPrimitiveRestartIndex          C.GPPRIMITIVERESTARTINDEX
 	gpReadPixels                     C.GPREADPIXELS
 	gpRenderbufferStorageMultisample C.GPRENDERBUFFERSTORAGEMULTISAMPLE
 	gpScissor                        C.GPSCISSOR
+	gpShaderBinary                   C.GPSHADERBINARY
 	gpShaderSource                   C.GPSHADERSOURCE
+	gpSpecializeShaderARB            C.GPSPECIALIZESHADERARB
 	gpStencilFuncSeparate            C.GPSTENCILFUNCSEPARATE
 	gpStencilMaskSeparate            C.GPSTENCILMASKSEPARATE
 	gpStencilOpSeparate              C.GPSTENCILOPSEPARATE
 	gpTexImage2D                     C.GPTEXIMAGE2D
 	gpTexParameterfv                 C.GPTEXPARAMETERFV
 	gpTexParameteri                  C.GPTEXPARAMETERI
+	gpTexParameteriv                 C.GPTEXPARAMETERIV
+	gpTexSubImage2D                  C.GPTEXSUBIMAGE2D
 	gpUniform1fv                     C.GPUNIFORM1FV
 	gpUniform1i                      C.GPUNIFORM1I
 	gpUniform1iv                     C.GPUNIFORM1IV
 	gpUniform2fv                     C.GPUNIFORM2FV
 	gpUniform3fv                     C.GPUNIFORM3FV
 	gpUniform4fv                     C.GPUNIFORM4FV
+	gpUniformBlockBinding            C.GPUNIFORMBLOCKBINDING
 	gpUniformMatrix4fv               C.GPUNIFORMMATRIX4FV
 	gpUseProgram                     C.GPUSEPROGRAM
 	gpVertexAttribPointer            C.GPVERTEXATTRIBPOINTER
@@ -741,6 +905,12 @@ func boolToInt(b bool) int {
 	return 0
 }
 
+// SPIRVLoaded reports whether the glShaderBinary and glSpecializeShaderARB
+// entry points, which are needed to load SPIR-V shaders, were loaded by Init.
+func SPIRVLoaded() bool {
+	return gpShaderBinary != nil && gpSpecializeShaderARB != nil
+}
+
 // select active texture unit
 func ActiveTexture(texture uint32) {
 	C.glowActiveTexture(gpActiveTexture, (C.GLenum)(texture))
@@ -761,6 +931,11 @@ func BindBuffer(target uint32, buffer uint32) {
 	C.glowBindBuffer(gpBindBuffer, (C.GLenum)(target), (C.GLuint)(buffer))
 }
 
+// bind a buffer object to an indexed buffer target
+func BindBufferBase(target uint32, index uint32, buffer uint32) {
+	C.glowBindBufferBase(gpBindBufferBase, (C.GLenum)(target), (C.GLuint)(index), (C.GLuint)(buffer))
+}
+
 // bind a framebuffer to a framebuffer target
 func BindFramebuffer(target uint32, framebuffer uint32) {
 	C.glowBindFramebuffer(gpBindFramebuffer, (C.GLenum)(target), (C.GLuint)(framebuffer))
@@ -791,10 +966,18 @@ func BlendFuncSeparate(sfactorRGB uint32, dfactorRGB uint32, sfactorAlpha uint32
 	C.glowBlendFuncSeparate(gpBlendFuncSeparate, (C.GLenum)(sfactorRGB), (C.GLenum)(dfactorRGB), (C.GLenum)(sfactorAlpha), (C.GLenum)(dfactorAlpha))
 }
 
+// copy a block of pixels from the read framebuffer to the draw framebuffer
+func BlitFramebuffer(srcX0 int32, srcY0 int32, srcX1 int32, srcY1 int32, dstX0 int32, dstY0 int32, dstX1 int32, dstY1 int32, mask uint32, filter uint32) {
+	C.glowBlitFramebuffer(gpBlitFramebuffer, (C.GLint)(srcX0), (C.GLint)(srcY0), (C.GLint)(srcX1), (C.GLint)(srcY1), (C.GLint)(dstX0), (C.GLint)(dstY0), (C.GLint)(dstX1), (C.GLint)(dstY1), (C.GLbitfield)(mask), (C.GLenum)(filter))
+}
+
 // creates and initializes a buffer object's data     store
 func BufferData(target uint32, size int, data unsafe.Pointer, usage uint32) {
 	C.glowBufferData(gpBufferData, (C.GLenum)(target), (C.GLsizeiptr)(size), data, (C.GLenum)(usage))
 }
+func BufferSubData(target uint32, offset int, size int, data unsafe.Pointer) {
+	C.glowBufferSubData(gpBufferSubData, (C.GLenum)(target), (C.GLintptr)(offset), (C.GLsizeiptr)(size), data)
+}
 
 // check the completeness status of a framebuffer
 func CheckFramebufferStatus(target uint32) uint32 {
@@ -820,6 +1003,12 @@ func ClearDepthf(d float32) {
 	C.glowClearDepthf(gpClearDepthf, (C.GLfloat)(d))
 }
 
+// block and wait for a sync object to become signaled
+func ClientWaitSync(sync unsafe.Pointer, flags uint32, timeout uint64) uint32 {
+	ret := C.glowClientWaitSync(gpClientWaitSync, (C.GLsync)(sync), (C.GLbitfield)(flags), (C.GLuint64)(timeout))
+	return (uint32)(ret)
+}
+
 // specify the clear value for the stencil buffer
 func ClearStencil(s int32) {
 	C.glowClearStencil(gpClearStencil, (C.GLint)(s))
@@ -884,6 +1073,11 @@ func DeleteShader(shader uint32) {
 	C.glowDeleteShader(gpDeleteShader, (C.GLuint)(shader))
 }
 
+// delete a sync object
+func DeleteSync(sync unsafe.Pointer) {
+	C.glowDeleteSync(gpDeleteSync, (C.GLsync)(sync))
+}
+
 // delete named textures
 func DeleteTextures(n int32, textures *uint32) {
 	C.glowDeleteTextures(gpDeleteTextures, (C.GLsizei)(n), (*C.GLuint)(unsafe.Pointer(textures)))
@@ -898,6 +1092,11 @@ func DepthFunc(xfunc uint32) {
 func DepthMask(flag bool) {
 	C.glowDepthMask(gpDepthMask, (C.GLboolean)(boolToInt(flag)))
 }
+
+// specify mapping of depth values from normalized device coordinates to window coordinates
+func DepthRange(near float64, far float64) {
+	C.glowDepthRange(gpDepthRange, (C.GLdouble)(near), (C.GLdouble)(far))
+}
 func Disable(cap uint32) {
 	C.glowDisable(gpDisable, (C.GLenum)(cap))
 }
@@ -930,6 +1129,12 @@ func EndQuery(target uint32) {
 	C.glowEndQuery(gpEndQuery, (C.GLenum)(target))
 }
 
+// create a new sync object and insert it into the GL command stream
+func FenceSync(condition uint32, flags uint32) unsafe.Pointer {
+	ret := C.glowFenceSync(gpFenceSync, (C.GLenum)(condition), (C.GLbitfield)(flags))
+	return (unsafe.Pointer)(ret)
+}
+
 // block until all GL execution is complete
 func Finish() {
 	C.glowFinish(gpFinish)
@@ -948,6 +1153,11 @@ func FramebufferTexture2D(target uint32, attachment uint32, textarget uint32, te
 	C.glowFramebufferTexture2D(gpFramebufferTexture2D, (C.GLenum)(target), (C.GLenum)(attachment), (C.GLenum)(textarget), (C.GLuint)(texture), (C.GLint)(level))
 }
 
+// define front- and back-facing polygons
+func FrontFace(mode uint32) {
+	C.glowFrontFace(gpFrontFace, (C.GLenum)(mode))
+}
+
 // generate buffer object names
 func GenBuffers(n int32, buffers *uint32) {
 	C.glowGenBuffers(gpGenBuffers, (C.GLsizei)(n), (*C.GLuint)(unsafe.Pointer(buffers)))
@@ -978,6 +1188,16 @@ func GenerateMipmap(target uint32) {
 	C.glowGenerateMipmap(gpGenerateMipmap, (C.GLenum)(target))
 }
 
+// Returns information about an active attribute variable for the specified program object
+func GetActiveAttrib(program uint32, index uint32, bufSize int32, length *int32, size *int32, xtype *uint32, name *uint8) {
+	C.glowGetActiveAttrib(gpGetActiveAttrib, (C.GLuint)(program), (C.GLuint)(index), (C.GLsizei)(bufSize), (*C.GLsizei)(unsafe.Pointer(length)), (*C.GLint)(unsafe.Pointer(size)), (*C.GLenum)(unsafe.Pointer(xtype)), (*C.GLchar)(unsafe.Pointer(name)))
+}
+
+// Returns information about an active uniform variable for the specified program object
+func GetActiveUniform(program uint32, index uint32, bufSize int32, length *int32, size *int32, xtype *uint32, name *uint8) {
+	C.glowGetActiveUniform(gpGetActiveUniform, (C.GLuint)(program), (C.GLuint)(index), (C.GLsizei)(bufSize), (*C.GLsizei)(unsafe.Pointer(length)), (*C.GLint)(unsafe.Pointer(size)), (*C.GLenum)(unsafe.Pointer(xtype)), (*C.GLchar)(unsafe.Pointer(name)))
+}
+
 // Returns the location of an attribute variable
 func GetAttribLocation(program uint32, name *uint8) int32 {
 	ret := C.glowGetAttribLocation(gpGetAttribLocation, (C.GLuint)(program), (*C.GLchar)(unsafe.Pointer(name)))
@@ -1014,6 +1234,9 @@ func GetProgramiv(program uint32, pname uint32, params *int32) {
 func GetQueryObjectiv(id uint32, pname uint32, params *int32) {
 	C.glowGetQueryObjectiv(gpGetQueryObjectiv, (C.GLuint)(id), (C.GLenum)(pname), (*C.GLint)(unsafe.Pointer(params)))
 }
+func GetQueryObjectui64v(id uint32, pname uint32, params *uint64) {
+	C.glowGetQueryObjectui64v(gpGetQueryObjectui64v, (C.GLuint)(id), (C.GLenum)(pname), (*C.GLuint64)(unsafe.Pointer(params)))
+}
 
 // return parameters of a query object target
 func GetQueryiv(target uint32, pname uint32, params *int32) {
@@ -1036,6 +1259,12 @@ func GetString(name uint32) *uint8 {
 	return (*uint8)(ret)
 }
 
+// retrieve the index of a named uniform block
+func GetUniformBlockIndex(program uint32, uniformBlockName *uint8) uint32 {
+	ret := C.glowGetUniformBlockIndex(gpGetUniformBlockIndex, (C.GLuint)(program), (*C.GLchar)(unsafe.Pointer(uniformBlockName)))
+	return (uint32)(ret)
+}
+
 // Returns the location of a uniform variable
 func GetUniformLocation(program uint32, name *uint8) int32 {
 	ret := C.glowGetUniformLocation(gpGetUniformLocation, (C.GLuint)(program), (*C.GLchar)(unsafe.Pointer(name)))
@@ -1047,6 +1276,31 @@ func LinkProgram(program uint32) {
 	C.glowLinkProgram(gpLinkProgram, (C.GLuint)(program))
 }
 
+// render multiple sets of primitives from array data
+func MultiDrawArrays(mode uint32, first *int32, count *int32, drawcount int32) {
+	C.glowMultiDrawArrays(gpMultiDrawArrays, (C.GLenum)(mode), (*C.GLint)(unsafe.Pointer(first)), (*C.GLsizei)(unsafe.Pointer(count)), (C.GLsizei)(drawcount))
+}
+
+// render multiple sets of primitives by specifying indices of array data elements
+func MultiDrawElements(mode uint32, count *int32, xtype uint32, indices *unsafe.Pointer, drawcount int32) {
+	C.glowMultiDrawElements(gpMultiDrawElements, (C.GLenum)(mode), (*C.GLsizei)(unsafe.Pointer(count)), (C.GLenum)(xtype), indices, (C.GLsizei)(drawcount))
+}
+
+// select a polygon rasterization mode
+func PolygonMode(face uint32, mode uint32) {
+	C.glowPolygonMode(gpPolygonMode, (C.GLenum)(face), (C.GLenum)(mode))
+}
+
+// set the scale and units used to calculate depth values
+func PolygonOffset(factor float32, units float32) {
+	C.glowPolygonOffset(gpPolygonOffset, (C.GLfloat)(factor), (C.GLfloat)(units))
+}
+
+// specify the primitive restart index
+func PrimitiveRestartIndex(index uint32) {
+	C.glowPrimitiveRestartIndex(gpPrimitiveRestartIndex, (C.GLuint)(index))
+}
+
 // read a block of pixels from the frame buffer
 func ReadPixels(x int32, y int32, width int32, height int32, format uint32, xtype uint32, pixels unsafe.Pointer) {
 	C.glowReadPixels(gpReadPixels, (C.GLint)(x), (C.GLint)(y), (C.GLsizei)(width), (C.GLsizei)(height), (C.GLenum)(format), (C.GLenum)(xtype), pixels)
@@ -1062,11 +1316,21 @@ func Scissor(x int32, y int32, width int32, height int32) {
 	C.glowScissor(gpScissor, (C.GLint)(x), (C.GLint)(y), (C.GLsizei)(width), (C.GLsizei)(height))
 }
 
+// load pre-compiled shader binaries
+func ShaderBinary(count int32, shaders *uint32, binaryformat uint32, binary unsafe.Pointer, length int32) {
+	C.glowShaderBinary(gpShaderBinary, (C.GLsizei)(count), (*C.GLuint)(unsafe.Pointer(shaders)), (C.GLenum)(binaryformat), binary, (C.GLsizei)(length))
+}
+
 // Replaces the source code in a shader object
 func ShaderSource(shader uint32, count int32, xstring **uint8, length *int32) {
 	C.glowShaderSource(gpShaderSource, (C.GLuint)(shader), (C.GLsizei)(count), (**C.GLchar)(unsafe.Pointer(xstring)), (*C.GLint)(unsafe.Pointer(length)))
 }
 
+// specialize a SPIR-V shader
+func SpecializeShaderARB(shader uint32, pEntryPoint *uint8, numSpecializationConstants uint32, pConstantIndex *uint32, pConstantValue *uint32) {
+	C.glowSpecializeShaderARB(gpSpecializeShaderARB, (C.GLuint)(shader), (*C.GLchar)(unsafe.Pointer(pEntryPoint)), (C.GLuint)(numSpecializationConstants), (*C.GLuint)(unsafe.Pointer(pConstantIndex)), (*C.GLuint)(unsafe.Pointer(pConstantValue)))
+}
+
 // set front and/or back function and reference value for stencil testing
 func StencilFuncSeparate(face uint32, xfunc uint32, ref int32, mask uint32) {
 	C.glowStencilFuncSeparate(gpStencilFuncSeparate, (C.GLenum)(face), (C.GLenum)(xfunc), (C.GLint)(ref), (C.GLuint)(mask))
@@ -1092,6 +1356,14 @@ func TexParameterfv(target uint32, pname uint32, params *float32) {
 func TexParameteri(target uint32, pname uint32, param int32) {
 	C.glowTexParameteri(gpTexParameteri, (C.GLenum)(target), (C.GLenum)(pname), (C.GLint)(param))
 }
+func TexParameteriv(target uint32, pname uint32, params *int32) {
+	C.glowTexParameteriv(gpTexParameteriv, (C.GLenum)(target), (C.GLenum)(pname), (*C.GLint)(unsafe.Pointer(params)))
+}
+
+// specify a two-dimensional texture subimage
+func TexSubImage2D(target uint32, level int32, xoffset int32, yoffset int32, width int32, height int32, format uint32, xtype uint32, pixels unsafe.Pointer) {
+	C.glowTexSubImage2D(gpTexSubImage2D, (C.GLenum)(target), (C.GLint)(level), (C.GLint)(xoffset), (C.GLint)(yoffset), (C.GLsizei)(width), (C.GLsizei)(height), (C.GLenum)(format), (C.GLenum)(xtype), pixels)
+}
 
 // Specify the value of a uniform variable for the current program object
 func Uniform1fv(location int32, count int32, value *float32) {
@@ -1123,6 +1395,11 @@ func Uniform4fv(location int32, count int32, value *float32) {
 	C.glowUniform4fv(gpUniform4fv, (C.GLint)(location), (C.GLsizei)(count), (*C.GLfloat)(unsafe.Pointer(value)))
 }
 
+// assign a binding point to an active uniform block
+func UniformBlockBinding(program uint32, uniformBlockIndex uint32, uniformBlockBinding uint32) {
+	C.glowUniformBlockBinding(gpUniformBlockBinding, (C.GLuint)(program), (C.GLuint)(uniformBlockIndex), (C.GLuint)(uniformBlockBinding))
+}
+
 // Specify the value of a uniform variable for the current program object
 func UniformMatrix4fv(location int32, count int32, transpose bool, value *float32) {
 	C.glowUniformMatrix4fv(gpUniformMatrix4fv, (C.GLint)(location), (C.GLsizei)(count), (C.GLboolean)(boolToInt(transpose)), (*C.GLfloat)(unsafe.Pointer(value)))
@@ -1166,6 +1443,7 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 	if gpBindBuffer == nil {
 		return errors.New("glBindBuffer")
 	}
+	gpBindBufferBase = (C.GPBINDBUFFERBASE)(getProcAddr("glBindBufferBase"))
 	gpBindFramebuffer = (C.GPBINDFRAMEBUFFER)(getProcAddr("glBindFramebuffer"))
 	gpBindRenderbuffer = (C.GPBINDRENDERBUFFER)(getProcAddr("glBindRenderbuffer"))
 	gpBindTexture = (C.GPBINDTEXTURE)(getProcAddr("glBindTexture"))
@@ -1184,10 +1462,15 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 	if gpBlendFuncSeparate == nil {
 		return errors.New("glBlendFuncSeparate")
 	}
+	gpBlitFramebuffer = (C.GPBLITFRAMEBUFFER)(getProcAddr("glBlitFramebuffer"))
 	gpBufferData = (C.GPBUFFERDATA)(getProcAddr("glBufferData"))
 	if gpBufferData == nil {
 		return errors.New("glBufferData")
 	}
+	gpBufferSubData = (C.GPBUFFERSUBDATA)(getProcAddr("glBufferSubData"))
+	if gpBufferSubData == nil {
+		return errors.New("glBufferSubData")
+	}
 	gpCheckFramebufferStatus = (C.GPCHECKFRAMEBUFFERSTATUS)(getProcAddr("glCheckFramebufferStatus"))
 	gpClear = (C.GPCLEAR)(getProcAddr("glClear"))
 	if gpClear == nil {
@@ -1206,6 +1489,7 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 	if gpClearStencil == nil {
 		return errors.New("glClearStencil")
 	}
+	gpClientWaitSync = (C.GPCLIENTWAITSYNC)(getProcAddr("glClientWaitSync"))
 	gpColorMask = (C.GPCOLORMASK)(getProcAddr("glColorMask"))
 	if gpColorMask == nil {
 		return errors.New("glColorMask")
@@ -1245,6 +1529,7 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 	if gpDeleteShader == nil {
 		return errors.New("glDeleteShader")
 	}
+	gpDeleteSync = (C.GPDELETESYNC)(getProcAddr("glDeleteSync"))
 	gpDeleteTextures = (C.GPDELETETEXTURES)(getProcAddr("glDeleteTextures"))
 	if gpDeleteTextures == nil {
 		return errors.New("glDeleteTextures")
@@ -1257,6 +1542,10 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 	if gpDepthMask == nil {
 		return errors.New("glDepthMask")
 	}
+	gpDepthRange = (C.GPDEPTHRANGE)(getProcAddr("glDepthRange"))
+	if gpDepthRange == nil {
+		return errors.New("glDepthRange")
+	}
 	gpDisable = (C.GPDISABLE)(getProcAddr("glDisable"))
 	if gpDisable == nil {
 		return errors.New("glDisable")
@@ -1285,6 +1574,7 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 	if gpEndQuery == nil {
 		return errors.New("glEndQuery")
 	}
+	gpFenceSync = (C.GPFENCESYNC)(getProcAddr("glFenceSync"))
 	gpFinish = (C.GPFINISH)(getProcAddr("glFinish"))
 	if gpFinish == nil {
 		return errors.New("glFinish")
@@ -1295,6 +1585,10 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 	}
 	gpFramebufferRenderbuffer = (C.GPFRAMEBUFFERRENDERBUFFER)(getProcAddr("glFramebufferRenderbuffer"))
 	gpFramebufferTexture2D = (C.GPFRAMEBUFFERTEXTURE2D)(getProcAddr("glFramebufferTexture2D"))
+	gpFrontFace = (C.GPFRONTFACE)(getProcAddr("glFrontFace"))
+	if gpFrontFace == nil {
+		return errors.New("glFrontFace")
+	}
 	gpGenBuffers = (C.GPGENBUFFERS)(getProcAddr("glGenBuffers"))
 	if gpGenBuffers == nil {
 		return errors.New("glGenBuffers")
@@ -1310,6 +1604,14 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 		return errors.New("glGenTextures")
 	}
 	gpGenerateMipmap = (C.GPGENERATEMIPMAP)(getProcAddr("glGenerateMipmap"))
+	gpGetActiveAttrib = (C.GPGETACTIVEATTRIB)(getProcAddr("glGetActiveAttrib"))
+	if gpGetActiveAttrib == nil {
+		return errors.New("glGetActiveAttrib")
+	}
+	gpGetActiveUniform = (C.GPGETACTIVEUNIFORM)(getProcAddr("glGetActiveUniform"))
+	if gpGetActiveUniform == nil {
+		return errors.New("glGetActiveUniform")
+	}
 	gpGetAttribLocation = (C.GPGETATTRIBLOCATION)(getProcAddr("glGetAttribLocation"))
 	if gpGetAttribLocation == nil {
 		return errors.New("glGetAttribLocation")
@@ -1346,6 +1648,7 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 	if gpGetQueryObjectiv == nil {
 		return errors.New("glGetQueryObjectiv")
 	}
+	gpGetQueryObjectui64v = (C.GPGETQUERYOBJECTUI64V)(getProcAddr("glGetQueryObjectui64v"))
 	gpGetQueryiv = (C.GPGETQUERYIV)(getProcAddr("glGetQueryiv"))
 	if gpGetQueryiv == nil {
 		return errors.New("glGetQueryiv")
@@ -1362,6 +1665,7 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 	if gpGetString == nil {
 		return errors.New("glGetString")
 	}
+	gpGetUniformBlockIndex = (C.GPGETUNIFORMBLOCKINDEX)(getProcAddr("glGetUniformBlockIndex"))
 	gpGetUniformLocation = (C.GPGETUNIFORMLOCATION)(getProcAddr("glGetUniformLocation"))
 	if gpGetUniformLocation == nil {
 		return errors.New("glGetUniformLocation")
@@ -1370,6 +1674,17 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 	if gpLinkProgram == nil {
 		return errors.New("glLinkProgram")
 	}
+	gpMultiDrawArrays = (C.GPMULTIDRAWARRAYS)(getProcAddr("glMultiDrawArrays"))
+	gpMultiDrawElements = (C.GPMULTIDRAWELEMENTS)(getProcAddr("glMultiDrawElements"))
+	gpPolygonMode = (C.GPPOLYGONMODE)(getProcAddr("glPolygonMode"))
+	if gpPolygonMode == nil {
+		return errors.New("glPolygonMode")
+	}
+	gpPolygonOffset = (C.GPPOLYGONOFFSET)(getProcAddr("glPolygonOffset"))
+	if gpPolygonOffset == nil {
+		return errors.New("glPolygonOffset")
+	}
+	gpPrimitiveRestartIndex = (C.GPPRIMITIVERESTARTINDEX)(getProcAddr("glPrimitiveRestartIndex"))
 	gpReadPixels = (C.GPREADPIXELS)(getProcAddr("glReadPixels"))
 	if gpReadPixels == nil {
 		return errors.New("glReadPixels")
@@ -1379,10 +1694,12 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 	if gpScissor == nil {
 		return errors.New("glScissor")
 	}
+	gpShaderBinary = (C.GPSHADERBINARY)(getProcAddr("glShaderBinary"))
 	gpShaderSource = (C.GPSHADERSOURCE)(getProcAddr("glShaderSource"))
 	if gpShaderSource == nil {
 		return errors.New("glShaderSource")
 	}
+	gpSpecializeShaderARB = (C.GPSPECIALIZESHADERARB)(getProcAddr("glSpecializeShaderARB"))
 	gpStencilFuncSeparate = (C.GPSTENCILFUNCSEPARATE)(getProcAddr("glStencilFuncSeparate"))
 	if gpStencilFuncSeparate == nil {
 		return errors.New("glStencilFuncSeparate")
@@ -1407,6 +1724,14 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 	if gpTexParameteri == nil {
 		return errors.New("glTexParameteri")
 	}
+	gpTexParameteriv = (C.GPTEXPARAMETERIV)(getProcAddr("glTexParameteriv"))
+	if gpTexParameteriv == nil {
+		return errors.New("glTexParameteriv")
+	}
+	gpTexSubImage2D = (C.GPTEXSUBIMAGE2D)(getProcAddr("glTexSubImage2D"))
+	if gpTexSubImage2D == nil {
+		return errors.New("glTexSubImage2D")
+	}
 	gpUniform1fv = (C.GPUNIFORM1FV)(getProcAddr("glUniform1fv"))
 	if gpUniform1fv == nil {
 		return errors.New("glUniform1fv")
@@ -1431,6 +1756,7 @@ func InitWithProcAddrFunc(getProcAddr func(name string) unsafe.Pointer) error {
 	if gpUniform4fv == nil {
 		return errors.New("glUniform4fv")
 	}
+	gpUniformBlockBinding = (C.GPUNIFORMBLOCKBINDING)(getProcAddr("glUniformBlockBinding"))
 	gpUniformMatrix4fv = (C.GPUNIFORMMATRIX4FV)(getProcAddr("glUniformMatrix4fv"))
 	if gpUniformMatrix4fv == nil {
 		return errors.New("glUniformMatrix4fv")
@@ -17,6 +17,26 @@ type LocationCache struct {
 	uniforms []location
 }
 
+// LocationMap returns a function which looks up the location of the given name
+// in the map, returning -1 if it is not present. It is used in place of the
+// GetAttribLocation and GetUniformLocation functions for shaders whose inputs
+// are bound by explicit location rather than by name (i.e. SPIR-V shaders).
+//
+// The map is copied, such that later changes to it have no effect.
+func LocationMap(m map[string]int) func(name string) int {
+	cpy := make(map[string]int, len(m))
+	for name, loc := range m {
+		cpy[name] = loc
+	}
+	return func(name string) int {
+		loc, ok := cpy[name]
+		if !ok {
+			return -1
+		}
+		return loc
+	}
+}
+
 // FindAttrib finds the named attribute location in the cache, if it's not in
 // the cache it is queried from l.GetAttribLocation directly and cached for
 // later.
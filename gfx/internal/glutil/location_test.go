@@ -0,0 +1,31 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glutil
+
+import "testing"
+
+func TestLocationMap(t *testing.T) {
+	attribs := map[string]int{"Vertex": 0, "TexCoord0": 2}
+	l := &LocationCache{
+		GetAttribLocation:  LocationMap(attribs),
+		GetUniformLocation: LocationMap(nil),
+	}
+
+	// Later changes to the map have no effect.
+	attribs["Vertex"] = 5
+
+	if loc := l.FindAttrib("Vertex"); loc != 0 {
+		t.Fatal("got Vertex location", loc, "want 0")
+	}
+	if loc := l.FindAttrib("TexCoord0"); loc != 2 {
+		t.Fatal("got TexCoord0 location", loc, "want 2")
+	}
+	if loc := l.FindAttrib("Color"); loc != -1 {
+		t.Fatal("got Color location", loc, "want -1")
+	}
+	if loc := l.FindUniform("MVP"); loc != -1 {
+		t.Fatal("got MVP location", loc, "want -1")
+	}
+}
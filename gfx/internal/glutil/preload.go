@@ -14,7 +14,10 @@ import (
 // PreLoadShader implements the precusor to gfx.Canvas.LoadShader; it returns a
 // boolean value whether or not loading of the given shader should continue,
 // and a (warning) error, if any.
-func PreLoadShader(s *gfx.Shader, done chan *gfx.Shader) (doLoad bool, err error) {
+//
+// The spirv parameter specifies whether or not the device supports loading
+// SPIR-V binaries, see UseSPIRV.
+func PreLoadShader(s *gfx.Shader, done chan *gfx.Shader, spirv bool) (doLoad bool, err error) {
 	// signal is used to signal completion to the done channel in a non
 	// blocking way.
 	signal := func() {
@@ -24,6 +27,15 @@ func PreLoadShader(s *gfx.Shader, done chan *gfx.Shader) (doLoad bool, err error
 		}
 	}
 
+	// If the shader's sources have changed then it must be reloaded, even if
+	// there was previously an error loading it. The device is responsible for
+	// freeing the old shader program (s.NativeShader), if any.
+	if s.Changed {
+		s.Changed = false
+		s.Loaded = false
+		s.Error = s.Error[:0]
+	}
+
 	// If the shader is already loaded or there was previously an error loading
 	// it then signal completion and perform no further loading.
 	if s.Loaded || len(s.Error) > 0 {
@@ -31,16 +43,25 @@ func PreLoadShader(s *gfx.Shader, done chan *gfx.Shader) (doLoad bool, err error
 		return false, nil
 	}
 
+	// If the SPIR-V binaries will be loaded, the GLSL sources are not needed.
+	if UseSPIRV(s, spirv) {
+		return true, nil
+	}
+
 	// A vertex or fragment shader with no code at all causes an undefined
 	// behavior and can cause some drivers to crash. It is an error and as such
 	// no further loading of the shader should occur.
-	if len(strings.TrimSpace(string(s.GLSL.Vertex))) == 0 {
+	glsl := s.GLSL
+	if glsl == nil {
+		glsl = &gfx.GLSLSources{}
+	}
+	if len(strings.TrimSpace(string(glsl.Vertex))) == 0 {
 		err = fmt.Errorf("%s | Vertex shader with no source code.", s.Name)
 		s.Error = append(s.Error, []byte(err.Error())...)
 		signal()
 		return false, err
 	}
-	if len(strings.TrimSpace(string(s.GLSL.Fragment))) == 0 {
+	if len(strings.TrimSpace(string(glsl.Fragment))) == 0 {
 		err = fmt.Errorf("%s | Fragment shader with no source code.", s.Name)
 		s.Error = append(s.Error, []byte(err.Error())...)
 		signal()
@@ -48,3 +69,10 @@ func PreLoadShader(s *gfx.Shader, done chan *gfx.Shader) (doLoad bool, err error
 	}
 	return true, nil
 }
+
+// UseSPIRV tells whether or not the SPIR-V binaries of the given shader should
+// be loaded instead of it's GLSL sources, given whether or not the device
+// supports SPIR-V at all.
+func UseSPIRV(s *gfx.Shader, supported bool) bool {
+	return supported && s.SPIRV != nil && len(s.SPIRV.Vertex) > 0 && len(s.SPIRV.Fragment) > 0
+}
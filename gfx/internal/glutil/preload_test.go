@@ -0,0 +1,54 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glutil
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+func TestPreLoadShaderChanged(t *testing.T) {
+	s := gfx.NewShader("test")
+	s.GLSL = &gfx.GLSLSources{
+		Vertex:   []byte("void main() {}"),
+		Fragment: []byte("void main() {}"),
+	}
+	s.Error = []byte("previous compiler error")
+
+	// A shader with a previous error should not be loaded again.
+	doLoad, err := PreLoadShader(s, nil, false)
+	if doLoad || err != nil {
+		t.Fatalf("got doLoad=%v err=%v, want doLoad=false err=nil", doLoad, err)
+	}
+
+	// Unless it's sources have changed.
+	s.Changed = true
+	doLoad, err = PreLoadShader(s, nil, false)
+	if !doLoad || err != nil {
+		t.Fatalf("got doLoad=%v err=%v, want doLoad=true err=nil", doLoad, err)
+	}
+	if s.Changed || len(s.Error) > 0 {
+		t.Fatalf("got Changed=%v Error=%q, want Changed=false and no error", s.Changed, s.Error)
+	}
+}
+
+func TestPreLoadShaderSPIRV(t *testing.T) {
+	s := gfx.NewShader("test")
+	s.SPIRV = &gfx.SPIRVSources{
+		Vertex:   []byte{0x03, 0x02, 0x23, 0x07},
+		Fragment: []byte{0x03, 0x02, 0x23, 0x07},
+	}
+
+	// Without GLSL sources the shader may only be loaded from SPIR-V.
+	doLoad, err := PreLoadShader(s, nil, true)
+	if !doLoad || err != nil {
+		t.Fatalf("got doLoad=%v err=%v, want doLoad=true err=nil", doLoad, err)
+	}
+	doLoad, err = PreLoadShader(s, nil, false)
+	if doLoad || err == nil {
+		t.Fatalf("got doLoad=%v err=%v, want doLoad=false and an error", doLoad, err)
+	}
+}
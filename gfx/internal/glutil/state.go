@@ -34,21 +34,24 @@ var DefaultBlendState = gfx.BlendState{
 }
 
 var DefaultState = &gfx.State{
-	gfx.NoAlpha,         // AlphaMode
-	DefaultBlendState,   // Blend
-	true,                // WriteRed
-	true,                // WriteGreen
-	true,                // WriteBlue
-	true,                // WriteAlpha
-	true,                // Dithering
-	false,               // DepthClamp
-	false,               // DepthTest
-	true,                // DepthWrite
-	gfx.Less,            // DepthCmp
-	false,               // StencilTest
-	gfx.NoFaceCulling,   // FaceCulling
-	DefaultStencilState, // StencilFront
-	DefaultStencilState, // StencilBack
+	gfx.NoAlpha,          // AlphaMode
+	DefaultBlendState,    // Blend
+	true,                 // WriteRed
+	true,                 // WriteGreen
+	true,                 // WriteBlue
+	true,                 // WriteAlpha
+	true,                 // Dithering
+	false,                // DepthClamp
+	false,                // DepthTest
+	true,                 // DepthWrite
+	gfx.Less,             // DepthCmp
+	gfx.DepthBias{},      // DepthBias
+	false,                // StencilTest
+	gfx.NoFaceCulling,    // FaceCulling
+	gfx.CounterClockwise, // FrontFace
+	DefaultStencilState,  // StencilFront
+	DefaultStencilState,  // StencilBack
+	nil,                  // ScissorRect
 }
 
 // CommonState represents a set of common OpenGL state properties not covered by gfx.State.
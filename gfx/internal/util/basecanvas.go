@@ -16,13 +16,23 @@ import (
 //
 //	SetMSAA
 //	MSAA
+//	SetAutoClear
+//	AutoClear
+//	SetClearColor
+//	ClearColor
 //	Precision
 //	Bounds
 type BaseCanvas struct {
 	sync.RWMutex
-	VMSAA      bool            // The MSAA state.
-	VPrecision gfx.Precision   // The precision of this canvas.
-	VBounds    image.Rectangle // The bounding rectangle of this canvas.
+	VMSAA       bool            // The MSAA state.
+	VAutoClear  bool            // The auto-clear state.
+	VClearColor gfx.Color       // The color to auto-clear to.
+	VClearDepth float64         // The depth to auto-clear to.
+	VPrecision  gfx.Precision   // The precision of this canvas.
+	VBounds     image.Rectangle // The bounding rectangle of this canvas.
+
+	// Whether or not drawing of the current frame has begun, see BeginFrame.
+	frameBegun bool
 }
 
 // Implements gfx.Canvas interface.
@@ -40,6 +50,57 @@ func (c *BaseCanvas) MSAA() bool {
 	return msaa
 }
 
+// Implements gfx.Canvas interface.
+func (c *BaseCanvas) SetAutoClear(enabled bool) {
+	c.Lock()
+	c.VAutoClear = enabled
+	c.Unlock()
+}
+
+// Implements gfx.Canvas interface.
+func (c *BaseCanvas) AutoClear() bool {
+	c.RLock()
+	enabled := c.VAutoClear
+	c.RUnlock()
+	return enabled
+}
+
+// Implements gfx.Canvas interface.
+func (c *BaseCanvas) SetClearColor(bg gfx.Color, depth float64) {
+	c.Lock()
+	c.VClearColor = bg
+	c.VClearDepth = depth
+	c.Unlock()
+}
+
+// Implements gfx.Canvas interface.
+func (c *BaseCanvas) ClearColor() (bg gfx.Color, depth float64) {
+	c.RLock()
+	bg, depth = c.VClearColor, c.VClearDepth
+	c.RUnlock()
+	return
+}
+
+// BeginFrame marks drawing of the current frame as having begun. It returns
+// clear=true along with the color and depth to clear to, if auto-clearing is
+// enabled and drawing of the frame had not already begun.
+func (c *BaseCanvas) BeginFrame() (clear bool, bg gfx.Color, depth float64) {
+	c.Lock()
+	clear = c.VAutoClear && !c.frameBegun
+	c.frameBegun = true
+	bg, depth = c.VClearColor, c.VClearDepth
+	c.Unlock()
+	return
+}
+
+// EndFrame marks the current frame as rendered, such that the next call to
+// BeginFrame begins a new frame.
+func (c *BaseCanvas) EndFrame() {
+	c.Lock()
+	c.frameBegun = false
+	c.Unlock()
+}
+
 // Implements gfx.Canvas interface.
 func (c *BaseCanvas) Precision() gfx.Precision {
 	c.RLock()
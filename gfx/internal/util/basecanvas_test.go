@@ -0,0 +1,36 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+func TestBaseCanvasBeginFrame(t *testing.T) {
+	c := &BaseCanvas{VClearDepth: 1.0}
+
+	// Auto-clearing is disabled by default.
+	if clear, _, _ := c.BeginFrame(); clear {
+		t.Fatal("expected no auto-clear by default")
+	}
+	c.EndFrame()
+
+	red := gfx.Color{R: 1, A: 1}
+	c.SetAutoClear(true)
+	c.SetClearColor(red, 0.5)
+	for frame := 0; frame < 3; frame++ {
+		// Only the first operation of each frame clears.
+		clear, bg, depth := c.BeginFrame()
+		if !clear || bg != red || depth != 0.5 {
+			t.Fatal("frame", frame, "got", clear, bg, depth)
+		}
+		if clear, _, _ := c.BeginFrame(); clear {
+			t.Fatal("frame", frame, "cleared twice")
+		}
+		c.EndFrame()
+	}
+}
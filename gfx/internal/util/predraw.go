@@ -5,6 +5,7 @@
 package util
 
 import (
+	"context"
 	"errors"
 	"image"
 
@@ -14,10 +15,14 @@ import (
 var (
 	ErrNilState    = errors.New("Draw: gfx.State is nil (ignoring object)")
 	ErrNilShader   = errors.New("Draw: gfx.Shader is nil (ignoring object)")
-	ErrNilSource   = errors.New("Draw: gfx.Texture has a nil Source image (ignoring object)")
+	ErrNilSource   = errors.New("Draw: gfx.Texture has a nil Source image and no Raw data (ignoring object)")
 	ErrNoVertices  = errors.New("Draw: gfx.Mesh has no vertices (ignoring object)")
 	ErrNoMeshes    = errors.New("Draw: gfx.Object has no meshes (ignoring object)")
 	ErrShaderError = errors.New("Draw: gfx.Shader has a compiler error (ignoring object)")
+
+	ErrTooManyTextures = errors.New("Draw: gfx.Object has more textures than the device has texture units (ignoring object)")
+
+	ErrDestroyed = errors.New("Draw: device was destroyed while loading the object (ignoring object)")
 )
 
 // PreDraw performs the commonplace tasks that occur before each object is
@@ -28,6 +33,8 @@ var (
 // It will return draw=false, err == nil in the following cases:
 //
 //	rect.Empty() == true
+//	o.Hidden == true
+//	o.State.ScissorRect != nil && rect.Intersect(*o.State.ScissorRect).Empty()
 //	o.Shader != nil && len(o.Shader.Error) > 0
 //
 // It may return the following errors:
@@ -38,6 +45,9 @@ var (
 //	ErrNoVertices
 //	ErrNoMeshes
 //	ErrShaderError
+//	ErrTooManyTextures
+//	ErrDestroyed
+//	ctx.Err()
 //
 // If draw == true && err == nil, then it will:
 //
@@ -47,12 +57,23 @@ var (
 //
 // Ask the given device to load each shader, mesh, and texture that the object
 // has associated with it and waits for loading to complete before returning.
-func PreDraw(dev gfx.Device, rect image.Rectangle, o *gfx.Object, c gfx.Camera) (draw bool, err error) {
+// If the context is done first, it stops waiting and returns ctx.Err(). If the
+// done channel is closed first (i.e. the device was destroyed), it stops
+// waiting and returns ErrDestroyed. A nil done channel waits forever.
+//
+// The device's load methods must not block once the done channel is closed,
+// or else PreDraw blocks as well.
+func PreDraw(ctx context.Context, dev gfx.Device, rect image.Rectangle, o *gfx.Object, c gfx.Camera, done <-chan struct{}) (draw bool, err error) {
 	// Draw calls with empty rectangles are effectively no-op.
 	if rect.Empty() {
 		return false, nil
 	}
 
+	// Hidden objects are not drawn.
+	if o.Hidden {
+		return false, nil
+	}
+
 	// Make the implicit o.Bounds() call required by gfx.Canvas.
 	o.Bounds()
 
@@ -60,25 +81,39 @@ func PreDraw(dev gfx.Device, rect image.Rectangle, o *gfx.Object, c gfx.Camera)
 	if o.State == nil {
 		return false, ErrNilState
 	}
+
+	// Objects entirely outside of their scissor rectangle are not drawn.
+	if o.State.ScissorRect != nil && rect.Intersect(*o.State.ScissorRect).Empty() {
+		return false, nil
+	}
 	if o.Shader == nil {
 		return false, ErrNilShader
 	}
-	if len(o.Shader.Error) > 0 {
+	if len(o.Shader.Error) > 0 && !o.Shader.HasChanged() {
 		return false, ErrShaderError
 	}
 	if len(o.Meshes) == 0 {
 		return false, ErrNoMeshes
 	}
+	if max := dev.Info().MaxTextureImageUnits; max > 0 && len(o.Textures) > max {
+		return false, ErrTooManyTextures
+	}
 
 	// Load all of the objects resources.
 	var (
 		meshLoad    chan *gfx.Mesh
 		textureLoad chan *gfx.Texture
 	)
-	if !o.Shader.Loaded {
+	if !o.Shader.Loaded || o.Shader.HasChanged() {
 		shaderLoad := make(chan *gfx.Shader, 1)
 		dev.LoadShader(o.Shader, shaderLoad)
-		<-shaderLoad
+		select {
+		case <-shaderLoad:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-done:
+			return false, ErrDestroyed
+		}
 	}
 	for _, m := range o.Meshes {
 		if m.Loaded && !m.HasChanged() {
@@ -91,20 +126,32 @@ func PreDraw(dev gfx.Device, rect image.Rectangle, o *gfx.Object, c gfx.Camera)
 			meshLoad = make(chan *gfx.Mesh, 1)
 		}
 		dev.LoadMesh(m, meshLoad)
-		<-meshLoad
+		select {
+		case <-meshLoad:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-done:
+			return false, ErrDestroyed
+		}
 	}
 	for _, t := range o.Textures {
 		if t.Loaded {
 			continue
 		}
-		if t.Source == nil {
+		if !t.HasData() {
 			return false, ErrNilSource
 		}
 		if textureLoad == nil {
 			textureLoad = make(chan *gfx.Texture, 1)
 		}
 		dev.LoadTexture(t, textureLoad)
-		<-textureLoad
+		select {
+		case <-textureLoad:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-done:
+			return false, ErrDestroyed
+		}
 	}
 
 	// Check the now-loaded shader for errors.
@@ -0,0 +1,189 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package util
+
+import (
+	"context"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+func TestPreDrawHidden(t *testing.T) {
+	var (
+		dev  = gfx.Nil()
+		rect = image.Rect(0, 0, 1, 1)
+		o    = gfx.NewObject()
+	)
+	if draw, err := PreDraw(context.Background(), dev, rect, o, nil, nil); draw || err != ErrNilState {
+		t.Fatal("got", draw, err, "want", false, ErrNilState)
+	}
+
+	// Hidden objects are skipped before any validity checks.
+	o.Hidden = true
+	if draw, err := PreDraw(context.Background(), dev, rect, o, nil, nil); draw || err != nil {
+		t.Fatal("got", draw, err, "want", false, nil)
+	}
+}
+
+func TestPreDrawScissorRect(t *testing.T) {
+	var (
+		dev  = gfx.Nil()
+		rect = image.Rect(0, 0, 4, 4)
+		o    = gfx.NewObject()
+	)
+	o.State = gfx.NewState()
+
+	// An object entirely outside of it's scissor rectangle is skipped.
+	o.State.ScissorRect = &image.Rectangle{Min: image.Pt(4, 0), Max: image.Pt(8, 4)}
+	if draw, err := PreDraw(context.Background(), dev, rect, o, nil, nil); draw || err != nil {
+		t.Fatal("got", draw, err, "want", false, nil)
+	}
+
+	// An overlapping scissor rectangle proceeds to the validity checks.
+	o.State.ScissorRect.Min.X = 2
+	if draw, err := PreDraw(context.Background(), dev, rect, o, nil, nil); draw || err != ErrNilShader {
+		t.Fatal("got", draw, err, "want", false, ErrNilShader)
+	}
+}
+
+// loadCountDevice counts the number of times each mesh is loaded.
+type loadCountDevice struct {
+	gfx.Device
+	meshLoads map[*gfx.Mesh]int
+}
+
+func (d *loadCountDevice) LoadMesh(m *gfx.Mesh, done chan *gfx.Mesh) {
+	d.meshLoads[m]++
+	d.Device.LoadMesh(m, done)
+}
+
+func TestPreDrawSharedMesh(t *testing.T) {
+	var (
+		dev    = &loadCountDevice{Device: gfx.Nil(), meshLoads: make(map[*gfx.Mesh]int)}
+		rect   = image.Rect(0, 0, 1, 1)
+		shared = gfx.NewMesh()
+	)
+	shared.Vertices = []gfx.Vec3{{}, {X: 1}, {Y: 1}}
+
+	// Two objects with independent state share a single mesh.
+	var objs [2]*gfx.Object
+	for i := range objs {
+		o := gfx.NewObject()
+		o.State = gfx.NewState()
+		o.Shader = gfx.NewShader("shared")
+		o.Meshes = []*gfx.Mesh{shared}
+		objs[i] = o
+	}
+	objs[1].State.FaceCulling = gfx.NoFaceCulling
+
+	for i, o := range objs {
+		if draw, err := PreDraw(context.Background(), dev, rect, o, nil, nil); !draw || err != nil {
+			t.Fatal(i, "got", draw, err, "want", true, nil)
+		}
+	}
+	if n := dev.meshLoads[shared]; n != 1 {
+		t.Fatal("shared mesh loaded", n, "times, want once")
+	}
+}
+
+// stuckDevice is a device which never completes loading a mesh.
+type stuckDevice struct {
+	gfx.Device
+}
+
+func (d stuckDevice) LoadMesh(m *gfx.Mesh, done chan *gfx.Mesh) {}
+
+func TestPreDrawDestroyed(t *testing.T) {
+	var (
+		dev  = stuckDevice{Device: gfx.Nil()}
+		rect = image.Rect(0, 0, 1, 1)
+		o    = gfx.NewObject()
+	)
+	o.State = gfx.NewState()
+	o.Shader = gfx.NewShader("stuck")
+	m := gfx.NewMesh()
+	m.Vertices = []gfx.Vec3{{}, {X: 1}, {Y: 1}}
+	o.Meshes = []*gfx.Mesh{m}
+
+	// The device is destroyed while waiting for the mesh to load.
+	done := make(chan struct{})
+	close(done)
+	if draw, err := PreDraw(context.Background(), dev, rect, o, nil, done); draw || err != ErrDestroyed {
+		t.Fatal("got", draw, err, "want", false, ErrDestroyed)
+	}
+}
+
+func TestPreDrawContext(t *testing.T) {
+	var (
+		dev  = stuckDevice{Device: gfx.Nil()}
+		rect = image.Rect(0, 0, 1, 1)
+		o    = gfx.NewObject()
+	)
+	o.State = gfx.NewState()
+	o.Shader = gfx.NewShader("stuck")
+	m := gfx.NewMesh()
+	m.Vertices = []gfx.Vec3{{}, {X: 1}, {Y: 1}}
+	o.Meshes = []*gfx.Mesh{m}
+
+	// The context times out while waiting for the mesh to load.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if draw, err := PreDraw(ctx, dev, rect, o, nil, make(chan struct{})); draw || err != context.DeadlineExceeded {
+		t.Fatal("got", draw, err, "want", false, context.DeadlineExceeded)
+	}
+}
+
+// unitsDevice is a device with the given number of texture units.
+type unitsDevice struct {
+	gfx.Device
+	units int
+}
+
+func (d unitsDevice) Info() gfx.DeviceInfo {
+	info := d.Device.Info()
+	info.MaxTextureImageUnits = d.units
+	return info
+}
+
+func TestPreDrawTooManyTextures(t *testing.T) {
+	rect := image.Rect(0, 0, 1, 1)
+	object := func(textures int) *gfx.Object {
+		o := gfx.NewObject()
+		o.State = gfx.NewState()
+		o.Shader = gfx.NewShader("textured")
+		m := gfx.NewMesh()
+		m.Vertices = []gfx.Vec3{{}, {X: 1}, {Y: 1}}
+		o.Meshes = []*gfx.Mesh{m}
+		for i := 0; i < textures; i++ {
+			tex := gfx.NewTexture()
+			tex.Source = image.NewRGBA(image.Rect(0, 0, 1, 1))
+			o.Textures = append(o.Textures, tex)
+		}
+		return o
+	}
+	tests := []struct {
+		units, textures int
+		draw            bool
+		err             error
+	}{
+		{units: 2, textures: 3, draw: false, err: ErrTooManyTextures},
+		{units: 2, textures: 2, draw: true, err: nil},
+		{units: 0, textures: 3, draw: true, err: nil}, // Unknown limit.
+	}
+	for _, tst := range tests {
+		dev := unitsDevice{Device: gfx.Nil(), units: tst.units}
+		o := object(tst.textures)
+		draw, err := PreDraw(context.Background(), dev, rect, o, nil, nil)
+		if draw != tst.draw || err != tst.err {
+			t.Fatalf("%d textures, %d units: got %v %v, want %v %v", tst.textures, tst.units, draw, err, tst.draw, tst.err)
+		}
+		if tst.err != nil && o.Textures[0].Loaded {
+			t.Fatal("textures were loaded despite the error")
+		}
+	}
+}
@@ -15,11 +15,23 @@ import (
 type Swapper struct {
 	Yield chan struct{}
 	Swap  chan gfx.Device
+
+	// Wait, if non-nil, is called at the end of each call to Render. It may
+	// block until the next frame should be rendered (e.g. for windows which
+	// render on-demand).
+	Wait func()
+
 	clock *clock.Clock
 	msaa  bool
 	d     gfx.Device
 }
 
+// Device returns the current graphics device, which the swapper forwards it's
+// method calls to.
+func (s *Swapper) Device() gfx.Device {
+	return s.d
+}
+
 // Clock returns this swapper's own clock.
 func (s *Swapper) Clock() *clock.Clock {
 	return s.clock
@@ -40,6 +52,11 @@ func (s *Swapper) Info() gfx.DeviceInfo {
 	return s.d.Info()
 }
 
+// Stats returns the rendering statistics of the current graphics device.
+func (s *Swapper) Stats() gfx.Stats {
+	return s.d.Stats()
+}
+
 // Download performs a download from the current graphics device.
 func (s *Swapper) Download(r image.Rectangle, complete chan image.Image) {
 	s.d.Download(r, complete)
@@ -57,6 +74,28 @@ func (s *Swapper) MSAA() (msaa bool) {
 	return s.d.MSAA()
 }
 
+// SetAutoClear sets the auto-clear status of the current graphics device.
+func (s *Swapper) SetAutoClear(enabled bool) {
+	s.d.SetAutoClear(enabled)
+}
+
+// AutoClear gets the auto-clear status of the current graphics device.
+func (s *Swapper) AutoClear() bool {
+	return s.d.AutoClear()
+}
+
+// SetClearColor sets the auto-clear color and depth of the current graphics
+// device.
+func (s *Swapper) SetClearColor(bg gfx.Color, depth float64) {
+	s.d.SetClearColor(bg, depth)
+}
+
+// ClearColor gets the auto-clear color and depth of the current graphics
+// device.
+func (s *Swapper) ClearColor() (bg gfx.Color, depth float64) {
+	return s.d.ClearColor()
+}
+
 // Clear submits a clear operation to the current graphics device.
 func (s *Swapper) Clear(r image.Rectangle, bg gfx.Color) {
 	s.d.Clear(r, bg)
@@ -73,17 +112,36 @@ func (s *Swapper) ClearStencil(r image.Rectangle, stencil int) {
 	s.d.ClearStencil(r, stencil)
 }
 
+// ClearRects submits a batch of clear operations to the current graphics
+// device.
+func (s *Swapper) ClearRects(rects []gfx.ClearRect) {
+	s.d.ClearRects(rects)
+}
+
 // Draw submits a draw operation to the current graphics device.
 func (s *Swapper) Draw(r image.Rectangle, o *gfx.Object, c gfx.Camera) {
 	s.d.Draw(r, o, c)
 }
 
+// Blit submits a blit operation to the current graphics device.
+func (s *Swapper) Blit(dst gfx.Canvas, src, dstRect image.Rectangle, filter gfx.TexFilter) error {
+	if dst == s {
+		dst = s.d
+	}
+	return s.d.Blit(dst, src, dstRect, filter)
+}
+
 // QueryWait waits for occlusion queries to wait on the current graphics
 // device.
 func (s *Swapper) QueryWait() {
 	s.d.QueryWait()
 }
 
+// QueryPoll polls for occlusion query results on the current graphics device.
+func (s *Swapper) QueryPoll() (pending int) {
+	return s.d.QueryPoll()
+}
+
 // Render renders a frame using the current graphics device. When it finishes
 // the swapper considers yielding and swapping the underlying graphics device
 // out with another.
@@ -97,6 +155,10 @@ func (s *Swapper) Render() {
 		s.d = <-s.Swap
 	default:
 	}
+
+	if s.Wait != nil {
+		s.Wait()
+	}
 }
 
 // LoadMesh loads a mesh using the current graphics device.
@@ -109,6 +171,11 @@ func (s *Swapper) LoadTexture(t *gfx.Texture, done chan *gfx.Texture) {
 	s.d.LoadTexture(t, done)
 }
 
+// UpdateStream updates a stream texture using the current graphics device.
+func (s *Swapper) UpdateStream(t *gfx.StreamTexture, done chan *gfx.StreamTexture) {
+	s.d.UpdateStream(t, done)
+}
+
 // LoadShader loads a shader using the current graphics device.
 func (s *Swapper) LoadShader(sh *gfx.Shader, done chan *gfx.Shader) {
 	s.d.LoadShader(sh, done)
@@ -121,6 +188,11 @@ func (s *Swapper) RenderToTexture(cfg gfx.RTTConfig) gfx.Canvas {
 	return s.d.RenderToTexture(cfg)
 }
 
+// Fence inserts a fence using the current graphics device.
+func (s *Swapper) Fence() gfx.Fence {
+	return s.d.Fence()
+}
+
 // NewSwapper returns a new graphics device swapper, wrapping the given device.
 func NewSwapper(d gfx.Device) *Swapper {
 	s := &Swapper{
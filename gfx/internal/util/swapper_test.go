@@ -0,0 +1,29 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+func TestSwapperWait(t *testing.T) {
+	s := NewSwapper(gfx.Nil())
+
+	// Without a wait function, Render returns immediately.
+	s.Render()
+
+	waits := 0
+	s.Wait = func() {
+		waits++
+	}
+	for frame := 0; frame < 3; frame++ {
+		s.Render()
+	}
+	if waits != 3 {
+		t.Fatal("expected 3 waits, got", waits)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Light represents the data of a single point or spot light, for use in a
+// shader program. It does not describe a lighting model, it only describes
+// the data that a shader's lighting model may use. See the SetLights method
+// of Shader for how it is given to a shader.
+type Light struct {
+	// The position of the light.
+	Position Vec3
+
+	// The direction the light is pointing in, only used by spot lights.
+	Direction Vec3
+
+	// The color of the light.
+	Color Color
+
+	// The constant (X), linear (Y), and quadratic (Z) attenuation factors of
+	// the light.
+	Attenuation Vec3
+
+	// The cosine of the spot light cone's angle, and the exponent controlling
+	// how the light fades towards the edge of the cone. A zero SpotCutoff is
+	// typically used to mean a point light.
+	SpotCutoff, SpotExponent float32
+}
+
+// SetLights sets the inputs of this shader such that the GLSL shader program
+// sees the given lights as an array of structures under the given name. For
+// example, SetLights("Lights", lights) is used with the GLSL declaration:
+//
+//	struct Light {
+//	    vec3 Position;
+//	    vec3 Direction;
+//	    vec4 Color;
+//	    vec3 Attenuation;
+//	    float SpotCutoff;
+//	    float SpotExponent;
+//	};
+//	uniform Light Lights[8];
+//	uniform float LightsCount;
+//
+// Each field of each light is stored in s.Inputs under it's GLSL name (e.g.
+// "Lights[0].Position"). As the GLSL array has a fixed size, the number of
+// lights is stored as a float32 under the name with a "Count" suffix (e.g.
+// "LightsCount").
+//
+// Inputs for lights from a previous call that are beyond the length of the
+// given slice are removed.
+func (s *Shader) SetLights(name string, lights []Light) {
+	if s.Inputs == nil {
+		s.Inputs = make(map[string]interface{})
+	}
+
+	// Remove the inputs of any previous lights.
+	prefix := name + "["
+	for k := range s.Inputs {
+		if strings.HasPrefix(k, prefix) {
+			delete(s.Inputs, k)
+		}
+	}
+
+	for i, l := range lights {
+		elem := fmt.Sprintf("%s[%d].", name, i)
+		s.Inputs[elem+"Position"] = l.Position
+		s.Inputs[elem+"Direction"] = l.Direction
+		s.Inputs[elem+"Color"] = l.Color
+		s.Inputs[elem+"Attenuation"] = l.Attenuation
+		s.Inputs[elem+"SpotCutoff"] = l.SpotCutoff
+		s.Inputs[elem+"SpotExponent"] = l.SpotExponent
+	}
+	s.Inputs[name+"Count"] = float32(len(lights))
+}
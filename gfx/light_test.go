@@ -0,0 +1,36 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import "testing"
+
+func TestShaderSetLights(t *testing.T) {
+	s := NewShader("lights")
+	s.SetLights("Lights", []Light{
+		{Position: Vec3{1, 2, 3}, Color: Color{1, 1, 1, 1}},
+		{Direction: Vec3{0, 0, -1}, SpotCutoff: 0.5},
+	})
+	if got := s.Inputs["Lights[0].Position"]; got != (Vec3{1, 2, 3}) {
+		t.Fatalf("Lights[0].Position: got %v", got)
+	}
+	if got := s.Inputs["Lights[1].SpotCutoff"]; got != float32(0.5) {
+		t.Fatalf("Lights[1].SpotCutoff: got %v", got)
+	}
+	if got := s.Inputs["LightsCount"]; got != float32(2) {
+		t.Fatalf("LightsCount: got %v", got)
+	}
+
+	// Fewer lights should remove the inputs of the previous ones.
+	s.SetLights("Lights", []Light{{}})
+	if _, ok := s.Inputs["Lights[1].Position"]; ok {
+		t.Fatal("Lights[1].Position: stale input not removed")
+	}
+	if got := s.Inputs["LightsCount"]; got != float32(1) {
+		t.Fatalf("LightsCount: got %v", got)
+	}
+	if len(s.Inputs) != 7 {
+		t.Fatalf("got %d inputs, want 7", len(s.Inputs))
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+// MemoryStats represents the amount of graphics memory in use by the resources
+// of a device, e.g. for asset budgeting. All sizes are measured in bytes.
+//
+// The sizes of resources are estimated from their dimensions and formats, the
+// actual amount of memory used by the graphics driver may differ (e.g. due to
+// padding or alignment).
+type MemoryStats struct {
+	// The memory used by loaded textures (including render-to-texture ones
+	// and their mipmaps).
+	Textures int64
+
+	// The memory used by the vertex buffers of loaded meshes.
+	Meshes int64
+
+	// The memory used by render buffers (i.e. render-to-texture color,
+	// depth, and stencil buffers which are not textures).
+	Renderbuffers int64
+
+	// The sum of all of the above.
+	Total int64
+
+	// The total and currently available video memory of the graphics
+	// hardware, or -1 if the device cannot query it.
+	VRAMTotal, VRAMAvailable int64
+}
@@ -37,6 +37,16 @@ type TexCoordSet struct {
 	Changed bool
 }
 
+// MeshRange represents a range of the indices of an indexed mesh, or of the
+// vertices of a non-indexed mesh, to be drawn. See the Ranges field of Mesh.
+type MeshRange struct {
+	// The first index (or vertex) of the range.
+	Start int
+
+	// The number of indices (or vertices) in the range.
+	Count int
+}
+
 // VertexAttrib represents a per-vertex attribute.
 type VertexAttrib struct {
 	// The literal per-vertex data slice. It must be a slice whose length is
@@ -178,13 +188,27 @@ type Mesh struct {
 	// Dynamic is a hint (it does not restrict how the mesh may be used) to the
 	// graphics device on how this mesh might be used. If you intend to update
 	// mesh data often (i.e. it's not static) then set this to true.
+	//
+	// It is equivalent to a Usage of Dynamic, and is ignored if Usage is not
+	// Static.
 	Dynamic bool
 
+	// Usage is a hint (it does not restrict how the mesh may be used) to the
+	// graphics device on how often the mesh data will be updated. Devices
+	// update the data of a Dynamic or Stream mesh in-place when it's size has
+	// not changed, instead of reallocating it.
+	Usage Usage
+
 	// AABB is the axis aligned bounding box of this mesh. There may not be one
 	// if AABB.Empty() == true, but one can be calculate using the
 	// CalculateBounds() method.
 	AABB lmath.Rect3
 
+	// Sphere is the bounding sphere of this mesh. There may not be one if
+	// Sphere.Radius == 0, but one can be calculated using the
+	// CalculateBoundingSphere() method.
+	Sphere lmath.Sphere
+
 	// A slice of indices, if non-nil then this slice contains indices into
 	// each other slice (such as Vertices) and this is a indexed mesh.
 	//
@@ -192,11 +216,42 @@ type Mesh struct {
 	// hardware.
 	Indices []uint32
 
+	// IndexType is a hint to the graphics device on the size of each index as
+	// stored in graphics memory. The zero-value (i.e. default value) is
+	// AutoIndex, see the EffectiveIndexType method.
+	IndexType
+
 	// Weather or not the indices have changed since the last time the mesh
 	// was loaded. If set to true the device should take note and re-upload the
 	// data slice to the graphics hardware.
 	IndicesChanged bool
 
+	// RestartIndex, if not nil, is the primitive restart index of this indexed
+	// mesh: each occurrence of it in Indices ends the current primitive and
+	// begins a new one, such that e.g. many line strips can be drawn as a
+	// single mesh.
+	//
+	// It requires support by the device (see the PrimitiveRestart field of
+	// DeviceInfo), and is ignored otherwise (i.e. the restart index is drawn
+	// like any other index). It is not uploaded to the graphics hardware, so
+	// it may be changed without the mesh being loaded again.
+	RestartIndex *uint32
+
+	// If non-empty then only these ranges of the mesh's indices (or of it's
+	// vertices, if the mesh is not indexed) are drawn, instead of the entire
+	// mesh. Devices draw all of the ranges at once where supported (see the
+	// MultiDraw field of DeviceInfo), such that many parts of a single large
+	// mesh (e.g. the visible chunks of a terrain) can be drawn without a draw
+	// call for each one. Ranges apply whenever the mesh is drawn (e.g. to a
+	// render-to-texture canvas, or as one of the meshes of a textured object).
+	// Some devices also offer drawing ranges of a mesh directly (e.g. the
+	// DrawMulti method of OpenGL devices).
+	//
+	// Ranges are not uploaded to the graphics hardware, so they may be changed
+	// at any time without the mesh being loaded again. Ranges which lie
+	// outside of the mesh are ignored.
+	Ranges []MeshRange
+
 	// The slice of vertices for the mesh.
 	Vertices []Vec3
 
@@ -229,6 +284,42 @@ type Mesh struct {
 	// and re-upload the data slice to the graphics hardware.
 	BaryChanged bool
 
+	// A slice of per-vertex tangents for the mesh, used for normal mapping.
+	// The XYZ components are the tangent vector and the W component (either
+	// +1 or -1) is the handedness of the tangent space, such that the
+	// bitangent is cross(normal, tangent.XYZ) * tangent.W.
+	//
+	// They may be generated from the vertices, normals, and texture
+	// coordinates of a mesh using the gfxutil.ComputeTangents function.
+	Tangents []Vec4
+
+	// Whether or not the tangents have changed since the last time the mesh
+	// was loaded. If set to true the device should take note and re-upload the
+	// data slice to the graphics hardware.
+	TangentsChanged bool
+
+	// A slice of per-vertex bone indices for the mesh, used for skeletal
+	// animation (skinning). Each component is the index of a bone in the
+	// skeleton of the object (see Object.Skeleton) which influences the
+	// vertex, up to four bones per vertex.
+	BoneIndices []Vec4
+
+	// Whether or not the bone indices have changed since the last time the
+	// mesh was loaded. If set to true the device should take note and
+	// re-upload the data slice to the graphics hardware.
+	BoneIndicesChanged bool
+
+	// A slice of per-vertex bone weights for the mesh, used for skeletal
+	// animation (skinning). Each component is the weight of the bone with the
+	// corresponding index in BoneIndices, the weights of a vertex should sum
+	// to one.
+	BoneWeights []Vec4
+
+	// Whether or not the bone weights have changed since the last time the
+	// mesh was loaded. If set to true the device should take note and
+	// re-upload the data slice to the graphics hardware.
+	BoneWeightsChanged bool
+
 	// A slice of texture coordinate sets for the mesh, there may be
 	// multiple sets which directly relate to multiple textures on a
 	// object.
@@ -277,9 +368,14 @@ func (m *Mesh) Copy() *Mesh {
 		m.Primitive,
 		m.KeepDataOnLoad,
 		m.Dynamic,
+		m.Usage,
 		m.AABB,
+		m.Sphere,
 		make([]uint32, len(m.Indices)),
+		m.IndexType,
 		false, // IndicesChanged -- not copied.
+		nil,   // RestartIndex -- copied below.
+		make([]MeshRange, len(m.Ranges)),
 		make([]Vec3, len(m.Vertices)),
 		false, // VerticesChanged -- not copied.
 		make([]Color, len(m.Colors)),
@@ -288,15 +384,29 @@ func (m *Mesh) Copy() *Mesh {
 		false, // NormalsChanged -- not copied.
 		make([]Vec3, len(m.Bary)),
 		false, // BaryChanged -- not copied.
+		make([]Vec4, len(m.Tangents)),
+		false, // TangentsChanged -- not copied.
+		make([]Vec4, len(m.BoneIndices)),
+		false, // BoneIndicesChanged -- not copied.
+		make([]Vec4, len(m.BoneWeights)),
+		false, // BoneWeightsChanged -- not copied.
 		make([]TexCoordSet, len(m.TexCoords)),
 		make(map[string]VertexAttrib, len(m.Attribs)),
 	}
 
 	copy(cpy.Indices, m.Indices)
+	if m.RestartIndex != nil {
+		restart := *m.RestartIndex
+		cpy.RestartIndex = &restart
+	}
+	copy(cpy.Ranges, m.Ranges)
 	copy(cpy.Vertices, m.Vertices)
 	copy(cpy.Colors, m.Colors)
 	copy(cpy.Normals, m.Normals)
 	copy(cpy.Bary, m.Bary)
+	copy(cpy.Tangents, m.Tangents)
+	copy(cpy.BoneIndices, m.BoneIndices)
+	copy(cpy.BoneWeights, m.BoneWeights)
 	for index, set := range m.TexCoords {
 		setCpy := TexCoordSet{
 			Slice: make([]TexCoord, len(set.Slice)),
@@ -320,6 +430,15 @@ func (m *Mesh) Bounds() lmath.Rect3 {
 	return bounds
 }
 
+// BoundingSphere returns the bounding sphere of this mesh. If the Sphere of
+// this mesh has a zero radius then the bounding sphere is calculated.
+func (m *Mesh) BoundingSphere() (center lmath.Vec3, radius float64) {
+	if m.Sphere.Radius == 0 {
+		m.CalculateBoundingSphere()
+	}
+	return m.Sphere.Center, m.Sphere.Radius
+}
+
 // GenerateBary generates the barycentric coordinates for this mesh.
 func (m *Mesh) GenerateBary() {
 	var (
@@ -354,10 +473,102 @@ func (m *Mesh) CalculateBounds() {
 	m.AABB = bb
 }
 
+// CalculateBoundingSphere calculates a new bounding sphere for this mesh using
+// Ritter's algorithm, which is fast but may produce a sphere slightly larger
+// than the minimal one.
+func (m *Mesh) CalculateBoundingSphere() {
+	var s lmath.Sphere
+	if len(m.Vertices) == 0 {
+		m.Sphere = s
+		return
+	}
+
+	// farthest returns the vertex farthest away from p.
+	farthest := func(p lmath.Vec3) lmath.Vec3 {
+		var (
+			far     = p
+			farDist float64
+		)
+		for _, v32 := range m.Vertices {
+			v := v32.Vec3()
+			if d := v.Sub(p).LengthSq(); d > farDist {
+				far, farDist = v, d
+			}
+		}
+		return far
+	}
+
+	// Start with the sphere between the two (approximately) farthest apart
+	// vertices.
+	a := farthest(m.Vertices[0].Vec3())
+	b := farthest(a)
+	s.Center = a.Add(b).MulScalar(0.5)
+	s.Radius = b.Sub(a).Length() / 2
+
+	// Grow the sphere to encompass any vertices outside of it.
+	for _, v32 := range m.Vertices {
+		v := v32.Vec3()
+		d := v.Sub(s.Center).Length()
+		if d <= s.Radius {
+			continue
+		}
+		r := (s.Radius + d) / 2
+		s.Center = s.Center.Add(v.Sub(s.Center).MulScalar((r - s.Radius) / d))
+		s.Radius = r
+	}
+	m.Sphere = s
+}
+
+// EffectiveUsage returns the usage hint of this mesh, taking into account the
+// Dynamic field (which is equivalent to a Usage of Dynamic).
+func (m *Mesh) EffectiveUsage() Usage {
+	if m.Usage == Static && m.Dynamic {
+		return Dynamic
+	}
+	return m.Usage
+}
+
+// EffectiveIndexType returns the index type that the indices of this mesh
+// should be stored with, which is never AutoIndex. A Uint16Index is returned
+// only if all of the indices fit into 16 bits and the IndexType hint is not
+// Uint32Index.
+func (m *Mesh) EffectiveIndexType() IndexType {
+	if m.IndexType == Uint32Index {
+		return Uint32Index
+	}
+	for _, index := range m.Indices {
+		if index > maxUint16Index {
+			return Uint32Index
+		}
+	}
+	return Uint16Index
+}
+
+// GLID returns the OpenGL buffer objects holding the vertices and indices of
+// this mesh, for interoperating with other OpenGL code. The indices buffer is
+// zero if the mesh is not indexed. If the mesh is not loaded, or it was not
+// loaded by an OpenGL based device, ok is false.
+//
+// The buffer objects may only be used on the device's OpenGL thread (e.g.
+// within a function given to the RenderDo method of an OpenGL 2 device), and
+// only until the mesh is destroyed or reloaded.
+func (m *Mesh) GLID() (vertices, indices uint32, ok bool) {
+	if n, ok := m.NativeMesh.(interface {
+		GLID() (vertices, indices uint32)
+	}); ok {
+		vertices, indices = n.GLID()
+		return vertices, indices, true
+	}
+	return 0, 0, false
+}
+
 // HasChanged tells if any of the data slices of the mesh are marked as having
 // changed.
 func (m *Mesh) HasChanged() bool {
-	if m.IndicesChanged || m.VerticesChanged || m.ColorsChanged || m.NormalsChanged || m.BaryChanged {
+	if m.IndicesChanged || m.VerticesChanged || m.ColorsChanged || m.NormalsChanged || m.BaryChanged || m.TangentsChanged {
+		return true
+	}
+	if m.BoneIndicesChanged || m.BoneWeightsChanged {
 		return true
 	}
 	for _, texCoordSet := range m.TexCoords {
@@ -520,6 +731,9 @@ func (m *Mesh) Append(other *Mesh) {
 	handle(&m.Colors, &other.Colors, &m.ColorsChanged)
 	handle(&m.Normals, &other.Normals, &m.NormalsChanged)
 	handle(&m.Bary, &other.Bary, &m.BaryChanged)
+	handle(&m.Tangents, &other.Tangents, &m.TangentsChanged)
+	handle(&m.BoneIndices, &other.BoneIndices, &m.BoneIndicesChanged)
+	handle(&m.BoneWeights, &other.BoneWeights, &m.BoneWeightsChanged)
 
 	// Handle texture coordinates.
 	for i, tcs := range m.TexCoords {
@@ -551,6 +765,9 @@ func (m *Mesh) ClearData() {
 		m.Colors = nil
 		m.Normals = nil
 		m.Bary = nil
+		m.Tangents = nil
+		m.BoneIndices = nil
+		m.BoneWeights = nil
 		m.TexCoords = nil
 		m.Attribs = nil
 	}
@@ -563,9 +780,14 @@ func (m *Mesh) Reset() {
 	m.Primitive = Triangles
 	m.KeepDataOnLoad = false
 	m.Dynamic = false
+	m.Usage = Static
 	m.AABB = lmath.Rect3Zero
+	m.Sphere = lmath.Sphere{}
 	m.Indices = m.Indices[:0]
+	m.IndexType = AutoIndex
 	m.IndicesChanged = false
+	m.RestartIndex = nil
+	m.Ranges = m.Ranges[:0]
 	m.Vertices = m.Vertices[:0]
 	m.VerticesChanged = false
 	m.Colors = m.Colors[:0]
@@ -574,6 +796,12 @@ func (m *Mesh) Reset() {
 	m.NormalsChanged = false
 	m.Bary = m.Bary[:0]
 	m.BaryChanged = false
+	m.Tangents = m.Tangents[:0]
+	m.TangentsChanged = false
+	m.BoneIndices = m.BoneIndices[:0]
+	m.BoneIndicesChanged = false
+	m.BoneWeights = m.BoneWeights[:0]
+	m.BoneWeightsChanged = false
 	for _, tcs := range m.TexCoords {
 		tcs.Slice = nil
 		tcs.Changed = false
@@ -4,7 +4,11 @@
 
 package gfx
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/lmath"
+)
 
 var meshAppendTests = []struct {
 	name                                           string
@@ -223,3 +227,155 @@ func BenchmarkMeshAppend4kPrealloc(b *testing.B) {
 func BenchmarkMeshAppend4kDumb(b *testing.B) {
 	benchmarkMeshAppend(b, 16000, false)
 }
+
+func TestMeshEffectiveUsage(t *testing.T) {
+	tests := []struct {
+		usage   Usage
+		dynamic bool
+		want    Usage
+	}{
+		{Static, false, Static},
+		{Static, true, Dynamic},
+		{Dynamic, false, Dynamic},
+		{Stream, false, Stream},
+		{Stream, true, Stream},
+	}
+	for _, tst := range tests {
+		m := NewMesh()
+		m.Usage = tst.usage
+		m.Dynamic = tst.dynamic
+		if got := m.EffectiveUsage(); got != tst.want {
+			t.Errorf("Usage=%v Dynamic=%v: got EffectiveUsage() == %v, want %v", tst.usage, tst.dynamic, got, tst.want)
+		}
+		if got := m.Copy().Usage; got != tst.usage {
+			t.Errorf("got Copy().Usage == %v, want %v", got, tst.usage)
+		}
+		m.Destroy()
+	}
+}
+
+func TestMeshEffectiveIndexType(t *testing.T) {
+	tests := []struct {
+		indexType IndexType
+		maxIndex  uint32
+		want      IndexType
+	}{
+		{AutoIndex, 65535, Uint16Index},
+		{AutoIndex, 65536, Uint32Index},
+		{Uint16Index, 65535, Uint16Index},
+		{Uint16Index, 65536, Uint32Index},
+		{Uint32Index, 0, Uint32Index},
+		{Uint32Index, 65536, Uint32Index},
+	}
+	for _, tst := range tests {
+		m := NewMesh()
+		m.IndexType = tst.indexType
+		m.Indices = append(m.Indices, 0, tst.maxIndex, 1)
+		if got := m.EffectiveIndexType(); got != tst.want {
+			t.Errorf("IndexType=%v maxIndex=%v: got EffectiveIndexType() == %v, want %v", tst.indexType, tst.maxIndex, got, tst.want)
+		}
+		if got := m.Copy().IndexType; got != tst.indexType {
+			t.Errorf("got Copy().IndexType == %v, want %v", got, tst.indexType)
+		}
+		m.Destroy()
+	}
+}
+
+func TestMeshRanges(t *testing.T) {
+	m := NewMesh()
+	m.Ranges = append(m.Ranges, MeshRange{Start: 0, Count: 6}, MeshRange{Start: 12, Count: 3})
+
+	cpy := m.Copy()
+	if len(cpy.Ranges) != 2 || cpy.Ranges[1] != m.Ranges[1] {
+		t.Fatal("got Copy().Ranges ==", cpy.Ranges, "want", m.Ranges)
+	}
+	cpy.Ranges[0].Count = 3
+	if m.Ranges[0].Count != 6 {
+		t.Fatal("Copy did not copy the Ranges slice")
+	}
+
+	m.Reset()
+	if len(m.Ranges) != 0 {
+		t.Fatal("Reset did not clear the Ranges")
+	}
+	m.Destroy()
+}
+
+func TestMeshBoundingSphere(t *testing.T) {
+	m := NewMesh()
+	if center, radius := m.BoundingSphere(); center != lmath.Vec3Zero || radius != 0 {
+		t.Fatal("got", center, radius, "want an empty sphere for an empty mesh")
+	}
+
+	m.Vertices = []Vec3{
+		{1, 2, 3}, {-3, 0, 1}, {5, 1, -2}, {0, -4, 0}, {2, 2, 6}, {1, 1, 1},
+	}
+	center, radius := m.BoundingSphere()
+	for _, v := range m.Vertices {
+		if d := v.Vec3().Sub(center).Length(); d > radius+1e-9 {
+			t.Fatalf("vertex %v is outside of the sphere (center %v, radius %v)", v, center, radius)
+		}
+	}
+
+	// The sphere is cached until it's radius is zero.
+	m.Vertices = append(m.Vertices, Vec3{100, 0, 0})
+	if c, r := m.BoundingSphere(); c != center || r != radius {
+		t.Fatal("BoundingSphere did not use the cached sphere")
+	}
+	m.CalculateBoundingSphere()
+	if _, r := m.BoundingSphere(); r <= radius {
+		t.Fatal("CalculateBoundingSphere did not grow the sphere")
+	}
+
+	if cpy := m.Copy(); cpy.Sphere != m.Sphere {
+		t.Fatal("Copy did not copy the Sphere")
+	}
+	m.Reset()
+	if m.Sphere != (lmath.Sphere{}) {
+		t.Fatal("Reset did not reset the Sphere")
+	}
+	m.Destroy()
+}
+
+func TestMeshRestartIndex(t *testing.T) {
+	m := NewMesh()
+	restart := uint32(0xFFFF)
+	m.RestartIndex = &restart
+
+	cpy := m.Copy()
+	if cpy.RestartIndex == nil || *cpy.RestartIndex != restart {
+		t.Fatal("Copy did not copy the RestartIndex")
+	}
+	if cpy.RestartIndex == m.RestartIndex {
+		t.Fatal("Copy shares the RestartIndex with the original mesh")
+	}
+
+	m.Reset()
+	if m.RestartIndex != nil {
+		t.Fatal("Reset did not reset the RestartIndex")
+	}
+	m.Destroy()
+}
+
+// glMesh is a native mesh of an OpenGL based device.
+type glMesh struct {
+	nilNativeMesh
+	vertices, indices uint32
+}
+
+func (g glMesh) GLID() (vertices, indices uint32) { return g.vertices, g.indices }
+
+func TestMeshGLID(t *testing.T) {
+	m := NewMesh()
+	if _, _, ok := m.GLID(); ok {
+		t.Fatal("expected no OpenGL buffers before loading")
+	}
+	m.NativeMesh = nilNativeMesh{}
+	if _, _, ok := m.GLID(); ok {
+		t.Fatal("expected no OpenGL buffers for a non-OpenGL device")
+	}
+	m.NativeMesh = glMesh{vertices: 4, indices: 5}
+	if v, i, ok := m.GLID(); !ok || v != 4 || i != 5 {
+		t.Fatal("got", v, i, ok, "want", 4, 5, true)
+	}
+}
@@ -69,7 +69,8 @@ func intMin(a, b int) int {
 //
 type MeshState struct {
 	// Whether or not indices, vertices, etc are present in the mesh.
-	Indices, Vertices, Colors, Normals, Bary bool
+	Indices, Vertices, Colors, Normals, Bary, Tangents bool
+	BoneIndices, BoneWeights                           bool
 
 	// How many texture coordinate sets are present in the mesh. The boolean
 	// value signifies whether the len(texCoord.Slice) > 0 or not.
@@ -97,6 +98,12 @@ func (s *MeshState) Equals(other *MeshState) bool {
 	if s.Bary != other.Bary {
 		return false
 	}
+	if s.Tangents != other.Tangents {
+		return false
+	}
+	if s.BoneIndices != other.BoneIndices || s.BoneWeights != other.BoneWeights {
+		return false
+	}
 	if len(s.TexCoords) > 0 && len(other.TexCoords) > 0 {
 		if len(s.TexCoords) != len(other.TexCoords) {
 			return false
@@ -134,9 +141,13 @@ func (s *MeshState) Diff(a, b *MeshState) bool {
 	s.Colors = a.Colors != b.Colors
 	s.Normals = a.Normals != b.Normals
 	s.Bary = a.Bary != b.Bary
+	s.Tangents = a.Tangents != b.Tangents
+	s.BoneIndices = a.BoneIndices != b.BoneIndices
+	s.BoneWeights = a.BoneWeights != b.BoneWeights
 
 	// Generate the diff boolean.
-	diff := s.Indices || s.Vertices || s.Colors || s.Normals || s.Bary
+	diff := s.Indices || s.Vertices || s.Colors || s.Normals || s.Bary || s.Tangents
+	diff = diff || s.BoneIndices || s.BoneWeights
 
 	// Only compare texture coordinates if we have them.
 	if len(a.TexCoords) > 0 && len(b.TexCoords) > 0 {
@@ -222,6 +233,9 @@ func (m *Mesh) State(s *MeshState) {
 	s.Colors = len(m.Colors) > 0
 	s.Normals = len(m.Normals) > 0
 	s.Bary = len(m.Bary) > 0
+	s.Tangents = len(m.Tangents) > 0
+	s.BoneIndices = len(m.BoneIndices) > 0
+	s.BoneWeights = len(m.BoneWeights) > 0
 	if len(m.TexCoords) > 0 {
 		s.TexCoords = make([]bool, len(m.TexCoords))
 		for i, tcs := range m.TexCoords {
@@ -0,0 +1,43 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package model implements loading of 3D model files into gfx objects.
+//
+// Wavefront OBJ models (along with their MTL material libraries) are loaded
+// using the LoadOBJ and LoadOBJFile functions:
+//
+//	objects, err := model.LoadOBJFile("teapot.obj")
+//	...
+//	for _, o := range objects {
+//		o.Shader = shader
+//		canvas.Draw(image.Rect(0, 0, 0, 0), o, camera)
+//	}
+//
+// The loaded objects have no shader, one must be assigned before drawing
+// them.
+//
+// glTF 2.0 models are loaded as a scene, a hierarchy of nodes each with their
+// own transform and objects, using the LoadGLTF function:
+//
+//	f, err := os.Open("helmet.glb")
+//	...
+//	scene, err := model.LoadGLTF(f, "")
+//	...
+//	for _, o := range scene.Objects {
+//		o.Shader = shader
+//		canvas.Draw(image.Rect(0, 0, 0, 0), o, camera)
+//	}
+//
+// # Coordinate System
+//
+// Most model formats are Y-up, whereas the gfx package is Z-up (see the gfx
+// package documentation). The models are converted into the Z-up coordinate
+// system, by rotating them 90 degrees about the X axis: the positions and
+// normals of OBJ models are converted while loading them, whereas glTF scenes
+// are converted by the root transform of the scene.
+//
+// Texture coordinates of OBJ models are likewise converted from a bottom-left
+// origin into the top-left origin used by the gfx package, glTF ones already
+// use a top-left origin.
+package model // import "github.com/qmcloud/engine/gfx/model"
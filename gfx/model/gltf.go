@@ -0,0 +1,763 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package model
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+var (
+	// ErrGLTFVersion is returned by LoadGLTF when the model is not a glTF 2.0
+	// one.
+	ErrGLTFVersion = errors.New("model: unsupported glTF version")
+
+	// ErrGLTFSparse is returned by LoadGLTF when the model uses sparse
+	// accessors, which are not supported.
+	ErrGLTFSparse = errors.New("model: sparse glTF accessors are not supported")
+)
+
+// Scene is a hierarchy of nodes, as loaded from a glTF model by LoadGLTF.
+type Scene struct {
+	// The root transform of the scene, which every root node is parented to.
+	// It converts from the Y-up coordinate system of glTF into the Z-up one
+	// of the gfx package, and may be used to position the whole scene.
+	Transform *gfx.Transform
+
+	// The root nodes of the scene.
+	Nodes []*Node
+
+	// Every object of the scene (i.e. of each node), for drawing.
+	Objects []*gfx.Object
+}
+
+// Node is a single node of a scene.
+type Node struct {
+	// The name of the node, if any.
+	Name string
+
+	// The transform of the node, which is parented to the transform of it's
+	// parent node (or of the scene, for root nodes).
+	Transform *gfx.Transform
+
+	// The child nodes of this node.
+	Children []*Node
+
+	// The objects of the node, one for each primitive of it's mesh (if any),
+	// whose transforms are parented to the transform of the node.
+	Objects []*gfx.Object
+}
+
+// LoadGLTF loads the glTF 2.0 model from r, in either the JSON (.gltf) or the
+// binary (.glb) format. External buffers and images are loaded relative to
+// the given directory, while embedded ones (data URIs, or the binary chunk of
+// a .glb file) are decoded directly.
+//
+// The default scene of the model is loaded (or every root node, if there are
+// no scenes). Each primitive of a mesh becomes a gfx.Object, with a mesh whose
+// data comes from the primitive's attributes:
+//
+//	POSITION   -> Mesh.Vertices
+//	NORMAL     -> Mesh.Normals
+//	TANGENT    -> Mesh.Tangents
+//	TEXCOORD_n -> Mesh.TexCoords[n]
+//	COLOR_0    -> Mesh.Colors
+//
+// Primitives without vertex colors are given the base color factor of their
+// material as vertex colors. The base color texture of the material becomes
+// the object's texture, and the alpha mode and double-sidedness of the material
+// are mapped onto the object's State. Skins, animations, and cameras are not
+// supported and are ignored.
+//
+// As usual, you will also need to import image decoders for the textures,
+// e.g. for png:
+//
+//	import _ "image/png"
+func LoadGLTF(r io.Reader, dir string) (*Scene, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	l := &gltfLoader{dir: dir}
+	if err := l.decode(data); err != nil {
+		return nil, err
+	}
+	return l.load()
+}
+
+// The subset of the glTF 2.0 JSON schema which is supported.
+type (
+	gltfDoc struct {
+		Asset struct {
+			Version string `json:"version"`
+		} `json:"asset"`
+		Scene       *int             `json:"scene"`
+		Scenes      []gltfScene      `json:"scenes"`
+		Nodes       []gltfNode       `json:"nodes"`
+		Meshes      []gltfMesh       `json:"meshes"`
+		Materials   []gltfMaterial   `json:"materials"`
+		Textures    []gltfTexture    `json:"textures"`
+		Images      []gltfImage      `json:"images"`
+		Samplers    []gltfSampler    `json:"samplers"`
+		Accessors   []gltfAccessor   `json:"accessors"`
+		BufferViews []gltfBufferView `json:"bufferViews"`
+		Buffers     []gltfBuffer     `json:"buffers"`
+	}
+	gltfScene struct {
+		Nodes []int `json:"nodes"`
+	}
+	gltfNode struct {
+		Name        string    `json:"name"`
+		Children    []int     `json:"children"`
+		Mesh        *int      `json:"mesh"`
+		Matrix      []float64 `json:"matrix"`
+		Translation []float64 `json:"translation"`
+		Rotation    []float64 `json:"rotation"`
+		Scale       []float64 `json:"scale"`
+	}
+	gltfMesh struct {
+		Primitives []gltfPrimitive `json:"primitives"`
+	}
+	gltfPrimitive struct {
+		Attributes map[string]int `json:"attributes"`
+		Indices    *int           `json:"indices"`
+		Material   *int           `json:"material"`
+		Mode       *int           `json:"mode"`
+	}
+	gltfMaterial struct {
+		PBR struct {
+			BaseColorFactor  []float32   `json:"baseColorFactor"`
+			BaseColorTexture *gltfTexRef `json:"baseColorTexture"`
+		} `json:"pbrMetallicRoughness"`
+		AlphaMode   string `json:"alphaMode"`
+		DoubleSided bool   `json:"doubleSided"`
+	}
+	gltfTexRef struct {
+		Index int `json:"index"`
+	}
+	gltfTexture struct {
+		Sampler *int `json:"sampler"`
+		Source  *int `json:"source"`
+	}
+	gltfImage struct {
+		URI        string `json:"uri"`
+		BufferView *int   `json:"bufferView"`
+	}
+	gltfSampler struct {
+		MagFilter int `json:"magFilter"`
+		WrapS     int `json:"wrapS"`
+		WrapT     int `json:"wrapT"`
+	}
+	gltfAccessor struct {
+		BufferView    *int            `json:"bufferView"`
+		ByteOffset    int             `json:"byteOffset"`
+		ComponentType int             `json:"componentType"`
+		Normalized    bool            `json:"normalized"`
+		Count         int             `json:"count"`
+		Type          string          `json:"type"`
+		Sparse        json.RawMessage `json:"sparse"`
+	}
+	gltfBufferView struct {
+		Buffer     int `json:"buffer"`
+		ByteOffset int `json:"byteOffset"`
+		ByteLength int `json:"byteLength"`
+		ByteStride int `json:"byteStride"`
+	}
+	gltfBuffer struct {
+		URI        string `json:"uri"`
+		ByteLength int    `json:"byteLength"`
+	}
+)
+
+// glTF constants.
+const (
+	glbMagic      = 0x46546C67 // "glTF"
+	glbChunkJSON  = 0x4E4F534A // "JSON"
+	glbChunkBIN   = 0x004E4942 // "BIN\x00"
+	gltfByte      = 5120
+	gltfUByte     = 5121
+	gltfShort     = 5122
+	gltfUShort    = 5123
+	gltfUInt      = 5125
+	gltfFloat     = 5126
+	gltfPoints    = 0
+	gltfLines     = 1
+	gltfTriangles = 4
+	gltfNearest   = 9728
+	gltfClamp     = 33071
+	gltfMirror    = 33648
+)
+
+// gltfLoader loads a single glTF model.
+type gltfLoader struct {
+	dir     string
+	doc     gltfDoc
+	bin     []byte   // The binary chunk of a .glb file.
+	buffers [][]byte // Loaded buffers, by index.
+
+	// Loaded meshes (one per primitive) and textures, by index.
+	meshes   [][]*gltfPrimitiveObject
+	textures []*gfx.Texture
+}
+
+// gltfPrimitiveObject is a loaded primitive, from which objects are created.
+type gltfPrimitiveObject struct {
+	mesh    *gfx.Mesh
+	state   *gfx.State
+	texture *gfx.Texture
+}
+
+// decode decodes the JSON document, and the binary chunk of a .glb file.
+func (l *gltfLoader) decode(data []byte) error {
+	if len(data) >= 12 && binary.LittleEndian.Uint32(data) == glbMagic {
+		if binary.LittleEndian.Uint32(data[4:]) != 2 {
+			return ErrGLTFVersion
+		}
+		var jsonChunk []byte
+		for chunks := data[12:]; len(chunks) >= 8; {
+			length := int(binary.LittleEndian.Uint32(chunks))
+			typ := binary.LittleEndian.Uint32(chunks[4:])
+			if length > len(chunks)-8 {
+				return errors.New("model: truncated glb chunk")
+			}
+			chunk := chunks[8 : 8+length]
+			switch typ {
+			case glbChunkJSON:
+				jsonChunk = chunk
+			case glbChunkBIN:
+				l.bin = chunk
+			}
+			chunks = chunks[8+length:]
+		}
+		data = jsonChunk
+	}
+	if err := json.Unmarshal(data, &l.doc); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(l.doc.Asset.Version, "2.") {
+		return ErrGLTFVersion
+	}
+	return nil
+}
+
+// load loads the scene.
+func (l *gltfLoader) load() (*Scene, error) {
+	l.buffers = make([][]byte, len(l.doc.Buffers))
+	l.meshes = make([][]*gltfPrimitiveObject, len(l.doc.Meshes))
+	l.textures = make([]*gfx.Texture, len(l.doc.Textures))
+
+	// Find the root nodes.
+	var roots []int
+	switch {
+	case l.doc.Scene != nil && *l.doc.Scene < len(l.doc.Scenes):
+		roots = l.doc.Scenes[*l.doc.Scene].Nodes
+	case len(l.doc.Scenes) > 0:
+		roots = l.doc.Scenes[0].Nodes
+	default:
+		isChild := make([]bool, len(l.doc.Nodes))
+		for _, n := range l.doc.Nodes {
+			for _, c := range n.Children {
+				if c >= 0 && c < len(isChild) {
+					isChild[c] = true
+				}
+			}
+		}
+		for i, child := range isChild {
+			if !child {
+				roots = append(roots, i)
+			}
+		}
+	}
+
+	// Convert from Y-up to Z-up, by rotating 90 degrees about the X axis.
+	s := &Scene{Transform: gfx.NewTransform()}
+	s.Transform.SetQuat(lmath.QuatFromAxisAngle(lmath.Vec3{X: 1}, math.Pi/2))
+	for _, index := range roots {
+		n, err := l.node(s, index, s.Transform, 0)
+		if err != nil {
+			return nil, err
+		}
+		s.Nodes = append(s.Nodes, n)
+	}
+	return s, nil
+}
+
+// node loads the node at the given index, and it's children, adding their
+// objects to the scene.
+func (l *gltfLoader) node(s *Scene, index int, parent *gfx.Transform, depth int) (*Node, error) {
+	if index < 0 || index >= len(l.doc.Nodes) {
+		return nil, fmt.Errorf("model: invalid glTF node %d", index)
+	}
+	if depth > len(l.doc.Nodes) {
+		return nil, errors.New("model: glTF node hierarchy contains a cycle")
+	}
+	gn := l.doc.Nodes[index]
+	n := &Node{
+		Name:      gn.Name,
+		Transform: gfx.NewTransform(),
+	}
+	n.Transform.SetParent(parent)
+
+	// Apply the local transformation, either as a matrix or as separate
+	// translation, rotation, and scale components.
+	if len(gn.Matrix) == 16 {
+		// The matrix is column-major, for column vectors. Transposing it for
+		// row vectors (as used by lmath) hence means reading it in order.
+		var m lmath.Mat4
+		for i, v := range gn.Matrix {
+			m[i/4][i%4] = v
+		}
+		scale, shear, hpr := m.UpperMat3().Decompose(lmath.CoordSysZUpRight)
+		n.Transform.SetPos(m.Translation())
+		n.Transform.SetQuat(lmath.QuatFromHpr(hpr, lmath.CoordSysZUpRight))
+		n.Transform.SetScale(scale)
+		n.Transform.SetShear(shear)
+	} else {
+		if t := gn.Translation; len(t) == 3 {
+			n.Transform.SetPos(lmath.Vec3{X: t[0], Y: t[1], Z: t[2]})
+		}
+		if r := gn.Rotation; len(r) == 4 {
+			n.Transform.SetQuat(lmath.Quat{W: r[3], X: r[0], Y: r[1], Z: r[2]})
+		}
+		if sc := gn.Scale; len(sc) == 3 {
+			n.Transform.SetScale(lmath.Vec3{X: sc[0], Y: sc[1], Z: sc[2]})
+		}
+	}
+
+	if gn.Mesh != nil {
+		prims, err := l.mesh(*gn.Mesh)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range prims {
+			o := gfx.NewObject()
+			o.Transform.SetParent(n.Transform)
+			o.Meshes = []*gfx.Mesh{p.mesh}
+			o.State = p.state
+			if p.texture != nil {
+				o.Textures = []*gfx.Texture{p.texture}
+			}
+			n.Objects = append(n.Objects, o)
+			s.Objects = append(s.Objects, o)
+		}
+	}
+
+	for _, child := range gn.Children {
+		c, err := l.node(s, child, n.Transform, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		n.Children = append(n.Children, c)
+	}
+	return n, nil
+}
+
+// mesh loads the primitives of the mesh at the given index, if they are not
+// already loaded.
+func (l *gltfLoader) mesh(index int) ([]*gltfPrimitiveObject, error) {
+	if index < 0 || index >= len(l.doc.Meshes) {
+		return nil, fmt.Errorf("model: invalid glTF mesh %d", index)
+	}
+	if l.meshes[index] != nil {
+		return l.meshes[index], nil
+	}
+	var prims []*gltfPrimitiveObject
+	for _, gp := range l.doc.Meshes[index].Primitives {
+		p, err := l.primitive(gp)
+		if err != nil {
+			return nil, err
+		}
+		prims = append(prims, p)
+	}
+	l.meshes[index] = prims
+	return prims, nil
+}
+
+// primitive loads a single primitive of a mesh.
+func (l *gltfLoader) primitive(gp gltfPrimitive) (*gltfPrimitiveObject, error) {
+	m := gfx.NewMesh()
+	mode := gltfTriangles
+	if gp.Mode != nil {
+		mode = *gp.Mode
+	}
+	switch mode {
+	case gltfTriangles:
+		m.Primitive = gfx.Triangles
+	case gltfLines:
+		m.Primitive = gfx.Lines
+	case gltfPoints:
+		m.Primitive = gfx.Points
+	default:
+		return nil, fmt.Errorf("model: unsupported glTF primitive mode %d", mode)
+	}
+
+	// Load the vertex attributes.
+	for name, index := range gp.Attributes {
+		v, n, err := l.accessor(index)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case name == "POSITION" && n == 3:
+			m.Vertices = make([]gfx.Vec3, len(v)/3)
+			for i := range m.Vertices {
+				m.Vertices[i] = gfx.Vec3{X: v[i*3], Y: v[i*3+1], Z: v[i*3+2]}
+			}
+		case name == "NORMAL" && n == 3:
+			m.Normals = make([]gfx.Vec3, len(v)/3)
+			for i := range m.Normals {
+				m.Normals[i] = gfx.Vec3{X: v[i*3], Y: v[i*3+1], Z: v[i*3+2]}
+			}
+		case name == "TANGENT" && n == 4:
+			m.Tangents = make([]gfx.Vec4, len(v)/4)
+			for i := range m.Tangents {
+				m.Tangents[i] = gfx.Vec4{X: v[i*4], Y: v[i*4+1], Z: v[i*4+2], W: v[i*4+3]}
+			}
+		case name == "COLOR_0" && (n == 3 || n == 4):
+			m.Colors = make([]gfx.Color, len(v)/n)
+			for i := range m.Colors {
+				c := gfx.Color{R: v[i*n], G: v[i*n+1], B: v[i*n+2], A: 1}
+				if n == 4 {
+					c.A = v[i*n+3]
+				}
+				m.Colors[i] = c
+			}
+		case strings.HasPrefix(name, "TEXCOORD_") && n == 2:
+			var set int
+			if _, err := fmt.Sscanf(name, "TEXCOORD_%d", &set); err != nil || set < 0 || set > 31 {
+				continue
+			}
+			for len(m.TexCoords) <= set {
+				m.TexCoords = append(m.TexCoords, gfx.TexCoordSet{})
+			}
+			tcs := make([]gfx.TexCoord, len(v)/2)
+			for i := range tcs {
+				tcs[i] = gfx.TexCoord{U: v[i*2], V: v[i*2+1]}
+			}
+			m.TexCoords[set].Slice = tcs
+		}
+	}
+	if len(m.Vertices) == 0 {
+		return nil, errors.New("model: glTF primitive has no positions")
+	}
+
+	// Load the indices.
+	if gp.Indices != nil {
+		indices, err := l.indices(*gp.Indices)
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range indices {
+			if uint64(index) >= uint64(len(m.Vertices)) {
+				return nil, fmt.Errorf("model: glTF index %d out of range", index)
+			}
+		}
+		m.Indices = indices
+	}
+
+	// Apply the material.
+	p := &gltfPrimitiveObject{mesh: m}
+	if gp.Material == nil {
+		return p, nil
+	}
+	if *gp.Material < 0 || *gp.Material >= len(l.doc.Materials) {
+		return nil, fmt.Errorf("model: invalid glTF material %d", *gp.Material)
+	}
+	mat := l.doc.Materials[*gp.Material]
+	if f := mat.PBR.BaseColorFactor; len(f) == 4 && m.Colors == nil {
+		c := gfx.Color{R: f[0], G: f[1], B: f[2], A: f[3]}
+		m.Colors = make([]gfx.Color, len(m.Vertices))
+		for i := range m.Colors {
+			m.Colors[i] = c
+		}
+	}
+	p.state = gfx.NewState()
+	switch mat.AlphaMode {
+	case "BLEND":
+		p.state.AlphaMode = gfx.AlphaBlend
+	case "MASK":
+		p.state.AlphaMode = gfx.BinaryAlpha
+	}
+	if mat.DoubleSided {
+		p.state.FaceCulling = gfx.NoFaceCulling
+	}
+	if ref := mat.PBR.BaseColorTexture; ref != nil {
+		tex, err := l.texture(ref.Index)
+		if err != nil {
+			return nil, err
+		}
+		p.texture = tex
+	}
+	return p, nil
+}
+
+// texture loads the texture at the given index, if it is not already loaded.
+func (l *gltfLoader) texture(index int) (*gfx.Texture, error) {
+	if index < 0 || index >= len(l.doc.Textures) {
+		return nil, fmt.Errorf("model: invalid glTF texture %d", index)
+	}
+	if l.textures[index] != nil {
+		return l.textures[index], nil
+	}
+	gt := l.doc.Textures[index]
+	if gt.Source == nil || *gt.Source < 0 || *gt.Source >= len(l.doc.Images) {
+		return nil, fmt.Errorf("model: glTF texture %d has no valid image", index)
+	}
+	gi := l.doc.Images[*gt.Source]
+
+	// Find the image data.
+	var (
+		data []byte
+		err  error
+	)
+	if gi.BufferView != nil {
+		data, _, err = l.bufferView(*gi.BufferView)
+	} else {
+		data, err = l.uri(gi.URI)
+	}
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	tex := gfx.NewTexture()
+	tex.Source = img
+	tex.Bounds = img.Bounds()
+	tex.MinFilter = gfx.LinearMipmapLinear
+	tex.MagFilter = gfx.Linear
+	tex.Format = gfx.RGBA
+	if gt.Sampler != nil && *gt.Sampler >= 0 && *gt.Sampler < len(l.doc.Samplers) {
+		s := l.doc.Samplers[*gt.Sampler]
+		if s.MagFilter == gltfNearest {
+			tex.MagFilter = gfx.Nearest
+		}
+		tex.WrapU = gltfWrap(s.WrapS)
+		tex.WrapV = gltfWrap(s.WrapT)
+	}
+	l.textures[index] = tex
+	return tex, nil
+}
+
+// gltfWrap converts a glTF sampler wrap mode.
+func gltfWrap(mode int) gfx.TexWrap {
+	switch mode {
+	case gltfClamp:
+		return gfx.Clamp
+	case gltfMirror:
+		return gfx.Mirror
+	}
+	return gfx.Repeat
+}
+
+// accessorData validates the accessor at the given index and returns it,
+// along with the data of it's buffer view (nil if it has none, i.e. the data
+// is all zeros), the byte stride between it's elements, and the size in bytes
+// of and number of components per element.
+func (l *gltfLoader) accessorData(index int) (a gltfAccessor, data []byte, stride, size, n int, err error) {
+	if index < 0 || index >= len(l.doc.Accessors) {
+		return a, nil, 0, 0, 0, fmt.Errorf("model: invalid glTF accessor %d", index)
+	}
+	a = l.doc.Accessors[index]
+	if len(a.Sparse) > 0 {
+		return a, nil, 0, 0, 0, ErrGLTFSparse
+	}
+	if a.Count < 0 || a.ByteOffset < 0 {
+		return a, nil, 0, 0, 0, fmt.Errorf("model: glTF accessor %d has a negative count or offset", index)
+	}
+	n, ok := map[string]int{"SCALAR": 1, "VEC2": 2, "VEC3": 3, "VEC4": 4}[a.Type]
+	if !ok {
+		return a, nil, 0, 0, 0, fmt.Errorf("model: unsupported glTF accessor type %q", a.Type)
+	}
+	size, ok = map[int]int{
+		gltfByte: 1, gltfUByte: 1,
+		gltfShort: 2, gltfUShort: 2,
+		gltfUInt: 4, gltfFloat: 4,
+	}[a.ComponentType]
+	if !ok {
+		return a, nil, 0, 0, 0, fmt.Errorf("model: unsupported glTF component type %d", a.ComponentType)
+	}
+	if a.BufferView == nil {
+		return a, nil, 0, size, n, nil
+	}
+	data, stride, err = l.bufferView(*a.BufferView)
+	if err != nil {
+		return a, nil, 0, 0, 0, err
+	}
+	if stride < 0 {
+		return a, nil, 0, 0, 0, fmt.Errorf("model: glTF buffer view %d has a negative stride", *a.BufferView)
+	}
+	if stride == 0 {
+		stride = size * n
+	}
+	if a.Count > 0 && a.ByteOffset+(a.Count-1)*stride+size*n > len(data) {
+		return a, nil, 0, 0, 0, fmt.Errorf("model: glTF accessor %d out of range", index)
+	}
+	return a, data, stride, size, n, nil
+}
+
+// accessor reads the data of the accessor at the given index as float32s,
+// returning the data and the number of components per element.
+func (l *gltfLoader) accessor(index int) (v []float32, n int, err error) {
+	a, data, stride, size, n, err := l.accessorData(index)
+	if err != nil {
+		return nil, 0, err
+	}
+	v = make([]float32, a.Count*n)
+	if data == nil {
+		// No buffer view means all zeros.
+		return v, n, nil
+	}
+
+	le := binary.LittleEndian
+	for i := 0; i < a.Count; i++ {
+		elem := data[a.ByteOffset+i*stride:]
+		for c := 0; c < n; c++ {
+			b := elem[c*size:]
+			var f float32
+			switch a.ComponentType {
+			case gltfFloat:
+				f = math.Float32frombits(le.Uint32(b))
+			case gltfUInt:
+				f = float32(le.Uint32(b))
+			case gltfUShort:
+				f = float32(le.Uint16(b))
+				if a.Normalized {
+					f /= math.MaxUint16
+				}
+			case gltfShort:
+				f = float32(int16(le.Uint16(b)))
+				if a.Normalized {
+					f = float32(math.Max(float64(f)/math.MaxInt16, -1))
+				}
+			case gltfUByte:
+				f = float32(b[0])
+				if a.Normalized {
+					f /= math.MaxUint8
+				}
+			case gltfByte:
+				f = float32(int8(b[0]))
+				if a.Normalized {
+					f = float32(math.Max(float64(f)/math.MaxInt8, -1))
+				}
+			}
+			v[i*n+c] = f
+		}
+	}
+	return v, n, nil
+}
+
+// indices reads the data of the accessor at the given index as vertex
+// indices, which must be unsigned integer scalars. They are read as integers
+// (not via float32, which cannot represent every uint32).
+func (l *gltfLoader) indices(index int) ([]uint32, error) {
+	a, data, stride, _, n, err := l.accessorData(index)
+	if err != nil {
+		return nil, err
+	}
+	if n != 1 {
+		return nil, errors.New("model: glTF indices must be scalars")
+	}
+	if a.ComponentType != gltfUByte && a.ComponentType != gltfUShort && a.ComponentType != gltfUInt {
+		return nil, errors.New("model: glTF indices must be unsigned integers")
+	}
+	v := make([]uint32, a.Count)
+	if data == nil {
+		// No buffer view means all zeros.
+		return v, nil
+	}
+	le := binary.LittleEndian
+	for i := range v {
+		b := data[a.ByteOffset+i*stride:]
+		switch a.ComponentType {
+		case gltfUInt:
+			v[i] = le.Uint32(b)
+		case gltfUShort:
+			v[i] = uint32(le.Uint16(b))
+		case gltfUByte:
+			v[i] = uint32(b[0])
+		}
+	}
+	return v, nil
+}
+
+// bufferView returns the data and byte stride of the buffer view at the given
+// index.
+func (l *gltfLoader) bufferView(index int) (data []byte, stride int, err error) {
+	if index < 0 || index >= len(l.doc.BufferViews) {
+		return nil, 0, fmt.Errorf("model: invalid glTF buffer view %d", index)
+	}
+	bv := l.doc.BufferViews[index]
+	buf, err := l.buffer(bv.Buffer)
+	if err != nil {
+		return nil, 0, err
+	}
+	if bv.ByteOffset < 0 || bv.ByteLength < 0 || bv.ByteOffset+bv.ByteLength > len(buf) {
+		return nil, 0, fmt.Errorf("model: glTF buffer view %d out of range", index)
+	}
+	return buf[bv.ByteOffset : bv.ByteOffset+bv.ByteLength], bv.ByteStride, nil
+}
+
+// buffer returns the buffer at the given index, loading it if needed.
+func (l *gltfLoader) buffer(index int) ([]byte, error) {
+	if index < 0 || index >= len(l.doc.Buffers) {
+		return nil, fmt.Errorf("model: invalid glTF buffer %d", index)
+	}
+	if l.buffers[index] != nil {
+		return l.buffers[index], nil
+	}
+	b := l.doc.Buffers[index]
+	var (
+		data []byte
+		err  error
+	)
+	if b.URI == "" {
+		// The binary chunk of a .glb file.
+		if index != 0 || l.bin == nil {
+			return nil, fmt.Errorf("model: glTF buffer %d has no data", index)
+		}
+		data = l.bin
+	} else if data, err = l.uri(b.URI); err != nil {
+		return nil, err
+	}
+	if len(data) < b.ByteLength {
+		return nil, fmt.Errorf("model: glTF buffer %d is truncated", index)
+	}
+	l.buffers[index] = data
+	return data, nil
+}
+
+// uri returns the data referenced by the given URI, which is either a base64
+// data URI or a file path relative to the model's directory.
+func (l *gltfLoader) uri(uri string) ([]byte, error) {
+	if strings.HasPrefix(uri, "data:") {
+		i := strings.Index(uri, ";base64,")
+		if i < 0 {
+			return nil, errors.New("model: unsupported glTF data URI")
+		}
+		return base64.StdEncoding.DecodeString(uri[i+len(";base64,"):])
+	}
+	path, err := url.PathUnescape(uri)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(filepath.Join(l.dir, filepath.FromSlash(path)))
+}
@@ -0,0 +1,183 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package model
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/lmath"
+)
+
+// testGLTFBuffer returns the binary buffer of the test model: a triangle's
+// positions (three float32 VEC3s) followed by it's indices (three uint16s).
+func testGLTFBuffer() []byte {
+	buf := new(bytes.Buffer)
+	for _, v := range []float32{1, 0, 0, 0, 1, 0, 0, 0, 1} {
+		binary.Write(buf, binary.LittleEndian, v)
+	}
+	for _, i := range []uint16{0, 1, 2} {
+		binary.Write(buf, binary.LittleEndian, i)
+	}
+	return buf.Bytes()
+}
+
+// testGLTF returns the JSON document of the test model, whose buffer has the
+// given URI. The triangle is the mesh of a child node, which is rotated 90
+// degrees about the (Y-up) Y axis, of a root node which is moved up by 2.
+func testGLTF(uri string) string {
+	s := math.Sqrt(0.5)
+	return fmt.Sprintf(`{
+	"asset": {"version": "2.0"},
+	"scene": 0,
+	"scenes": [{"nodes": [0]}],
+	"nodes": [
+		{"name": "root", "translation": [0, 2, 0], "children": [1]},
+		{"name": "child", "rotation": [0, %v, 0, %v], "mesh": 0}
+	],
+	"meshes": [{"primitives": [{
+		"attributes": {"POSITION": 0},
+		"indices": 1,
+		"material": 0
+	}]}],
+	"materials": [{
+		"pbrMetallicRoughness": {"baseColorFactor": [1, 0, 0, 0.5]},
+		"alphaMode": "BLEND",
+		"doubleSided": true
+	}],
+	"accessors": [
+		{"bufferView": 0, "componentType": 5126, "count": 3, "type": "VEC3"},
+		{"bufferView": 1, "componentType": 5123, "count": 3, "type": "SCALAR"}
+	],
+	"bufferViews": [
+		{"buffer": 0, "byteOffset": 0, "byteLength": 36},
+		{"buffer": 0, "byteOffset": 36, "byteLength": 6}
+	],
+	"buffers": [{"uri": %q, "byteLength": 42}]
+}`, s, s, uri)
+}
+
+func checkTestScene(t *testing.T, scene *Scene) {
+	if len(scene.Nodes) != 1 || len(scene.Objects) != 1 {
+		t.Fatal("expected a single root node and object")
+	}
+	root := scene.Nodes[0]
+	if root.Name != "root" || len(root.Children) != 1 || len(root.Children[0].Objects) != 1 {
+		t.Fatal("bad node hierarchy")
+	}
+	o := root.Children[0].Objects[0]
+	if o != scene.Objects[0] {
+		t.Fatal("expected the child's object in the scene objects")
+	}
+
+	m := o.Meshes[0]
+	if len(m.Vertices) != 3 || len(m.Indices) != 3 || m.Indices[2] != 2 {
+		t.Fatal("bad mesh data", m.Vertices, m.Indices)
+	}
+	if len(m.Colors) != 3 || m.Colors[0] != (gfx.Color{R: 1, A: 0.5}) {
+		t.Fatal("expected the base color factor as vertex colors, got", m.Colors)
+	}
+	if o.State.AlphaMode != gfx.AlphaBlend || o.State.FaceCulling != gfx.NoFaceCulling {
+		t.Fatal("expected an alpha blended, double-sided state")
+	}
+
+	// The vertex +X is rotated about the Y axis to -Z, moved up to Y=2, and
+	// then converted to Z-up.
+	world := o.Transform.ConvertPos(m.Vertices[0].Vec3(), gfx.LocalToWorld)
+	if want := (lmath.Vec3{X: 0, Y: 1, Z: 2}); !world.AlmostEquals(want, 1e-6) {
+		t.Fatal("got world position", world, "want", want)
+	}
+}
+
+func TestLoadGLTF(t *testing.T) {
+	uri := "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(testGLTFBuffer())
+	scene, err := LoadGLTF(strings.NewReader(testGLTF(uri)), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTestScene(t, scene)
+
+	// The same translation, as a (column-major) matrix.
+	doc := strings.Replace(testGLTF(uri), `"translation": [0, 2, 0]`, `"matrix": [1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 2, 0, 1]`, 1)
+	scene, err = LoadGLTF(strings.NewReader(doc), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTestScene(t, scene)
+}
+
+func TestLoadGLB(t *testing.T) {
+	// Remove the buffer URI, such that the binary chunk is used.
+	doc := strings.Replace(testGLTF(""), `"uri": "", `, "", 1)
+	for len(doc)%4 != 0 {
+		doc += " "
+	}
+	bin := testGLTFBuffer()
+	for len(bin)%4 != 0 {
+		bin = append(bin, 0)
+	}
+
+	glb := new(bytes.Buffer)
+	le := binary.LittleEndian
+	binary.Write(glb, le, []uint32{glbMagic, 2, uint32(12 + 8 + len(doc) + 8 + len(bin))})
+	binary.Write(glb, le, []uint32{uint32(len(doc)), glbChunkJSON})
+	glb.WriteString(doc)
+	binary.Write(glb, le, []uint32{uint32(len(bin)), glbChunkBIN})
+	glb.Write(bin)
+
+	scene, err := LoadGLTF(glb, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTestScene(t, scene)
+}
+
+func TestLoadGLTFErrors(t *testing.T) {
+	uri := "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(testGLTFBuffer())
+	tests := map[string]string{
+		"version": `{"asset": {"version": "1.0"}}`,
+		"sparse":  strings.Replace(testGLTF("x.bin"), `"type": "VEC3"`, `"type": "VEC3", "sparse": {}`, 1),
+		"missing": testGLTF("missing.bin"),
+		"count":   strings.Replace(testGLTF(uri), `"count": 3, "type": "VEC3"`, `"count": -1, "type": "VEC3"`, 1),
+		"offset":  strings.Replace(testGLTF(uri), `"componentType": 5126`, `"byteOffset": -4, "componentType": 5126`, 1),
+		"indices": strings.Replace(testGLTF(uri), `"componentType": 5123`, `"componentType": 5126`, 1),
+	}
+	for name, doc := range tests {
+		if _, err := LoadGLTF(strings.NewReader(doc), t.TempDir()); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestGLTFIndicesUInt(t *testing.T) {
+	// 16777217 (2^24 + 1) is not representable as a float32.
+	bin := make([]byte, 8)
+	binary.LittleEndian.PutUint32(bin, 16777217)
+	binary.LittleEndian.PutUint32(bin[4:], math.MaxUint32)
+	l := &gltfLoader{bin: bin}
+	err := l.decode([]byte(`{
+	"asset": {"version": "2.0"},
+	"accessors": [{"bufferView": 0, "componentType": 5125, "count": 2, "type": "SCALAR"}],
+	"bufferViews": [{"buffer": 0, "byteLength": 8}],
+	"buffers": [{"byteLength": 8}]
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.buffers = make([][]byte, 1)
+	indices, err := l.indices(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indices) != 2 || indices[0] != 16777217 || indices[1] != math.MaxUint32 {
+		t.Fatal("got indices", indices)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+// Material is a basic material, as described by a Wavefront MTL material
+// library. Only the properties which map onto the gfx package are kept.
+type Material struct {
+	// The name of the material.
+	Name string
+
+	// The diffuse color of the material (Kd), which is white by default.
+	Diffuse gfx.Color
+
+	// The opacity of the material (d, or 1-Tr), which is one by default.
+	Dissolve float32
+
+	// The path to the diffuse texture of the material (map_Kd), relative to
+	// the material library, if any.
+	DiffuseMap string
+}
+
+// State returns a new graphics state suitable for drawing objects using the
+// material: transparent materials (i.e. with a Dissolve < 1) use alpha
+// blending.
+func (m *Material) State() *gfx.State {
+	s := gfx.NewState()
+	if m.Dissolve < 1 {
+		s.AlphaMode = gfx.AlphaBlend
+	}
+	return s
+}
+
+// ParseMTL parses the Wavefront MTL material library from r, and returns the
+// materials it describes by name.
+func ParseMTL(r io.Reader) (map[string]*Material, error) {
+	var (
+		materials = make(map[string]*Material)
+		current   *Material
+		scanner   = bufio.NewScanner(r)
+		lineNum   int
+	)
+	for scanner.Scan() {
+		lineNum++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if fields[0] == "newmtl" {
+			current = &Material{
+				Name:     strings.Join(fields[1:], " "),
+				Diffuse:  gfx.Color{R: 1, G: 1, B: 1, A: 1},
+				Dissolve: 1,
+			}
+			materials[current.Name] = current
+			continue
+		}
+		if current == nil {
+			// Properties before the first material are ignored.
+			continue
+		}
+
+		floats, err := parseFloats(fields[1:])
+		switch fields[0] {
+		case "Kd":
+			if err != nil || len(floats) < 3 {
+				return nil, fmt.Errorf("model: mtl line %d: invalid diffuse color", lineNum)
+			}
+			current.Diffuse = gfx.Color{R: floats[0], G: floats[1], B: floats[2], A: 1}
+		case "d":
+			if err != nil || len(floats) < 1 {
+				return nil, fmt.Errorf("model: mtl line %d: invalid dissolve", lineNum)
+			}
+			current.Dissolve = floats[0]
+		case "Tr":
+			if err != nil || len(floats) < 1 {
+				return nil, fmt.Errorf("model: mtl line %d: invalid transparency", lineNum)
+			}
+			current.Dissolve = 1 - floats[0]
+		case "map_Kd":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("model: mtl line %d: missing diffuse map", lineNum)
+			}
+			// Options (e.g. -bm 1) precede the file name, which is last.
+			current.DiffuseMap = fields[len(fields)-1]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return materials, nil
+}
+
+// parseFloats parses each of the given fields as a float32.
+func parseFloats(fields []string) ([]float32, error) {
+	floats := make([]float32, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(f, 32)
+		if err != nil {
+			return nil, err
+		}
+		floats[i] = float32(v)
+	}
+	return floats, nil
+}
@@ -0,0 +1,327 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/qmcloud/engine/gfx"
+	"github.com/qmcloud/engine/gfx/gfxutil"
+)
+
+// LoadOBJ loads the Wavefront OBJ model from r. One object is returned for
+// each object (o), group (g), or material (usemtl) section of the model that
+// has faces, each object has a single indexed triangle mesh. Polygons are
+// triangulated, and the separate position, texture coordinate, and normal
+// indices of the OBJ format are resolved into unified per-vertex data.
+//
+// The meshes have texture coordinates and normals only if the model specifies
+// them. Lines, points, and curves are not supported and are ignored.
+//
+// Material libraries (mtllib) are separate files, and as such are ignored by
+// LoadOBJ, see LoadOBJFile instead.
+func LoadOBJ(r io.Reader) ([]*gfx.Object, error) {
+	l := &objLoader{}
+	return l.load(r)
+}
+
+// LoadOBJFile loads the named Wavefront OBJ model file, like LoadOBJ, but also
+// loads it's material libraries (mtllib) and the textures they reference,
+// relative to the directory of the model file.
+//
+// The objects of each material are given:
+//
+//	Vertex colors from the material's diffuse color (Kd).
+//	A State from the material's State method.
+//	The material's diffuse texture (map_Kd), loaded via gfxutil.OpenTexture.
+//
+// As usual, you will also need to import image decoders for the textures,
+// e.g. for png:
+//
+//	import _ "image/png"
+func LoadOBJFile(path string) ([]*gfx.Object, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	l := &objLoader{
+		dir:       filepath.Dir(path),
+		files:     true,
+		materials: make(map[string]*Material),
+		textures:  make(map[string]*gfx.Texture),
+	}
+	return l.load(f)
+}
+
+// objVertex is a unique combination of position, texture coordinate, and
+// normal indices (zero-based, or -1 if absent) referenced by a face.
+type objVertex struct {
+	v, vt, vn int
+}
+
+// objLoader loads a single OBJ model.
+type objLoader struct {
+	// The directory of the model file, whether or not files (material
+	// libraries and textures) may be opened, and the ones already opened.
+	dir       string
+	files     bool
+	materials map[string]*Material
+	textures  map[string]*gfx.Texture
+
+	// The data of the model, referenced by faces.
+	positions, normals []gfx.Vec3
+	texCoords          []gfx.TexCoord
+
+	// The objects built so far, and the one being built.
+	objects                  []*gfx.Object
+	material                 *Material
+	vertices                 map[objVertex]uint32
+	mesh                     *gfx.Mesh
+	meshTexCoords            []gfx.TexCoord
+	hasTexCoords, hasNormals bool
+}
+
+// load loads the model from r.
+func (l *objLoader) load(r io.Reader) ([]*gfx.Object, error) {
+	var (
+		scanner = bufio.NewScanner(r)
+		lineNum int
+	)
+	for scanner.Scan() {
+		lineNum++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if err := l.statement(fields); err != nil {
+			return nil, fmt.Errorf("model: obj line %d: %v", lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := l.flush(); err != nil {
+		return nil, err
+	}
+	return l.objects, nil
+}
+
+// statement handles a single statement of the model.
+func (l *objLoader) statement(fields []string) error {
+	switch fields[0] {
+	case "v", "vn":
+		f, err := parseFloats(fields[1:])
+		if err != nil || len(f) < 3 {
+			return fmt.Errorf("invalid %s statement", fields[0])
+		}
+		// Convert from Y-up to Z-up.
+		v := gfx.Vec3{X: f[0], Y: -f[2], Z: f[1]}
+		if fields[0] == "v" {
+			l.positions = append(l.positions, v)
+		} else {
+			l.normals = append(l.normals, v)
+		}
+
+	case "vt":
+		f, err := parseFloats(fields[1:])
+		if err != nil || len(f) < 1 {
+			return fmt.Errorf("invalid vt statement")
+		}
+		tc := gfx.TexCoord{U: f[0], V: 1}
+		if len(f) > 1 {
+			// Convert from a bottom-left to a top-left origin.
+			tc.V = 1 - f[1]
+		}
+		l.texCoords = append(l.texCoords, tc)
+
+	case "f":
+		if len(fields) < 4 {
+			return fmt.Errorf("face has less than three vertices")
+		}
+		indices := make([]uint32, len(fields)-1)
+		for i, field := range fields[1:] {
+			v, err := l.parseVertex(field)
+			if err != nil {
+				return err
+			}
+			indices[i] = l.vertex(v)
+		}
+
+		// Triangulate the polygon as a fan.
+		for i := 1; i+1 < len(indices); i++ {
+			l.mesh.Indices = append(l.mesh.Indices, indices[0], indices[i], indices[i+1])
+		}
+
+	case "o", "g":
+		return l.flush()
+
+	case "usemtl":
+		if err := l.flush(); err != nil {
+			return err
+		}
+		l.material = nil
+		if l.files && len(fields) > 1 {
+			l.material = l.materials[strings.Join(fields[1:], " ")]
+		}
+
+	case "mtllib":
+		if !l.files {
+			return nil
+		}
+		for _, name := range fields[1:] {
+			if err := l.loadMTL(name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseVertex parses a single face vertex, in one of the forms v, v/vt, v//vn,
+// or v/vt/vn.
+func (l *objLoader) parseVertex(field string) (objVertex, error) {
+	parts := strings.Split(field, "/")
+	if len(parts) > 3 {
+		return objVertex{}, fmt.Errorf("invalid face vertex %q", field)
+	}
+	v := objVertex{v: -1, vt: -1, vn: -1}
+	lens := [3]int{len(l.positions), len(l.texCoords), len(l.normals)}
+	dsts := [3]*int{&v.v, &v.vt, &v.vn}
+	for i, part := range parts {
+		if part == "" {
+			if i == 0 {
+				return objVertex{}, fmt.Errorf("invalid face vertex %q", field)
+			}
+			continue
+		}
+		index, err := strconv.Atoi(part)
+		if err != nil {
+			return objVertex{}, fmt.Errorf("invalid face vertex %q", field)
+		}
+
+		// Indices are one-based, negative ones are relative to the end.
+		if index < 0 {
+			index += lens[i]
+		} else {
+			index--
+		}
+		if index < 0 || index >= lens[i] {
+			return objVertex{}, fmt.Errorf("face vertex %q out of range", field)
+		}
+		*dsts[i] = index
+	}
+	return v, nil
+}
+
+// vertex returns the index of the given face vertex in the mesh being built,
+// adding it to the mesh if needed.
+func (l *objLoader) vertex(v objVertex) uint32 {
+	if l.mesh == nil {
+		l.mesh = gfx.NewMesh()
+		l.vertices = make(map[objVertex]uint32)
+	}
+	if index, ok := l.vertices[v]; ok {
+		return index
+	}
+	index := uint32(len(l.mesh.Vertices))
+	l.vertices[v] = index
+
+	l.mesh.Vertices = append(l.mesh.Vertices, l.positions[v.v])
+	var (
+		tc     gfx.TexCoord
+		normal gfx.Vec3
+	)
+	if v.vt >= 0 {
+		tc = l.texCoords[v.vt]
+		l.hasTexCoords = true
+	}
+	if v.vn >= 0 {
+		normal = l.normals[v.vn]
+		l.hasNormals = true
+	}
+	l.meshTexCoords = append(l.meshTexCoords, tc)
+	l.mesh.Normals = append(l.mesh.Normals, normal)
+	if l.material != nil {
+		l.mesh.Colors = append(l.mesh.Colors, l.material.Diffuse)
+	}
+	return index
+}
+
+// flush finishes building the current object, if it has any faces.
+func (l *objLoader) flush() error {
+	m := l.mesh
+	l.mesh = nil
+	l.vertices = nil
+	texCoords := l.meshTexCoords
+	l.meshTexCoords = nil
+	hasTexCoords, hasNormals := l.hasTexCoords, l.hasNormals
+	l.hasTexCoords, l.hasNormals = false, false
+	if m == nil || len(m.Indices) == 0 {
+		return nil
+	}
+
+	if hasTexCoords {
+		m.TexCoords = []gfx.TexCoordSet{{Slice: texCoords}}
+	}
+	if !hasNormals {
+		m.Normals = nil
+	}
+	o := gfx.NewObject()
+	o.Meshes = []*gfx.Mesh{m}
+	if l.material != nil {
+		o.State = l.material.State()
+		if l.material.DiffuseMap != "" {
+			tex, err := l.texture(l.material.DiffuseMap)
+			if err != nil {
+				return err
+			}
+			o.Textures = []*gfx.Texture{tex}
+		}
+	}
+	l.objects = append(l.objects, o)
+	return nil
+}
+
+// loadMTL loads the named material library.
+func (l *objLoader) loadMTL(name string) error {
+	f, err := os.Open(filepath.Join(l.dir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	materials, err := ParseMTL(f)
+	if err != nil {
+		return err
+	}
+	for _, m := range materials {
+		// Textures are relative to the material library, make them relative
+		// to the model file instead.
+		if m.DiffuseMap != "" {
+			m.DiffuseMap = filepath.Join(filepath.Dir(name), m.DiffuseMap)
+		}
+		l.materials[m.Name] = m
+	}
+	return nil
+}
+
+// texture returns the named texture, opening it if it was not already.
+func (l *objLoader) texture(name string) (*gfx.Texture, error) {
+	if tex, ok := l.textures[name]; ok {
+		return tex, nil
+	}
+	tex, err := gfxutil.OpenTexture(filepath.Join(l.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	l.textures[name] = tex
+	return tex, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package model
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/qmcloud/engine/gfx"
+)
+
+// testOBJ is a unit quad (as a single polygon) with texture coordinates and
+// normals, followed by a group with a triangle without them.
+const testOBJ = `# Test model.
+mtllib test.mtl
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+vt 0 0
+vt 1 0
+vt 1 1
+vt 0 1
+vn 0 0 1
+o quad
+usemtl red
+f 1/1/1 2/2/1 3/3/1 4/4/1
+g triangle
+usemtl glass
+f -4 -3 -2
+`
+
+const testMTL = `newmtl red
+Kd 1 0 0
+map_Kd red.png
+
+newmtl glass
+d 0.5
+`
+
+func TestLoadOBJ(t *testing.T) {
+	objects, err := LoadOBJ(strings.NewReader(testOBJ))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 2 {
+		t.Fatal("expected 2 objects, got", len(objects))
+	}
+
+	// The quad is triangulated, and it's vertices are unified.
+	quad := objects[0].Meshes[0]
+	if len(quad.Vertices) != 4 || len(quad.Indices) != 6 {
+		t.Fatal("expected 4 vertices and 6 indices, got", len(quad.Vertices), len(quad.Indices))
+	}
+	if len(quad.TexCoords) != 1 || len(quad.Normals) != 4 || quad.Colors != nil {
+		t.Fatal("expected texture coordinates and normals, but no colors")
+	}
+
+	// Positions and normals are converted to Z-up, and texture coordinates to
+	// a top-left origin.
+	if v := quad.Vertices[2]; v != (gfx.Vec3{X: 1, Y: 0, Z: 1}) {
+		t.Fatal("got vertex", v)
+	}
+	if n := quad.Normals[0]; n != (gfx.Vec3{X: 0, Y: -1, Z: 0}) {
+		t.Fatal("got normal", n)
+	}
+	if tc := quad.TexCoords[0].Slice[0]; tc != (gfx.TexCoord{U: 0, V: 1}) {
+		t.Fatal("got texture coordinate", tc)
+	}
+
+	// The triangle uses relative indices, and has only positions.
+	tri := objects[1].Meshes[0]
+	if len(tri.Vertices) != 3 || tri.TexCoords != nil || tri.Normals != nil {
+		t.Fatal("expected a triangle with only positions")
+	}
+	if tri.Vertices[0] != quad.Vertices[0] {
+		t.Fatal("relative index resolved to", tri.Vertices[0])
+	}
+}
+
+func TestLoadOBJErrors(t *testing.T) {
+	tests := []string{
+		"v 0 0\n",
+		"v 0 0 0\nf 1 1\n",
+		"v 0 0 0\nf 1 2 3\n",
+		"v 0 0 0\nf 1/x 1 1\n",
+	}
+	for _, src := range tests {
+		if _, err := LoadOBJ(strings.NewReader(src)); err == nil {
+			t.Errorf("LoadOBJ(%q): expected error", src)
+		}
+	}
+}
+
+func TestLoadOBJFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"test.obj": testOBJ,
+		"test.mtl": testMTL,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	img, err := os.Create(filepath.Join(dir, "red.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(img, image.NewNRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatal(err)
+	}
+	img.Close()
+
+	objects, err := LoadOBJFile(filepath.Join(dir, "test.obj"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	quad, glass := objects[0], objects[1]
+	if len(quad.Textures) != 1 || quad.Textures[0].Bounds != image.Rect(0, 0, 4, 4) {
+		t.Fatal("expected the quad to have the red texture")
+	}
+	if c := quad.Meshes[0].Colors[0]; c != (gfx.Color{R: 1, A: 1}) {
+		t.Fatal("got quad color", c)
+	}
+	if glass.State == nil || glass.State.AlphaMode != gfx.AlphaBlend {
+		t.Fatal("expected the glass to use alpha blending")
+	}
+	if quad.State.AlphaMode == gfx.AlphaBlend {
+		t.Fatal("expected the quad to be opaque")
+	}
+}
@@ -7,6 +7,7 @@ package gfx
 import (
 	"image"
 	"sync"
+	"time"
 
 	"github.com/qmcloud/engine/gfx/clock"
 )
@@ -45,6 +46,14 @@ type nilDevice struct {
 		enabled bool
 	}
 
+	// The auto-clear state.
+	autoClear struct {
+		sync.RWMutex
+		enabled bool
+		bg      Color
+		depth   float64
+	}
+
 	precision Precision
 
 	// The graphics clock.
@@ -65,11 +74,18 @@ func (n *nilDevice) Precision() Precision {
 
 func (n *nilDevice) Info() DeviceInfo {
 	return DeviceInfo{
-		MaxTextureSize:  8096,
-		AlphaToCoverage: true,
-		OcclusionQuery:  false,
+		MaxTextureSize:               8096,
+		MaxVertexAttribs:             16,
+		MaxTextureImageUnits:         16,
+		MaxCombinedTextureImageUnits: 32,
+		Uint32Indices:                true,
+		AlphaToCoverage:              true,
+		OcclusionQuery:               false,
 	}
 }
+func (n *nilDevice) Stats() Stats {
+	return Stats{}
+}
 func (n *nilDevice) Download(r image.Rectangle, complete chan image.Image) {
 	complete <- nil
 }
@@ -84,14 +100,47 @@ func (n *nilDevice) MSAA() (msaa bool) {
 	n.msaa.RUnlock()
 	return
 }
+func (n *nilDevice) SetAutoClear(enabled bool) {
+	n.autoClear.Lock()
+	n.autoClear.enabled = enabled
+	n.autoClear.Unlock()
+}
+func (n *nilDevice) AutoClear() (enabled bool) {
+	n.autoClear.RLock()
+	enabled = n.autoClear.enabled
+	n.autoClear.RUnlock()
+	return
+}
+func (n *nilDevice) SetClearColor(bg Color, depth float64) {
+	n.autoClear.Lock()
+	n.autoClear.bg = bg
+	n.autoClear.depth = depth
+	n.autoClear.Unlock()
+}
+func (n *nilDevice) ClearColor() (bg Color, depth float64) {
+	n.autoClear.RLock()
+	bg, depth = n.autoClear.bg, n.autoClear.depth
+	n.autoClear.RUnlock()
+	return
+}
 func (n *nilDevice) Clear(r image.Rectangle, bg Color)           {}
 func (n *nilDevice) ClearDepth(r image.Rectangle, depth float64) {}
 func (n *nilDevice) ClearStencil(r image.Rectangle, stencil int) {}
+func (n *nilDevice) ClearRects(rects []ClearRect)                {}
 func (n *nilDevice) Draw(r image.Rectangle, o *Object, c Camera) {
+	if r.Empty() || o.Hidden {
+		return
+	}
 	o.Bounds()
 	o.NativeObject = nilNativeObject{}
 }
+func (n *nilDevice) Blit(dst Canvas, src, dstRect image.Rectangle, filter TexFilter) error {
+	return nil
+}
 func (n *nilDevice) QueryWait() {}
+func (n *nilDevice) QueryPoll() (pending int) {
+	return 0
+}
 func (n *nilDevice) Render() {
 	n.clock.Tick()
 }
@@ -116,8 +165,22 @@ func (n *nilDevice) LoadTexture(t *Texture, done chan *Texture) {
 	default:
 	}
 }
+func (n *nilDevice) UpdateStream(t *StreamTexture, done chan *StreamTexture) {
+	t.Loaded = true
+	t.ClearData()
+	if t.NativeTexture == nil {
+		t.NativeTexture = nilNativeTexture{
+			RGBA,
+		}
+	}
+	select {
+	case done <- t:
+	default:
+	}
+}
 func (n *nilDevice) LoadShader(s *Shader, done chan *Shader) {
 	s.Loaded = true
+	s.Changed = false
 	s.ClearData()
 	s.NativeShader = nilNativeShader{}
 	select {
@@ -130,6 +193,17 @@ func (n *nilDevice) RenderToTexture(cfg RTTConfig) Canvas {
 	return nil
 }
 
+// nilFence is always signaled, as the nil device does not draw anything.
+type nilFence struct{}
+
+func (f nilFence) Wait(timeout time.Duration) bool {
+	return true
+}
+
+func (n *nilDevice) Fence() Fence {
+	return nilFence{}
+}
+
 // Nil returns a device that does not actually draw anything.
 func Nil() Device {
 	r := new(nilDevice)
@@ -142,6 +216,7 @@ func Nil() Device {
 		StencilBits: 255,
 	}
 	r.msaa.enabled = true
+	r.autoClear.depth = 1.0
 	r.clock = clock.New()
 	return r
 }
@@ -38,3 +38,10 @@ func TestNilDevice(t *testing.T) {
 		d.Render()
 	}
 }
+
+func TestNilDeviceFence(t *testing.T) {
+	f := Nil().Fence()
+	if !f.Wait(0) {
+		t.Fatal("expected the fence of a nil device to be signaled")
+	}
+}
@@ -55,6 +55,12 @@ type Object struct {
 	// SampleCount() method of NativeObject.
 	OcclusionTest bool
 
+	// Whether or not this object is hidden. Hidden objects are ignored by
+	// canvases when drawn (see Canvas.Draw), which allows toggling the
+	// visibility of an object without removing it from e.g. a list of objects
+	// to draw.
+	Hidden bool
+
 	// The render state of this object.
 	*State
 
@@ -71,6 +77,11 @@ type Object struct {
 	// This is a slice specifically to allow device implementations to optimize
 	// the number of draw calls that must occur to draw consecutively listed
 	// meshes.
+	//
+	// A mesh may be shared by multiple objects (e.g. objects that differ only
+	// in their textures or state). The mesh is loaded by the device only once,
+	// when the first of those objects is drawn, and is reused by the others
+	// until it changes (see Mesh.HasChanged).
 	Meshes []*Mesh
 
 	// A slice of textures which are used to texture the meshes of this object.
@@ -78,6 +89,10 @@ type Object struct {
 	// in which they are sent to the graphics card.
 	Textures []*Texture
 
+	// The skeleton used to animate (skin) the meshes of this object, or nil if
+	// the object is not animated. See the Skeleton type for details.
+	Skeleton *Skeleton
+
 	// CachedBounds represents the pre-calculated cached bounding box of this
 	// object. Note that the bounds are only calculated once Object.Bounds() is
 	// invoked.
@@ -91,10 +106,16 @@ type Object struct {
 	//
 	// And then simply invoke o.Bounds() again to calculate the bounds again.
 	CachedBounds *lmath.Rect3
+
+	// UserData is arbitrary application data associated with this object
+	// (e.g. an entity ID or material handle). It is never used by this
+	// package or by devices, and it does not participate in Compare.
+	UserData interface{}
 }
 
 // Bounds implements the Boundable interface. The returned bounding box takes
 // into account all of the mesh's bounding boxes, transformed into world space.
+// It is identical to WorldBounds.
 //
 // The bounding box is cached (see o.CachedBounds) so that multiple calls to
 // this method are fast. If you make changes to the vertices, or add/remove
@@ -106,38 +127,98 @@ type Object struct {
 // You do not need to clear the cached bounds if the transform of the object
 // has changed (as it is applied after calculation of the bounding box).
 func (o *Object) Bounds() lmath.Rect3 {
-	var b lmath.Rect3
+	return lmath.Rect3(o.WorldBounds())
+}
+
+// LocalBounds returns the axis-aligned bounding box of all of the object's
+// meshes in the local space of the object, i.e. without the object's
+// transform applied. It is cached in the same way as Bounds is.
+func (o *Object) LocalBounds() Bounds {
 	// Do we have a cached bounding box? If so, use it.
 	if o.CachedBounds != nil {
-		b = *o.CachedBounds
-	} else {
-		// Calculate the bounding box then.
-		for i, m := range o.Meshes {
-			if i == 0 {
-				b = m.Bounds()
-			} else {
-				b = b.Union(m.Bounds())
-			}
-		}
+		return Bounds(*o.CachedBounds)
+	}
 
-		// Make a copy of the untransformed bounding box and cache it for
-		// later. We don't cache the transformed bounding box because otherwise
-		// we would need to recalculate the bounding box every time the object
-		// is moved, scaled, etc.
-		cpy := b
-		o.CachedBounds = &cpy
+	// Calculate the bounding box then.
+	var b lmath.Rect3
+	for i, m := range o.Meshes {
+		if i == 0 {
+			b = m.Bounds()
+		} else {
+			b = b.Union(m.Bounds())
+		}
 	}
+
+	// Make a copy of the untransformed bounding box and cache it for later. We
+	// don't cache the transformed bounding box because otherwise we would need
+	// to recalculate the bounding box every time the object is moved, scaled,
+	// etc.
+	cpy := b
+	o.CachedBounds = &cpy
+	return Bounds(b)
+}
+
+// WorldCorners returns the eight corners of the object's oriented bounding
+// box in world space, i.e. the corners of LocalBounds with the object's full
+// transform (including rotation, and that of it's parents) applied.
+func (o *Object) WorldCorners() [8]lmath.Vec3 {
+	corners := lmath.Rect3(o.LocalBounds()).Corners()
 	if o.Transform != nil {
-		b.Min = o.Transform.ConvertPos(b.Min, LocalToWorld)
-		b.Max = o.Transform.ConvertPos(b.Max, LocalToWorld)
-		b = b.Union(b)
+		for i, c := range corners {
+			corners[i] = o.Transform.ConvertPos(c, LocalToWorld)
+		}
 	}
-	return b
+	return corners
+}
+
+// WorldBounds returns the axis-aligned bounding box, in world space, which
+// encloses the oriented bounding box of the object (see WorldCorners). Unlike
+// simply transforming the minimum and maximum of LocalBounds, rotation of the
+// object (or of it's parents) never causes the box to be too small.
+func (o *Object) WorldBounds() Bounds {
+	if o.Transform == nil {
+		return o.LocalBounds()
+	}
+	var b lmath.Rect3
+	for i, c := range o.WorldCorners() {
+		if i == 0 {
+			b = lmath.Rect3{Min: c, Max: c}
+			continue
+		}
+		b = b.Union(lmath.Rect3{Min: c, Max: c})
+	}
+	return Bounds(b)
+}
+
+// SetParent sets the parent object of this object, such that the world
+// transform of this object is derived from the parent object's. It is short
+// hand for:
+//
+//	o.Transform.SetParent(p.Transform)
+//
+// A nil parent (or one without a transform) makes this object relative to
+// the world again. Moving the parent later on automatically moves this object
+// as well. If the parent (or any of it's parents) is this object, then a panic
+// occurs as the hierarchy would contain a cycle.
+//
+// If this object has no transform, a new one is allocated for it (unless the
+// object is being made relative to the world, which it already is).
+func (o *Object) SetParent(p *Object) {
+	if p == nil || p.Transform == nil {
+		if o.Transform != nil {
+			o.Transform.SetParent(nil)
+		}
+		return
+	}
+	if o.Transform == nil {
+		o.Transform = NewTransform()
+	}
+	o.Transform.SetParent(p.Transform)
 }
 
 // Compare compares this object's state (including shader and textures) against
 // the other one and determines if it should sort before the other one for
-// state sorting purposes.
+// state sorting purposes. The UserData of the objects is not compared.
 func (o *Object) Compare(other *Object) bool {
 	if o == other {
 		return true
@@ -162,18 +243,25 @@ func (o *Object) Compare(other *Object) bool {
 // Copy returns a new copy of this Object. Explicitily not copied is the native
 // object. The transform is copied via it's Copy() method.
 //
-// The state, shader, meshes, and textures are all shallow copies only (i.e.
-// only the pointer values are copied).
+// The state, shader, meshes, textures, skeleton, and user data are all shallow
+// copies only (i.e. only the pointer values are copied).
 func (o *Object) Copy() *Object {
-	cpyCachedBounds := *o.CachedBounds
+	var cpyCachedBounds *lmath.Rect3
+	if o.CachedBounds != nil {
+		b := *o.CachedBounds
+		cpyCachedBounds = &b
+	}
 	cpy := &Object{
 		OcclusionTest: o.OcclusionTest,
+		Hidden:        o.Hidden,
 		State:         o.State,
 		Transform:     o.Transform.Copy(),
 		Shader:        o.Shader,
 		Meshes:        make([]*Mesh, len(o.Meshes)),
 		Textures:      make([]*Texture, len(o.Textures)),
-		CachedBounds:  &cpyCachedBounds,
+		Skeleton:      o.Skeleton,
+		CachedBounds:  cpyCachedBounds,
+		UserData:      o.UserData,
 	}
 	copy(cpy.Meshes, o.Meshes)
 	copy(cpy.Textures, o.Textures)
@@ -184,10 +272,13 @@ func (o *Object) Copy() *Object {
 func (o *Object) Reset() {
 	o.NativeObject = nil
 	o.OcclusionTest = false
+	o.Hidden = false
 	o.State = nil
 	o.Transform = NewTransform()
 	o.Shader = nil
+	o.Skeleton = nil
 	o.CachedBounds = nil
+	o.UserData = nil
 
 	// Nil out each mesh pointer.
 	for i := 0; i < len(o.Meshes); i++ {
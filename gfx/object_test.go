@@ -0,0 +1,126 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/lmath"
+)
+
+func TestObjectBoundsRotated(t *testing.T) {
+	m := NewMesh()
+	m.Vertices = []Vec3{
+		{-1, -1, -1},
+		{1, 1, 1},
+	}
+
+	parent := NewObject()
+	parent.SetPos(lmath.Vec3{0, 0, 10})
+
+	o := NewObject()
+	o.Meshes = []*Mesh{m}
+	o.SetParent(parent)
+	o.SetScale(lmath.Vec3{2, 1, 1})
+	o.SetRot(lmath.Vec3{0, 0, 90})
+
+	// Rotated 90 degrees about Z, the X scale now lies on the Y axis.
+	want := lmath.Rect3{
+		Min: lmath.Vec3{-1, -2, 9},
+		Max: lmath.Vec3{1, 2, 11},
+	}
+	if b := o.Bounds(); !b.AlmostEquals(want, 1e-9) {
+		t.Log("got", b)
+		t.Log("want", want)
+		t.Fail()
+	}
+}
+
+func TestObjectLocalWorldBounds(t *testing.T) {
+	m := NewMesh()
+	m.Vertices = []Vec3{
+		{0, 0, 0},
+		{2, 1, 1},
+	}
+
+	o := NewObject()
+	o.Meshes = []*Mesh{m}
+	o.SetPos(lmath.Vec3{5, 0, 0})
+	o.SetRot(lmath.Vec3{0, 0, 90})
+
+	local := lmath.Rect3{Max: lmath.Vec3{2, 1, 1}}
+	if b := lmath.Rect3(o.LocalBounds()); !b.AlmostEquals(local, 1e-9) {
+		t.Fatalf("LocalBounds() = %v, want %v", b, local)
+	}
+
+	// Every oriented corner must lie within the world bounds.
+	world := lmath.Rect3(o.WorldBounds())
+	for _, c := range o.WorldCorners() {
+		if !world.Contains(c) && !world.Union(lmath.Rect3{Min: c, Max: c}).AlmostEquals(world, 1e-9) {
+			t.Fatalf("corner %v outside of WorldBounds() %v", c, world)
+		}
+	}
+	if b := o.Bounds(); !b.AlmostEquals(world, 1e-9) {
+		t.Fatalf("Bounds() = %v, want WorldBounds() %v", b, world)
+	}
+	want := lmath.Rect3{
+		Min: lmath.Vec3{4, 0, 0},
+		Max: lmath.Vec3{5, 2, 1},
+	}
+	if !world.AlmostEquals(want, 1e-9) {
+		t.Fatalf("WorldBounds() = %v, want %v", world, want)
+	}
+}
+
+func TestObjectParentCycle(t *testing.T) {
+	a := NewObject()
+	b := NewObject()
+	b.SetParent(a)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	a.SetParent(b)
+}
+
+func TestObjectSetParentNoTransform(t *testing.T) {
+	o := &Object{}
+	o.SetParent(nil)
+	if o.Transform != nil {
+		t.Fatal("SetParent(nil) allocated a transform")
+	}
+
+	parent := NewObject()
+	parent.Transform.SetPos(lmath.Vec3{1, 2, 3})
+	o.SetParent(parent)
+	if o.Transform == nil {
+		t.Fatal("SetParent did not allocate a transform")
+	}
+	if o.Transform.Parent() != parent.Transform {
+		t.Fatal("got parent", o.Transform.Parent(), "want", parent.Transform)
+	}
+}
+
+func TestObjectUserData(t *testing.T) {
+	type entity struct{ id int }
+	e := &entity{id: 42}
+
+	o := NewObject()
+	o.UserData = e
+	cpy := o.Copy()
+	if cpy.UserData != e {
+		t.Fatal("Copy did not copy the UserData")
+	}
+	if cpy.CachedBounds != nil {
+		t.Fatal("Copy created cached bounds")
+	}
+
+	o.Reset()
+	if o.UserData != nil {
+		t.Fatal("Reset did not reset the UserData")
+	}
+}
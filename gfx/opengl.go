@@ -117,6 +117,11 @@ type GLSLSources struct {
 
 	// The GLSL fragment shader source code.
 	Fragment []byte
+
+	// The GLSL geometry shader source code, if any. Geometry shaders are
+	// optional and only supported by some devices, see the GeometryShaders
+	// field of DeviceInfo.
+	Geometry []byte
 }
 
 // Copy returns a deep copy of this shader and it's source byte slices.
@@ -127,5 +132,57 @@ func (s *GLSLSources) Copy() *GLSLSources {
 	}
 	copy(cpy.Vertex, s.Vertex)
 	copy(cpy.Fragment, s.Fragment)
+	if s.Geometry != nil {
+		cpy.Geometry = make([]byte, len(s.Geometry))
+		copy(cpy.Geometry, s.Geometry)
+	}
+	return cpy
+}
+
+// SPIRVSources represents the precompiled SPIR-V binaries of a shader program.
+// The entry point of each stage must be named "main".
+//
+// SPIR-V binaries need not retain the names of their inputs, so unlike with
+// GLSL sources the shader's inputs and the mesh's attributes are bound by the
+// explicit locations (i.e. layout(location = N)) given in the Uniforms and
+// Attribs maps rather than by name. Inputs and attributes whose names are not
+// in the maps are not fed into the program.
+type SPIRVSources struct {
+	// The SPIR-V vertex shader binary.
+	Vertex []byte
+
+	// The SPIR-V fragment shader binary.
+	Fragment []byte
+
+	// The locations of the uniforms, by the name of their input in the
+	// shader's Inputs map (e.g. "MVP").
+	Uniforms map[string]int
+
+	// The locations of the vertex attributes, by their attribute name (e.g.
+	// "Vertex", "TexCoord0", or the name of a custom attribute).
+	Attribs map[string]int
+}
+
+// Copy returns a deep copy of this shader and it's binary byte slices and
+// location maps.
+func (s *SPIRVSources) Copy() *SPIRVSources {
+	cpy := &SPIRVSources{
+		Vertex:   make([]byte, len(s.Vertex)),
+		Fragment: make([]byte, len(s.Fragment)),
+	}
+	copy(cpy.Vertex, s.Vertex)
+	copy(cpy.Fragment, s.Fragment)
+	if s.Uniforms != nil {
+		cpy.Uniforms = make(map[string]int, len(s.Uniforms))
+		for name, loc := range s.Uniforms {
+			cpy.Uniforms[name] = loc
+		}
+	}
+	if s.Attribs != nil {
+		cpy.Attribs = make(map[string]int, len(s.Attribs))
+		for name, loc := range s.Attribs {
+			cpy.Attribs[name] = loc
+		}
+	}
 	return cpy
 }
@@ -110,6 +110,10 @@ type RTTConfig struct {
 
 	// The number of samples to use for multisampling. It should be one of the
 	// numbers listed in the GPUInfo.RTTFormats structure.
+	//
+	// Textures cannot be multisampled themselves, so when multisampling is
+	// used along with textures the canvas renders into multisampled buffers
+	// and resolves them into the textures each time Render is called.
 	Samples int
 
 	// Color, Depth, and Stencil textures, each of these texture's Format
@@ -120,6 +124,9 @@ type RTTConfig struct {
 	// Specify nil for any you do not intend to use as a texture (e.g. if you
 	// want a 16-bit depth buffer but do not intend to use it as a texture, you
 	// should set Depth == nil and DepthFormat == Depth16).
+	//
+	// The Depth texture may be sampled with depth comparison (e.g. as a shadow
+	// map) by setting it's Compare and CompareCmp fields.
 	Color, Depth, Stencil *Texture
 
 	// Color format to use for the color buffer, it should be one listed in the
@@ -37,6 +37,24 @@ type Shader struct {
 	//
 	GLSL *GLSLSources
 
+	// SPIRV represents the precompiled SPIR-V binaries of the shader, if any.
+	// They are only used by devices that support them, so the GLSL sources
+	// should still be provided as a fallback:
+	//
+	//  if device.Info().SPIRV {
+	//      // Device will load the SPIR-V binaries instead of GLSL sources.
+	//  }
+	//
+	SPIRV *SPIRVSources
+
+	// Weather or not the sources of this shader have changed since the last
+	// time the shader was loaded. If set to true the device will recompile the
+	// shader from it's new sources the next time it is drawn (even if there
+	// was previously an error loading it), freeing the old shader program.
+	//
+	// This is useful for e.g. reloading shaders from disk during development.
+	Changed bool
+
 	// A map of names and values to use as inputs for the shader program while
 	// rendering. Values must be of the following data types or else they will
 	// be ignored:
@@ -54,6 +72,7 @@ type Shader struct {
 	//  []gfx.Color
 	//  gfx.TexCoord
 	//  []gfx.TexCoord
+	//  *gfx.UniformBlock (see the UniformBlock type)
 	//
 	Inputs map[string]interface{}
 
@@ -62,6 +81,81 @@ type Shader struct {
 	Error []byte
 }
 
+// UniformInfo describes a single active uniform variable of a loaded shader
+// program, see the ActiveUniforms method of Shader.
+type UniformInfo struct {
+	// The name of the uniform, as reported by the device. For OpenGL devices
+	// arrays are reported as their first element (e.g. "Lights[0]").
+	Name string
+
+	// The device-specific data type of the uniform. For OpenGL devices this is
+	// the GL type enumeration (e.g. GL_FLOAT_VEC3).
+	Type uint32
+
+	// The size of the uniform, which is the number of elements for arrays and
+	// one otherwise.
+	Size int
+}
+
+// AttribInfo describes a single active vertex attribute of a loaded shader
+// program, see the ActiveAttributes method of Shader.
+type AttribInfo struct {
+	// The name of the attribute, as reported by the device.
+	Name string
+
+	// The device-specific data type of the attribute. For OpenGL devices this
+	// is the GL type enumeration (e.g. GL_FLOAT_VEC3).
+	Type uint32
+
+	// The size of the attribute, which is the number of elements for arrays
+	// and one otherwise.
+	Size int
+}
+
+// ActiveUniforms returns the uniform variables that are actually used by the
+// shader program, as reported by the device when it loaded the shader.
+//
+// If the shader is not loaded, or the device does not support introspection,
+// nil is returned.
+func (s *Shader) ActiveUniforms() []UniformInfo {
+	if n, ok := s.NativeShader.(interface {
+		ActiveUniforms() []UniformInfo
+	}); ok {
+		return n.ActiveUniforms()
+	}
+	return nil
+}
+
+// ActiveAttributes returns the vertex attributes that are actually used by the
+// shader program, as reported by the device when it loaded the shader.
+//
+// If the shader is not loaded, or the device does not support introspection,
+// nil is returned.
+func (s *Shader) ActiveAttributes() []AttribInfo {
+	if n, ok := s.NativeShader.(interface {
+		ActiveAttributes() []AttribInfo
+	}); ok {
+		return n.ActiveAttributes()
+	}
+	return nil
+}
+
+// GLID returns the OpenGL program object of this shader, for interoperating
+// with other OpenGL code. If the shader is not loaded, or it was not loaded by
+// an OpenGL based device, ok is false.
+//
+// The program object may only be used on the device's OpenGL thread (e.g.
+// within a function given to the RenderDo method of an OpenGL 2 device), and
+// only until the shader is destroyed or reloaded.
+func (s *Shader) GLID() (id uint32, ok bool) {
+	if n, ok := s.NativeShader.(interface {
+		GLID() uint32
+	}); ok {
+		return n.GLID(), true
+	}
+	return 0, false
+}
+
 // Copy returns a new copy of this Shader. Explicitly not copied over is the
 // native shader, the OnLoad slice, the Loaded status, and error log slice.
 func (s *Shader) Copy() *Shader {
@@ -70,25 +164,43 @@ func (s *Shader) Copy() *Shader {
 		false, // Loaded status -- not copied.
 		s.KeepDataOnLoad,
 		s.Name,
-		nil, // GLSL shader.
+		nil,   // GLSL shader.
+		nil,   // SPIR-V shader.
+		false, // Changed status -- not copied.
 		make(map[string]interface{}, len(s.Inputs)),
 		nil, // Error slice -- not copied.
 	}
 	if s.GLSL != nil {
 		cpy.GLSL = s.GLSL.Copy()
 	}
+	if s.SPIRV != nil {
+		cpy.SPIRV = s.SPIRV.Copy()
+	}
 	for name := range s.Inputs {
 		cpy.Inputs[name] = s.Inputs[name]
 	}
 	return cpy
 }
 
+// HasChanged tells if the sources of this shader are marked as having changed,
+// see the Changed field.
+func (s *Shader) HasChanged() bool {
+	return s.Changed
+}
+
 // ClearData sets the data slices (s.GLSLVert, s.Error, etc) of this shader to
 // nil if s.KeepDataOnLoad is set to false.
 func (s *Shader) ClearData() {
 	if !s.KeepDataOnLoad {
-		s.GLSL.Vertex = nil
-		s.GLSL.Fragment = nil
+		if s.GLSL != nil {
+			s.GLSL.Vertex = nil
+			s.GLSL.Fragment = nil
+			s.GLSL.Geometry = nil
+		}
+		if s.SPIRV != nil {
+			s.SPIRV.Vertex = nil
+			s.SPIRV.Fragment = nil
+		}
 		s.Error = nil
 	}
 }
@@ -99,9 +211,21 @@ func (s *Shader) Reset() {
 	s.Loaded = false
 	s.KeepDataOnLoad = false
 	s.Name = ""
+	s.Changed = false
 	if s.GLSL != nil {
 		s.GLSL.Vertex = s.GLSL.Vertex[:0]
 		s.GLSL.Fragment = s.GLSL.Fragment[:0]
+		s.GLSL.Geometry = s.GLSL.Geometry[:0]
+	}
+	if s.SPIRV != nil {
+		s.SPIRV.Vertex = s.SPIRV.Vertex[:0]
+		s.SPIRV.Fragment = s.SPIRV.Fragment[:0]
+		for k := range s.SPIRV.Uniforms {
+			delete(s.SPIRV.Uniforms, k)
+		}
+		for k := range s.SPIRV.Attribs {
+			delete(s.SPIRV.Attribs, k)
+		}
 	}
 	for k := range s.Inputs {
 		delete(s.Inputs, k)
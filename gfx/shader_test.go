@@ -0,0 +1,64 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import "testing"
+
+// activeShader is a native shader which supports introspection.
+type activeShader struct {
+	nilNativeShader
+	uniforms []UniformInfo
+	attribs  []AttribInfo
+}
+
+func (a activeShader) ActiveUniforms() []UniformInfo  { return a.uniforms }
+func (a activeShader) ActiveAttributes() []AttribInfo { return a.attribs }
+
+func TestShaderActive(t *testing.T) {
+	s := NewShader("test")
+	if s.ActiveUniforms() != nil || s.ActiveAttributes() != nil {
+		t.Fatal("expected no active uniforms or attributes before loading")
+	}
+
+	// The nil device does not support introspection.
+	s.NativeShader = nilNativeShader{}
+	if s.ActiveUniforms() != nil || s.ActiveAttributes() != nil {
+		t.Fatal("expected no active uniforms or attributes without support")
+	}
+
+	s.NativeShader = activeShader{
+		uniforms: []UniformInfo{{Name: "Lights[0]", Type: 0x8B51, Size: 4}},
+		attribs:  []AttribInfo{{Name: "Vertex", Type: 0x8B51, Size: 1}},
+	}
+	if u := s.ActiveUniforms(); len(u) != 1 || u[0].Name != "Lights[0]" || u[0].Size != 4 {
+		t.Fatalf("ActiveUniforms() = %v", u)
+	}
+	if a := s.ActiveAttributes(); len(a) != 1 || a[0].Name != "Vertex" {
+		t.Fatalf("ActiveAttributes() = %v", a)
+	}
+}
+
+// glShader is a native shader of an OpenGL based device.
+type glShader struct {
+	nilNativeShader
+	program uint32
+}
+
+func (g glShader) GLID() uint32 { return g.program }
+
+func TestShaderGLID(t *testing.T) {
+	s := NewShader("test")
+	if _, ok := s.GLID(); ok {
+		t.Fatal("expected no OpenGL program before loading")
+	}
+	s.NativeShader = nilNativeShader{}
+	if _, ok := s.GLID(); ok {
+		t.Fatal("expected no OpenGL program for a non-OpenGL device")
+	}
+	s.NativeShader = glShader{program: 3}
+	if id, ok := s.GLID(); !ok || id != 3 {
+		t.Fatal("got", id, ok, "want", 3, true)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import "github.com/qmcloud/engine/lmath"
+
+// Skeleton represents the bones of an animated (skinned) graphics object, for
+// use in a shader program. Each vertex of the object's meshes is transformed by
+// up to four of the bones (see Mesh.BoneIndices and Mesh.BoneWeights).
+//
+// Devices provide the bone matrices to shader programs as the uniform array:
+//
+//	uniform mat4 Bones[N];
+//
+// Where N is the maximum number of bones the shader supports. A typical
+// skinning vertex shader computes the skinned vertex position as:
+//
+//	mat4 skin = BoneWeights.x * Bones[int(BoneIndices.x)] +
+//	            BoneWeights.y * Bones[int(BoneIndices.y)] +
+//	            BoneWeights.z * Bones[int(BoneIndices.z)] +
+//	            BoneWeights.w * Bones[int(BoneIndices.w)];
+//	gl_Position = MVP * skin * vec4(Vertex, 1.0);
+//
+// A skeleton may be shared by multiple graphics objects.
+type Skeleton struct {
+	// The skinning matrix of each bone, which transforms a vertex from the
+	// bind pose of the mesh (i.e. the pose it's vertices are specified in)
+	// into the bone's current pose. See the SetBone method.
+	Bones []Mat4
+}
+
+// SetBone sets the skinning matrix of the bone at index i, given the inverse of
+// the bone's bind pose matrix and it's current pose matrix (both in the
+// coordinate space of the mesh):
+//
+//	s.Bones[i] = ConvertMat4(inverseBind.Mul(pose))
+func (s *Skeleton) SetBone(i int, inverseBind, pose lmath.Mat4) {
+	s.Bones[i] = ConvertMat4(inverseBind.Mul(pose))
+}
+
+// Copy returns a new copy of this skeleton and it's bones.
+func (s *Skeleton) Copy() *Skeleton {
+	cpy := &Skeleton{
+		Bones: make([]Mat4, len(s.Bones)),
+	}
+	copy(cpy.Bones, s.Bones)
+	return cpy
+}
+
+// NewSkeleton returns a new skeleton with n bones, each of which is set to the
+// identity matrix (i.e. the bind pose).
+func NewSkeleton(n int) *Skeleton {
+	s := &Skeleton{
+		Bones: make([]Mat4, n),
+	}
+	for i := range s.Bones {
+		s.Bones[i] = ConvertMat4(lmath.Mat4Identity)
+	}
+	return s
+}
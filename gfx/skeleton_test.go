@@ -0,0 +1,42 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import (
+	"testing"
+
+	"github.com/qmcloud/engine/lmath"
+)
+
+func TestSkeletonSetBone(t *testing.T) {
+	s := NewSkeleton(2)
+	identity := ConvertMat4(lmath.Mat4Identity)
+	for i, b := range s.Bones {
+		if b != identity {
+			t.Fatalf("Bones[%d]: got %v, want identity", i, b)
+		}
+	}
+
+	// A bone bound at (0, 0, 1) and posed at (1, 0, 1) moves the vertices
+	// it influences by +1 on the X axis.
+	bind := lmath.Mat4FromTranslation(lmath.Vec3{Z: 1})
+	inverseBind, _ := bind.Inverse()
+	pose := lmath.Mat4FromTranslation(lmath.Vec3{X: 1, Z: 1})
+	s.SetBone(1, inverseBind, pose)
+
+	v := lmath.Vec3{X: 2, Y: 3, Z: 4}.TransformMat4(s.Bones[1].Mat4())
+	if want := (lmath.Vec3{X: 3, Y: 3, Z: 4}); !v.Equals(want) {
+		t.Fatalf("got %v, want %v", v, want)
+	}
+	if s.Bones[0] != identity {
+		t.Fatal("Bones[0]: changed by SetBone(1, ...)")
+	}
+
+	cpy := s.Copy()
+	cpy.Bones[1] = identity
+	if s.Bones[1] == identity {
+		t.Fatal("Copy: bones are shared with the original")
+	}
+}
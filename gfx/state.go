@@ -4,7 +4,11 @@
 
 package gfx
 
-import "sync"
+import (
+	"image"
+	"math"
+	"sync"
+)
 
 // State represents a generic set of graphics state properties to be used when
 // drawing a graphics object. Changes to such properties across multiple draw
@@ -29,24 +33,43 @@ type State struct {
 	Blend BlendState
 
 	// Whether or not red/green/blue/alpha should be written to the color
-	// buffer or not when drawing the object.
+	// buffer or not when drawing the object (i.e. the color write mask). By
+	// default all are written, writing only some channels is useful for e.g.
+	// velocity buffers or masking.
+	//
+	// The mask only applies when drawing the object, clearing a canvas (see
+	// Canvas.Clear and Canvas.ClearRects) always writes all channels.
 	WriteRed, WriteGreen, WriteBlue, WriteAlpha bool
 
 	// Whether or not dithering should be used when drawing the object.
 	Dithering bool
 
 	// DepthClamp when enabled effectively disables the near and far clipping
-	// planes when drawing the object.
+	// planes when drawing the object, clamping the depth of fragments instead
+	// (which is useful for e.g. shadow map rendering and skyboxes).
+	//
+	// It is ignored by devices that do not support it (see the DepthClamp
+	// field of DeviceInfo), which write a message to their debug output.
 	DepthClamp bool
 
 	// Whether or not depth testing and depth writing should be enabled when
-	// drawing the object.
+	// drawing the object. Both are enabled by default.
+	//
+	// They are independent of one another: for example a user interface
+	// overlay is typically drawn with both disabled, and a skybox with depth
+	// testing enabled but depth writing disabled. With depth testing disabled
+	// and depth writing enabled, every fragment passes (regardless of
+	// DepthCmp) and it's depth is written.
 	DepthTest, DepthWrite bool
 
 	// The comparison operator to use for depth testing against existing pixels
 	// in the depth buffer.
 	DepthCmp Cmp
 
+	// The bias added to the depth of the object's fragments before depth
+	// testing, see DepthBias. The default is zero (i.e. no bias).
+	DepthBias DepthBias
+
 	// Whether or not stencil testing should be enabled when drawing the
 	// object.
 	StencilTest bool
@@ -57,8 +80,22 @@ type State struct {
 	// Must be one of: BackFaceCulling, FrontFaceCulling, NoFaceCulling
 	FaceCulling FaceCullMode
 
+	// The winding order of front-facing triangles, which determines which
+	// faces FaceCulling considers to be the front or back ones.
+	//
+	// Must be one of: CounterClockwise, Clockwise
+	FrontFace FrontFace
+
 	// The stencil state for front and back facing pixels, respectively.
 	StencilFront, StencilBack StencilState
+
+	// ScissorRect, if not nil, restricts drawing of the object to the given
+	// rectangle of the canvas, in addition to the rectangle given to
+	// Canvas.Draw. It affects only which pixels are written, not how the
+	// object is projected, which is useful for e.g. clipping user interface
+	// elements that overflow their container. The default is nil (i.e. only
+	// the Draw rectangle applies).
+	ScissorRect *image.Rectangle
 }
 
 // Compare compares this state against the other one using DefaultState as a
@@ -101,28 +138,149 @@ func (s *State) Compare(other *State) bool {
 	if s.DepthCmp != other.DepthCmp {
 		return s.DepthCmp == defaultState.DepthCmp
 	}
+	if s.DepthBias != other.DepthBias {
+		return s.DepthBias == defaultState.DepthBias
+	}
 	if s.StencilTest != other.StencilTest {
 		return s.StencilTest == defaultState.StencilTest
 	}
 	if s.FaceCulling != other.FaceCulling {
 		return s.FaceCulling == defaultState.FaceCulling
 	}
+	if s.FrontFace != other.FrontFace {
+		return s.FrontFace == defaultState.FrontFace
+	}
 	if s.StencilFront != other.StencilFront {
 		return s.StencilFront.Compare(other.StencilFront)
 	}
 	if s.StencilBack != other.StencilBack {
 		return s.StencilBack.Compare(other.StencilBack)
 	}
+	if !scissorEquals(s.ScissorRect, other.ScissorRect) {
+		if s.ScissorRect == nil || other.ScissorRect == nil {
+			return s.ScissorRect == defaultState.ScissorRect
+		}
+		a, b := *s.ScissorRect, *other.ScissorRect
+		if a.Min != b.Min {
+			return a.Min.X < b.Min.X || (a.Min.X == b.Min.X && a.Min.Y < b.Min.Y)
+		}
+		return a.Max.X < b.Max.X || (a.Max.X == b.Max.X && a.Max.Y < b.Max.Y)
+	}
 	return true
 }
 
+// Equal tells if this state is equal to the other one, i.e. if every field of
+// the two states is equal (the ScissorRect fields are compared by the
+// rectangles they point to).
+func (s *State) Equal(other *State) bool {
+	if s == other {
+		return true
+	}
+	a, b := *s, *other
+	a.ScissorRect, b.ScissorRect = nil, nil
+	return a == b && scissorEquals(s.ScissorRect, other.ScissorRect)
+}
+
+// scissorEquals tells if the two (possibly nil) scissor rectangles are equal.
+func scissorEquals(a, b *image.Rectangle) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Hash returns a stable 64-bit hash of every field of this state, such that
+// equal states always have equal hashes. It is useful for caching objects
+// derived from a state (e.g. pipeline objects), but as with any hash unequal
+// states may collide, so Equal should be used to confirm a match.
+func (s *State) Hash() uint64 {
+	// 64-bit FNV-1a hash.
+	h := uint64(14695981039346656037)
+	write := func(v uint64) {
+		for i := 0; i < 8; i++ {
+			h ^= v & 0xFF
+			h *= 1099511628211
+			v >>= 8
+		}
+	}
+	writeBool := func(b bool) {
+		if b {
+			write(1)
+		} else {
+			write(0)
+		}
+	}
+	writeFloat := func(f float32) {
+		if f == 0 {
+			// Negative zero is equal to positive zero, so it must hash the
+			// same.
+			f = 0
+		}
+		write(uint64(math.Float32bits(f)))
+	}
+	writeColor := func(c Color) {
+		writeFloat(c.R)
+		writeFloat(c.G)
+		writeFloat(c.B)
+		writeFloat(c.A)
+	}
+	writeStencil := func(st StencilState) {
+		write(uint64(st.WriteMask))
+		write(uint64(st.ReadMask))
+		write(uint64(st.Reference))
+		write(uint64(st.Fail))
+		write(uint64(st.DepthFail))
+		write(uint64(st.DepthPass))
+		write(uint64(st.Cmp))
+	}
+
+	write(uint64(s.AlphaMode))
+	writeColor(s.Blend.Color)
+	write(uint64(s.Blend.SrcRGB))
+	write(uint64(s.Blend.DstRGB))
+	write(uint64(s.Blend.SrcAlpha))
+	write(uint64(s.Blend.DstAlpha))
+	write(uint64(s.Blend.RGBEq))
+	write(uint64(s.Blend.AlphaEq))
+	writeBool(s.WriteRed)
+	writeBool(s.WriteGreen)
+	writeBool(s.WriteBlue)
+	writeBool(s.WriteAlpha)
+	writeBool(s.Dithering)
+	writeBool(s.DepthClamp)
+	writeBool(s.DepthTest)
+	writeBool(s.DepthWrite)
+	write(uint64(s.DepthCmp))
+	writeFloat(s.DepthBias.Slope)
+	writeFloat(s.DepthBias.Constant)
+	writeBool(s.StencilTest)
+	write(uint64(s.FaceCulling))
+	write(uint64(s.FrontFace))
+	writeStencil(s.StencilFront)
+	writeStencil(s.StencilBack)
+	writeBool(s.ScissorRect != nil)
+	if s.ScissorRect != nil {
+		write(uint64(s.ScissorRect.Min.X))
+		write(uint64(s.ScissorRect.Min.Y))
+		write(uint64(s.ScissorRect.Max.X))
+		write(uint64(s.ScissorRect.Max.Y))
+	}
+	return h
+}
+
 // Copy returns a copy of this state, it is short-handed for:
 //
 //  cpy := *s
 //  return &cpy
 //
+// except that the ScissorRect (if any) is copied as well, instead of being
+// shared by both states.
 func (s *State) Copy() *State {
 	cpy := *s
+	if s.ScissorRect != nil {
+		r := *s.ScissorRect
+		cpy.ScissorRect = &r
+	}
 	return &cpy
 }
 
@@ -149,6 +307,7 @@ func (s *State) Reset() {
 		DepthCmp:     Less,
 		StencilTest:  false,
 		FaceCulling:  BackFaceCulling,
+		FrontFace:    CounterClockwise,
 		StencilFront: DefaultStencilState,
 		StencilBack:  DefaultStencilState,
 	}
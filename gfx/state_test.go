@@ -0,0 +1,129 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import (
+	"image"
+	"math"
+	"testing"
+)
+
+func TestStateHashEqual(t *testing.T) {
+	changes := map[string]func(s *State){
+		"AlphaMode":              func(s *State) { s.AlphaMode = AlphaBlend },
+		"Blend.Color":            func(s *State) { s.Blend.Color.G = 0.5 },
+		"Blend.SrcRGB":           func(s *State) { s.Blend.SrcRGB = BZero },
+		"Blend.DstRGB":           func(s *State) { s.Blend.DstRGB = BZero },
+		"Blend.SrcAlpha":         func(s *State) { s.Blend.SrcAlpha = BZero },
+		"Blend.DstAlpha":         func(s *State) { s.Blend.DstAlpha = BZero },
+		"Blend.RGBEq":            func(s *State) { s.Blend.RGBEq = BSub },
+		"Blend.AlphaEq":          func(s *State) { s.Blend.AlphaEq = BSub },
+		"WriteRed":               func(s *State) { s.WriteRed = false },
+		"WriteGreen":             func(s *State) { s.WriteGreen = false },
+		"WriteBlue":              func(s *State) { s.WriteBlue = false },
+		"WriteAlpha":             func(s *State) { s.WriteAlpha = false },
+		"Dithering":              func(s *State) { s.Dithering = false },
+		"DepthClamp":             func(s *State) { s.DepthClamp = true },
+		"DepthTest":              func(s *State) { s.DepthTest = false },
+		"DepthWrite":             func(s *State) { s.DepthWrite = false },
+		"DepthCmp":               func(s *State) { s.DepthCmp = Greater },
+		"DepthBias.Slope":        func(s *State) { s.DepthBias.Slope = 1 },
+		"DepthBias.Constant":     func(s *State) { s.DepthBias.Constant = 1 },
+		"StencilTest":            func(s *State) { s.StencilTest = true },
+		"FaceCulling":            func(s *State) { s.FaceCulling = NoFaceCulling },
+		"FrontFace":              func(s *State) { s.FrontFace = Clockwise },
+		"StencilFront.WriteMask": func(s *State) { s.StencilFront.WriteMask = 1 },
+		"StencilFront.ReadMask":  func(s *State) { s.StencilFront.ReadMask = 1 },
+		"StencilFront.Reference": func(s *State) { s.StencilFront.Reference = 1 },
+		"StencilFront.Fail":      func(s *State) { s.StencilFront.Fail = SInvert },
+		"StencilFront.DepthFail": func(s *State) { s.StencilFront.DepthFail = SInvert },
+		"StencilFront.DepthPass": func(s *State) { s.StencilFront.DepthPass = SInvert },
+		"StencilFront.Cmp":       func(s *State) { s.StencilFront.Cmp = Never },
+		"StencilBack.WriteMask":  func(s *State) { s.StencilBack.WriteMask = 1 },
+		"StencilBack.ReadMask":   func(s *State) { s.StencilBack.ReadMask = 1 },
+		"StencilBack.Reference":  func(s *State) { s.StencilBack.Reference = 1 },
+		"StencilBack.Fail":       func(s *State) { s.StencilBack.Fail = SInvert },
+		"StencilBack.DepthFail":  func(s *State) { s.StencilBack.DepthFail = SInvert },
+		"StencilBack.DepthPass":  func(s *State) { s.StencilBack.DepthPass = SInvert },
+		"StencilBack.Cmp":        func(s *State) { s.StencilBack.Cmp = Never },
+		"ScissorRect":            func(s *State) { s.ScissorRect = &image.Rectangle{Max: image.Pt(4, 4)} },
+	}
+
+	def := NewState()
+	defer def.Destroy()
+	if cpy := def.Copy(); !cpy.Equal(def) || cpy.Hash() != def.Hash() {
+		t.Fatal("copy of state is not equal to the original")
+	}
+
+	hashes := map[uint64]string{def.Hash(): "default"}
+	for field, change := range changes {
+		s := def.Copy()
+		change(s)
+		if s.Equal(def) {
+			t.Errorf("%s: changed state equals the default state", field)
+		}
+		h := s.Hash()
+		if other, ok := hashes[h]; ok {
+			t.Errorf("%s: hash %x collides with %s", field, h, other)
+		}
+		hashes[h] = field
+	}
+}
+
+func TestStateNegativeZero(t *testing.T) {
+	negZero := float32(math.Copysign(0, -1))
+	a, b := NewState(), NewState()
+	defer a.Destroy()
+	defer b.Destroy()
+	b.DepthBias.Constant = negZero
+	b.Blend.Color.R = negZero
+
+	// Negative zero equals positive zero, so the hashes must be equal too.
+	if !a.Equal(b) {
+		t.Fatal("states differing only in the sign of zero are not equal")
+	}
+	if a.Hash() != b.Hash() {
+		t.Fatal("equal states differing only in the sign of zero hash differently")
+	}
+}
+
+func TestStateScissorRect(t *testing.T) {
+	s := NewState()
+	defer s.Destroy()
+	s.ScissorRect = &image.Rectangle{Max: image.Pt(4, 4)}
+
+	// Scissor rectangles are compared by value, and copied.
+	cpy := s.Copy()
+	if cpy.ScissorRect == s.ScissorRect {
+		t.Fatal("Copy did not copy the ScissorRect")
+	}
+	if !cpy.Equal(s) || cpy.Hash() != s.Hash() {
+		t.Fatal("copy of state is not equal to the original")
+	}
+	cpy.ScissorRect.Max.X = 8
+	if cpy.Equal(s) {
+		t.Fatal("states with different scissor rectangles are equal")
+	}
+	if !s.Compare(cpy) || cpy.Compare(s) {
+		t.Fatal("expected the smaller scissor rectangle to sort first")
+	}
+	if !defaultState.Compare(s) || s.Compare(defaultState) {
+		t.Fatal("expected a nil scissor rectangle to sort first")
+	}
+
+	s.Reset()
+	if s.ScissorRect != nil {
+		t.Fatal("Reset did not reset the ScissorRect")
+	}
+}
+
+func TestDepthBiasEnabled(t *testing.T) {
+	if (DepthBias{}).Enabled() {
+		t.Fatal("zero DepthBias is enabled")
+	}
+	if !(DepthBias{Slope: 1}).Enabled() || !(DepthBias{Constant: -1}).Enabled() {
+		t.Fatal("non-zero DepthBias is not enabled")
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+// Stats represents rendering statistics collected by a device over the course
+// of a single frame, e.g. for display in an on-screen debug overlay.
+type Stats struct {
+	// The number of draw calls submitted, i.e. one per mesh drawn.
+	DrawCalls int
+
+	// The number of triangles submitted, only meshes whose primitive is
+	// Triangles are counted. Meshes drawn with primitive restart (see the
+	// RestartIndex field of Mesh) are not counted, as their number of
+	// triangles depends on the restart indices.
+	Triangles int
+
+	// The number of objects drawn whose state differed from the state of the
+	// object drawn before it.
+	StateChanges int
+
+	// The number of textures bound for drawing objects.
+	TextureBinds int
+}
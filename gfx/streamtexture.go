@@ -0,0 +1,59 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import "image"
+
+// StreamTexture is a texture whose source image is replaced frequently, e.g.
+// the frames of a video or a procedurally generated image which changes each
+// frame.
+//
+// Each new image is uploaded using the UpdateStream method of a device. Unlike
+// reloading the texture (via LoadTexture) for each image, this re-uses the
+// existing texture on the graphics hardware when the image has the same
+// dimensions as the last one, avoiding costly reallocation.
+//
+// Stream textures are always stored in an uncompressed format, the Format
+// field of the texture is ignored.
+type StreamTexture struct {
+	// The texture that the images are uploaded to, it may be used by objects
+	// for drawing like any other texture.
+	*Texture
+}
+
+// SetFrame sets the source image of the texture to the given one, which is
+// uploaded by the next call to a device's UpdateStream method.
+func (s *StreamTexture) SetFrame(img image.Image) {
+	s.Source = img
+	s.Raw = nil
+	s.Bounds = img.Bounds()
+}
+
+// SetRawFrame sets the raw pixel data of the texture to the given tightly
+// packed pixels of the given format and size (see the Raw field of Texture),
+// which are uploaded by the next call to a device's UpdateStream method.
+//
+// Unlike SetFrame, no image needs to be constructed for each frame, and the
+// device may upload the pixels without copying them.
+func (s *StreamTexture) SetRawFrame(pix []byte, format TexFormat, size image.Point) {
+	s.Source = nil
+	s.Raw = pix
+	s.RawFormat = format
+	s.Bounds = image.Rectangle{Max: size}
+}
+
+// NewStreamTexture returns a new stream texture whose first frame is the given
+// image. The texture is Dynamic, and it's MinFilter and MagFilter are Linear
+// (regenerating mipmaps for each frame would be costly).
+func NewStreamTexture(img image.Image) *StreamTexture {
+	s := &StreamTexture{
+		Texture: NewTexture(),
+	}
+	s.Dynamic = true
+	s.MinFilter = Linear
+	s.MagFilter = Linear
+	s.SetFrame(img)
+	return s
+}
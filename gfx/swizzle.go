@@ -0,0 +1,29 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+// SwizzleComponent represents the source of a single component of the color
+// that is returned when a texture is sampled (see Texture.Swizzle).
+// SwizzleIdentity is the default (zero value).
+type SwizzleComponent uint8
+
+const (
+	// SwizzleIdentity means the component is sourced from the same channel of
+	// the texture (i.e. it is not swizzled).
+	SwizzleIdentity SwizzleComponent = iota
+
+	// SwizzleRed, SwizzleGreen, SwizzleBlue, and SwizzleAlpha mean the
+	// component is sourced from the red, green, blue, and alpha channel of the
+	// texture, respectively.
+	SwizzleRed
+	SwizzleGreen
+	SwizzleBlue
+	SwizzleAlpha
+
+	// SwizzleZero and SwizzleOne mean the component is always zero or one,
+	// respectively.
+	SwizzleZero
+	SwizzleOne
+)
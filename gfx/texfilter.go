@@ -27,6 +27,22 @@ func (t TexFilter) Mipmapped() bool {
 	return false
 }
 
+// NonMipmapped returns the texture filter with mipmapping removed, i.e. for
+// NearestMipmapNearest and NearestMipmapLinear it returns Nearest, and for
+// LinearMipmapNearest and LinearMipmapLinear it returns Linear. Other filters
+// are returned as-is.
+//
+// Devices use it for magnification, for which mipmapped filters are invalid.
+func (t TexFilter) NonMipmapped() TexFilter {
+	switch t {
+	case NearestMipmapNearest, NearestMipmapLinear:
+		return Nearest
+	case LinearMipmapNearest, LinearMipmapLinear:
+		return Linear
+	}
+	return t
+}
+
 const (
 	// Nearest samples the nearest pixel.
 	Nearest TexFilter = iota
@@ -76,6 +76,42 @@ const (
 	DXT5
 )
 
+// CubeFace represents a single face of a cube map texture, see the CubeFaces
+// field of Texture.
+//
+// The faces are named according to this package's right-handed Z-up
+// coordinate system, and are in the same order as OpenGL's cube map faces
+// (+X, -X, +Y, -Y, +Z, -Z). As such a cube map is sampled in a shader using a
+// world space direction vector, e.g. for a skybox:
+//
+//	uniform samplerCube Texture0;
+//	...
+//	gl_FragColor = textureCube(Texture0, worldDirection);
+//
+// The orientation of the image within each face follows the OpenGL cube map
+// convention.
+type CubeFace uint8
+
+const (
+	// CubeRight is the +X face.
+	CubeRight CubeFace = iota
+
+	// CubeLeft is the -X face.
+	CubeLeft
+
+	// CubeForward is the +Y face.
+	CubeForward
+
+	// CubeBack is the -Y face.
+	CubeBack
+
+	// CubeUp is the +Z face.
+	CubeUp
+
+	// CubeDown is the -Z face.
+	CubeDown
+)
+
 // Downloadable represents a image that can be downloaded from the graphics
 // hardware into system memory (e.g. for taking a screen-shot).
 type Downloadable interface {
@@ -145,6 +181,52 @@ type Texture struct {
 	// to texture, unless downloaded).
 	Source image.Image
 
+	// Raw is the already-decoded pixel data of the texture, which is used
+	// instead of the Source image if Source is nil. It allows pixel data that
+	// is already in memory (e.g. from a network stream) to be uploaded by the
+	// device directly, without constructing (and copying into) an image.
+	//
+	// The pixels are tightly packed (i.e. there is no padding between rows),
+	// in top-to-bottom row order, and in the format specified by RawFormat.
+	// The dimensions of the pixel data are those of Bounds, which must be set.
+	//
+	// Like the Source image, Raw is set to nil once the texture is loaded
+	// unless KeepDataOnLoad is set to true.
+	Raw []byte
+
+	// The format of the Raw pixel data, which must be either RGBA (four bytes
+	// per pixel, with premultiplied alpha like image.RGBA) or RGB (three bytes
+	// per pixel). It is RGBA for new textures (see NewTexture).
+	RawFormat TexFormat
+
+	// PremultipliedAlpha, if true, makes the device premultiply the RGB
+	// components of the texture's pixels by their alpha component when
+	// loading the texture, for pixel data that has straight (i.e. not
+	// premultiplied) alpha. The texture's images and Raw data are left
+	// unmodified.
+	//
+	// Images of most types (e.g. the *image.NRGBA images decoded from PNG
+	// files) are already converted to premultiplied alpha when loaded, so it
+	// only affects straight alpha stored as RGBA Raw data or in an
+	// *image.RGBA (whose pixels Go defines as premultiplied), and has no
+	// effect on images of any other type.
+	//
+	// Premultiplied textures must be drawn using premultiplied alpha blending,
+	// i.e. a BlendState whose SrcRGB is BOne (like DefaultBlendState), rather
+	// than StraightBlendState, which would weight the color by alpha a second
+	// time and cause dark halos around the edges of transparent areas. The
+	// SrcAlpha and DstAlpha operands are unaffected.
+	PremultipliedAlpha bool
+
+	// The six face images of a cube map texture, indexed by CubeFace. If all
+	// six faces are non-nil then the texture is a cube map texture and the
+	// Source image is ignored. Each face must be square and all faces must be
+	// of the same size.
+	//
+	// Like the Source image, the faces are set to nil once the texture is
+	// loaded unless KeepDataOnLoad is set to true.
+	CubeFaces [6]image.Image
+
 	// The texture format to use for storing this texture on the GPU, which may
 	// result in lossy conversions (e.g. RGB would lose the alpha channel, etc).
 	//
@@ -153,21 +235,151 @@ type Texture struct {
 	// can be determined via NativeTexture's ChosenFormat method).
 	Format TexFormat
 
-	// The U and V wrap modes of this texture.
+	// The U and V wrap modes of this texture, i.e. how the texture wraps along
+	// the horizontal and vertical axis respectively (on OpenGL devices these
+	// are the S and T axis). Each axis may use a different wrap mode, e.g.
+	// Mirror along U and Clamp along V.
+	//
+	// The wrap modes are applied each time the texture is drawn, so they may
+	// be changed without reloading the texture.
 	WrapU, WrapV TexWrap
 
 	// The color of the border when a wrap mode is set to BorderColor.
 	BorderColor Color
 
 	// The texture filtering used for minification and magnification of the
-	// texture.
+	// texture. The two are independent of each other, e.g. pixel art that is
+	// mipmapped can be sharp up close and smooth in the distance using:
+	//
+	//	t.MinFilter = gfx.LinearMipmapLinear
+	//	t.MagFilter = gfx.Nearest
+	//
+	// Mipmapped filters only apply to minification, if MagFilter is one then
+	// it's non-mipmapped equivalent is used instead (see the NonMipmapped
+	// method of TexFilter).
 	MinFilter, MagFilter TexFilter
+
+	// NoAutoMipmap specifies whether or not the device skips generating
+	// mipmaps for the texture. If false (the default) the device generates
+	// them based on MinFilter alone, i.e. only if it is a mipmapped filter
+	// (see the Mipmapped method). Mipmaps are generated when the texture is
+	// loaded, and each time a render-to-texture canvas renders to it. If true
+	// then only the full resolution image of the texture is sampled, even with
+	// a mipmapped MinFilter.
+	//
+	// Changing it on an already-loaded texture requires reloading the texture.
+	NoAutoMipmap bool
+
+	// LODBias is added to the level-of-detail (i.e. mipmap level) that the
+	// device computes when sampling a mipmapped texture. Positive values make
+	// the texture blurrier, negative values make it sharper (but can cause
+	// shimmering).
+	LODBias float32
+
+	// MinLOD and MaxLOD clamp the level-of-detail used when sampling a
+	// mipmapped texture, where level zero is the full resolution image. If
+	// both are zero then the full level-of-detail range is used.
+	MinLOD, MaxLOD float32
+
+	// Compare enables depth comparison when sampling a depth texture (e.g. the
+	// Depth texture of a render-to-texture canvas), as is used for shadow
+	// mapping. Instead of the depth value itself, sampling then returns the
+	// result of comparing the third (R) texture coordinate against it using
+	// CompareCmp: one if the comparison passes and zero if it fails (with a
+	// Linear filter, the results of nearby texels are blended). A shader must
+	// sample such a texture using a shadow sampler, e.g. in GLSL:
+	//
+	//	uniform sampler2DShadow Texture0;
+	//	...
+	//	float lit = shadow2D(Texture0, shadowCoord.xyz).r;
+	//
+	// Compare is ignored for textures that do not have a depth format. Like
+	// the wrap modes, it is applied each time the texture is drawn.
+	Compare bool
+
+	// The comparison operator used when Compare is true. It is LessOrEqual for
+	// new textures (see NewTexture).
+	CompareCmp Cmp
+
+	// SamplerName, if not empty, is the name of the shader sampler uniform
+	// that the texture is bound to when drawing an object, instead of the
+	// default name derived from the texture's index in the object's Textures
+	// slice (e.g. Texture0, Texture1, etc). For example, with an albedo,
+	// normal, and specular map:
+	//
+	//	albedo.SamplerName = "Albedo"
+	//	normal.SamplerName = "NormalMap"
+	//	specular.SamplerName = "SpecularMap"
+	//
+	//	// And in GLSL:
+	//	uniform sampler2D Albedo;
+	//	uniform sampler2D NormalMap;
+	//	uniform sampler2D SpecularMap;
+	//
+	// The name applies to every object that the texture is used by.
+	SamplerName string
+
+	// Swizzle specifies the source of the red, green, blue, and alpha
+	// components (in that order) of the color returned when the texture is
+	// sampled, which is useful for single-channel textures such as font
+	// atlases and masks. For example, to broadcast the red channel to every
+	// component:
+	//
+	//	t.Swizzle = [4]gfx.SwizzleComponent{
+	//		gfx.SwizzleRed, gfx.SwizzleRed, gfx.SwizzleRed, gfx.SwizzleRed,
+	//	}
+	//
+	// The zero value (all SwizzleIdentity) means no swizzling. Like the wrap
+	// modes, it is applied each time the texture is drawn. Not all devices
+	// support swizzling (see DeviceInfo.TextureSwizzle), those that do not
+	// ignore it.
+	Swizzle [4]SwizzleComponent
+}
+
+// HasData tells if this texture has data to be loaded by a device, i.e. if it
+// has a Source image, Raw pixel data, or is a cube map (see CubeMap).
+func (t *Texture) HasData() bool {
+	return t.Source != nil || t.Raw != nil || t.CubeMap()
+}
+
+// CubeMap tells if this texture has all six of it's cube map faces (see the
+// CubeFaces field) and is thus a cube map texture.
+func (t *Texture) CubeMap() bool {
+	for _, face := range t.CubeFaces {
+		if face == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// GLID returns the OpenGL texture object of this texture, for interoperating
+// with other OpenGL code. If the texture is not loaded, or it was not loaded
+// by an OpenGL based device, ok is false.
+//
+// The texture object may only be used on the device's OpenGL thread (e.g.
+// within a function given to the RenderDo method of an OpenGL 2 device), and
+// only until the texture is destroyed or reloaded.
+func (t *Texture) GLID() (id uint32, ok bool) {
+	if n, ok := t.NativeTexture.(interface {
+		GLID() uint32
+	}); ok {
+		return n.GLID(), true
+	}
+	return 0, false
+}
+
+// Mipmapped tells if the device generates mipmaps for this texture, i.e. if
+// NoAutoMipmap is false and MinFilter is a mipmapped filter.
+func (t *Texture) Mipmapped() bool {
+	return !t.NoAutoMipmap && t.MinFilter.Mipmapped()
 }
 
 // Copy returns a new copy of this Texture. Explicitly not copied over is the
-// native texture, the OnLoad slice, the Loaded status, and the source image
-// (because the image type is not strictly known). Because the texture's source
-// image is not copied over, you may want to copy it directly over yourself.
+// native texture, the OnLoad slice, the Loaded status, the raw pixel data, and
+// the source and cube map face images (because the image type is not strictly
+// known). Because the texture's images are not copied over, you may want to
+// copy them directly over yourself.
 func (t *Texture) Copy() *Texture {
 	return &Texture{
 		nil,   // Native texture -- not copied.
@@ -176,20 +388,34 @@ func (t *Texture) Copy() *Texture {
 		t.Dynamic,
 		t.Bounds,
 		nil, // Source image -- not copied.
+		nil, // Raw pixel data -- not copied.
+		t.RawFormat,
+		t.PremultipliedAlpha,
+		[6]image.Image{}, // Cube map faces -- not copied.
 		t.Format,
 		t.WrapU,
 		t.WrapV,
 		t.BorderColor,
 		t.MinFilter,
 		t.MagFilter,
+		t.NoAutoMipmap,
+		t.LODBias,
+		t.MinLOD,
+		t.MaxLOD,
+		t.Compare,
+		t.CompareCmp,
+		t.SamplerName,
+		t.Swizzle,
 	}
 }
 
-// ClearData sets the data source image, t.Source, of this texture to nil if
-// t.KeepDataOnLoad is set to false.
+// ClearData sets the data source image, t.Source, and the raw pixel data,
+// t.Raw, of this texture to nil if t.KeepDataOnLoad is set to false.
 func (t *Texture) ClearData() {
 	if !t.KeepDataOnLoad {
 		t.Source = nil
+		t.Raw = nil
+		t.CubeFaces = [6]image.Image{}
 	}
 }
 
@@ -201,12 +427,24 @@ func (t *Texture) Reset() {
 	t.Dynamic = false
 	t.Bounds = image.Rectangle{}
 	t.Source = nil
+	t.Raw = nil
+	t.RawFormat = RGBA
+	t.PremultipliedAlpha = false
+	t.CubeFaces = [6]image.Image{}
 	t.Format = RGBA
 	t.WrapU = 0
 	t.WrapV = 0
 	t.BorderColor = Color{}
 	t.MinFilter = 0
 	t.MagFilter = 0
+	t.NoAutoMipmap = false
+	t.LODBias = 0
+	t.MinLOD = 0
+	t.MaxLOD = 0
+	t.Compare = false
+	t.CompareCmp = LessOrEqual
+	t.SamplerName = ""
+	t.Swizzle = [4]SwizzleComponent{}
 }
 
 // Destroy destroys this texture for use by other callees to NewTexture. You
@@ -0,0 +1,210 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import (
+	"image"
+	"testing"
+)
+
+func TestTextureCubeMap(t *testing.T) {
+	tex := NewTexture()
+	if tex.CubeMap() {
+		t.Fatal("new texture is a cube map")
+	}
+	face := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for i := CubeRight; i < CubeDown; i++ {
+		tex.CubeFaces[i] = face
+	}
+	if tex.CubeMap() {
+		t.Fatal("texture with five faces is a cube map")
+	}
+	tex.CubeFaces[CubeDown] = face
+	if !tex.CubeMap() {
+		t.Fatal("texture with six faces is not a cube map")
+	}
+
+	tex.KeepDataOnLoad = true
+	tex.ClearData()
+	if !tex.CubeMap() {
+		t.Fatal("ClearData cleared faces with KeepDataOnLoad set")
+	}
+	tex.KeepDataOnLoad = false
+	tex.ClearData()
+	if tex.CubeMap() {
+		t.Fatal("ClearData did not clear faces")
+	}
+}
+
+func TestTextureLOD(t *testing.T) {
+	tex := NewTexture()
+	tex.LODBias = -0.5
+	tex.MinLOD = 1
+	tex.MaxLOD = 4
+	cpy := tex.Copy()
+	if cpy.LODBias != -0.5 || cpy.MinLOD != 1 || cpy.MaxLOD != 4 {
+		t.Fatal("Copy did not copy the level-of-detail fields")
+	}
+	tex.Reset()
+	if tex.LODBias != 0 || tex.MinLOD != 0 || tex.MaxLOD != 0 {
+		t.Fatal("Reset did not reset the level-of-detail fields")
+	}
+}
+
+func TestTextureCompare(t *testing.T) {
+	tex := NewTexture()
+	if tex.Compare || tex.CompareCmp != LessOrEqual {
+		t.Fatal("expected Compare == false and CompareCmp == LessOrEqual")
+	}
+	tex.Compare = true
+	tex.CompareCmp = Greater
+	cpy := tex.Copy()
+	if !cpy.Compare || cpy.CompareCmp != Greater {
+		t.Fatal("Copy did not copy the comparison fields")
+	}
+	tex.Reset()
+	if tex.Compare || tex.CompareCmp != LessOrEqual {
+		t.Fatal("Reset did not reset the comparison fields")
+	}
+}
+
+func TestTextureNoAutoMipmap(t *testing.T) {
+	tex := NewTexture()
+	if tex.NoAutoMipmap || tex.Mipmapped() {
+		t.Fatal("expected NoAutoMipmap == false and Mipmapped() == false")
+	}
+	tex.MinFilter = LinearMipmapLinear
+	if !tex.Mipmapped() {
+		t.Fatal("expected Mipmapped() == true with a mipmapped MinFilter")
+	}
+	tex.NoAutoMipmap = true
+	if tex.Mipmapped() {
+		t.Fatal("expected Mipmapped() == false with NoAutoMipmap == true")
+	}
+	if cpy := tex.Copy(); !cpy.NoAutoMipmap {
+		t.Fatal("Copy did not copy NoAutoMipmap")
+	}
+	tex.Reset()
+	if tex.NoAutoMipmap {
+		t.Fatal("Reset did not reset NoAutoMipmap")
+	}
+
+	// The zero value generates mipmaps based on MinFilter alone.
+	lit := &Texture{MinFilter: LinearMipmapLinear}
+	if !lit.Mipmapped() {
+		t.Fatal("expected Mipmapped() == true for a texture literal")
+	}
+}
+
+func TestTextureSamplerName(t *testing.T) {
+	tex := NewTexture()
+	tex.SamplerName = "NormalMap"
+	if cpy := tex.Copy(); cpy.SamplerName != "NormalMap" {
+		t.Fatal("Copy did not copy the SamplerName")
+	}
+	tex.Reset()
+	if tex.SamplerName != "" {
+		t.Fatal("Reset did not reset the SamplerName")
+	}
+}
+
+func TestTextureSwizzle(t *testing.T) {
+	tex := NewTexture()
+	if tex.Swizzle != [4]SwizzleComponent{} {
+		t.Fatal("expected a new texture to not be swizzled")
+	}
+	red := [4]SwizzleComponent{SwizzleRed, SwizzleRed, SwizzleRed, SwizzleRed}
+	tex.Swizzle = red
+	if cpy := tex.Copy(); cpy.Swizzle != red {
+		t.Fatal("Copy did not copy the Swizzle")
+	}
+	tex.Reset()
+	if tex.Swizzle != [4]SwizzleComponent{} {
+		t.Fatal("Reset did not reset the Swizzle")
+	}
+}
+
+func TestTextureRaw(t *testing.T) {
+	tex := NewTexture()
+	if tex.HasData() || tex.RawFormat != RGBA {
+		t.Fatal("expected a new texture without data and RawFormat == RGBA")
+	}
+	tex.Raw = make([]byte, 2*2*3)
+	tex.RawFormat = RGB
+	if !tex.HasData() {
+		t.Fatal("expected Raw data to count as texture data")
+	}
+	tex.PremultipliedAlpha = true
+	if cpy := tex.Copy(); cpy.Raw != nil || cpy.RawFormat != RGB || !cpy.PremultipliedAlpha {
+		t.Fatal("Copy copied the Raw data, or did not copy RawFormat and PremultipliedAlpha")
+	}
+	tex.ClearData()
+	if tex.Raw != nil {
+		t.Fatal("ClearData did not clear the Raw data")
+	}
+
+	stream := NewStreamTexture(image.NewRGBA(image.Rect(0, 0, 4, 4)))
+	stream.SetRawFrame(make([]byte, 8*2*4), RGBA, image.Pt(8, 2))
+	if stream.Source != nil || stream.Bounds != image.Rect(0, 0, 8, 2) {
+		t.Fatal("SetRawFrame did not replace the Source image")
+	}
+	stream.SetFrame(image.NewRGBA(image.Rect(0, 0, 4, 4)))
+	if stream.Raw != nil {
+		t.Fatal("SetFrame did not clear the Raw data")
+	}
+}
+
+// glTexture is a native texture of an OpenGL based device.
+type glTexture struct {
+	nilNativeTexture
+	id uint32
+}
+
+func (g glTexture) GLID() uint32 { return g.id }
+
+func TestTextureGLID(t *testing.T) {
+	tex := NewTexture()
+	if _, ok := tex.GLID(); ok {
+		t.Fatal("expected no OpenGL texture before loading")
+	}
+	tex.NativeTexture = nilNativeTexture{}
+	if _, ok := tex.GLID(); ok {
+		t.Fatal("expected no OpenGL texture for a non-OpenGL device")
+	}
+	tex.NativeTexture = glTexture{id: 7}
+	if id, ok := tex.GLID(); !ok || id != 7 {
+		t.Fatal("got", id, ok, "want", 7, true)
+	}
+}
+
+func TestTexFilterNonMipmapped(t *testing.T) {
+	tests := []struct {
+		filter, want TexFilter
+		mipmapped    bool
+	}{
+		{Nearest, Nearest, false},
+		{Linear, Linear, false},
+		{NearestMipmapNearest, Nearest, true},
+		{LinearMipmapNearest, Linear, true},
+		{NearestMipmapLinear, Nearest, true},
+		{LinearMipmapLinear, Linear, true},
+	}
+	for _, tst := range tests {
+		if got := tst.filter.Mipmapped(); got != tst.mipmapped {
+			t.Errorf("%v.Mipmapped() = %v, want %v", tst.filter, got, tst.mipmapped)
+		}
+		if got := tst.filter.NonMipmapped(); got != tst.want {
+			t.Errorf("%v.NonMipmapped() = %v, want %v", tst.filter, got, tst.want)
+		}
+	}
+
+	// The filters are independent, e.g. for mipmapped pixel art.
+	tex := NewTexture()
+	tex.MinFilter = LinearMipmapLinear
+	tex.MagFilter = Nearest
+	if !tex.Mipmapped() || tex.MagFilter.NonMipmapped() != Nearest {
+		t.Fatal("expected a mipmapped texture with a Nearest magnification filter")
+	}
+}
@@ -214,7 +214,21 @@ func (t *Transform) LocalMat4() lmath.Mat4 {
 //
 // e.g. setting the parent of a camera's transform to the player's transform
 // makes it such that the camera follows the player.
+//
+// Changes made to any parent are picked up automatically the next time the
+// transform is used. If p (or any of it's parents) is this transform, then
+// a panic occurs as the hierarchy would contain a cycle.
 func (t *Transform) SetParent(p Transformable) {
+	for a := p; a != nil; {
+		at := a.Transform()
+		if at == nil {
+			break
+		}
+		if at == t {
+			panic("SetParent(): parent cycle detected")
+		}
+		a = at.Parent()
+	}
 	t.access.Lock()
 	if t.parent != p {
 		t.built = nil
@@ -461,6 +475,44 @@ func (t *Transform) ConvertPos(p lmath.Vec3, c CoordConv) lmath.Vec3 {
 	return p.TransformMat4(t.Convert(c))
 }
 
+// Lerp returns a new transform which is the interpolation between this
+// transform and the other one, e.g. for keyframe animation. The amount
+// parameter is the amount to interpolate (0.0 - 1.0) between the transforms.
+//
+// The position, scale, and shear are interpolated linearly, while rotation is
+// spherically interpolated (see lmath.Quat.Slerp) and as such the returned
+// transform uses quaternion rotation.
+//
+// The returned transform has the same parent as this transform. If the other
+// transform has a different parent then it's position, rotation, scale, and
+// shear are first converted into this transform's parent space.
+func (t *Transform) Lerp(other *Transform, amount float64) *Transform {
+	lerp := func(a, b lmath.Vec3) lmath.Vec3 {
+		return a.Add(b.Sub(a).MulScalar(amount))
+	}
+
+	parent := t.Parent()
+	otherPos, otherQuat := other.Pos(), other.Quat()
+	otherScale, otherShear := other.Scale(), other.Shear()
+	if other.Parent() != parent {
+		// Find the other transform's local space relative to this transform's
+		// parent space, and decompose it.
+		m := other.Convert(LocalToWorld).Mul(t.Convert(WorldToParent))
+		var hpr lmath.Vec3
+		otherScale, otherShear, hpr = m.UpperMat3().Decompose(lmath.CoordSysZUpRight)
+		otherQuat = lmath.QuatFromHpr(hpr, lmath.CoordSysZUpRight)
+		otherPos = m.Translation()
+	}
+
+	ret := NewTransform()
+	ret.SetParent(parent)
+	ret.SetPos(lerp(t.Pos(), otherPos))
+	ret.SetQuat(t.Quat().Normalized().Slerp(otherQuat.Normalized(), amount))
+	ret.SetScale(lerp(t.Scale(), otherScale))
+	ret.SetShear(lerp(t.Shear(), otherShear))
+	return ret
+}
+
 // ConvertRot converts the given rotation, r, using the given coordinate space
 // conversion. For instance to convert a rotation in local space into world
 // space:
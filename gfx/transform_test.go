@@ -136,6 +136,108 @@ func TestTransformQuat(t *testing.T) {
 	}
 }
 
+func TestTransformLerp(t *testing.T) {
+	a := NewTransform()
+	a.SetPos(lmath.Vec3{0, 0, 0})
+	a.SetScale(lmath.Vec3{1, 1, 1})
+	a.SetRot(lmath.Vec3{0, 0, 0})
+
+	b := NewTransform()
+	b.SetPos(lmath.Vec3{10, 20, 30})
+	b.SetScale(lmath.Vec3{3, 3, 3})
+	b.SetRot(lmath.Vec3{0, 0, 90})
+
+	c := a.Lerp(b, 0.5)
+	if want := (lmath.Vec3{5, 10, 15}); !c.Pos().Equals(want) {
+		t.Log("got pos", c.Pos())
+		t.Log("want pos", want)
+		t.Fail()
+	}
+	if want := (lmath.Vec3{2, 2, 2}); !c.Scale().Equals(want) {
+		t.Log("got scale", c.Scale())
+		t.Log("want scale", want)
+		t.Fail()
+	}
+	if want := (lmath.Vec3{0, 0, 45}); !c.Rot().AlmostEquals(want, 1e-6) {
+		t.Log("got rot", c.Rot())
+		t.Log("want rot", want)
+		t.Fail()
+	}
+
+	// With a different parent, the position of b should be converted into the
+	// parent space of a.
+	p := NewTransform()
+	p.SetPos(lmath.Vec3{-10, -20, -30})
+	b.SetParent(p)
+	c = a.Lerp(b, 1)
+	if want := (lmath.Vec3{0, 0, 0}); !c.Pos().AlmostEquals(want, 1e-6) {
+		t.Log("got pos", c.Pos())
+		t.Log("want pos", want)
+		t.Fail()
+	}
+	if c.Parent() != nil {
+		t.Log("got parent", c.Parent())
+		t.Fail()
+	}
+
+	// So should it's rotation and scale.
+	p.SetScale(lmath.Vec3{2, 2, 2})
+	p.SetRot(lmath.Vec3{0, 0, 90})
+	c = a.Lerp(b, 1)
+	want := b.Convert(LocalToWorld)
+	if got := c.Convert(LocalToWorld); !got.AlmostEquals(want, 1e-6) {
+		t.Log("got local-to-world", got)
+		t.Log("want local-to-world", want)
+		t.Fail()
+	}
+	if want := (lmath.Vec3{6, 6, 6}); !c.Scale().AlmostEquals(want, 1e-6) {
+		t.Log("got scale", c.Scale())
+		t.Log("want scale", want)
+		t.Fail()
+	}
+	if want := (lmath.Vec3{0, 0, 180}); !c.Rot().AlmostEquals(want, 1e-6) && !c.Rot().AlmostEquals(want.MulScalar(-1), 1e-6) {
+		t.Log("got rot", c.Rot())
+		t.Log("want rot", want)
+		t.Fail()
+	}
+}
+
+func TestTransformParentCycle(t *testing.T) {
+	a := NewTransform()
+	b := NewTransform()
+	c := NewTransform()
+	b.SetParent(a)
+	c.SetParent(b)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+		if a.Parent() != nil {
+			t.Fatal("parent assigned despite cycle")
+		}
+	}()
+	a.SetParent(c)
+}
+
+func TestTransformParentMoved(t *testing.T) {
+	parent := NewObject()
+	child := NewObject()
+	child.SetParent(parent)
+	child.SetPos(lmath.Vec3{1, 0, 0})
+
+	// Build the child's transform, then move the parent.
+	child.Mat4()
+	parent.SetPos(lmath.Vec3{0, 5, 0})
+
+	world := child.ConvertPos(lmath.Vec3{0, 0, 0}, LocalToWorld)
+	if want := (lmath.Vec3{1, 5, 0}); !world.AlmostEquals(want, 1e-9) {
+		t.Log("got", world)
+		t.Log("want", want)
+		t.Fail()
+	}
+}
+
 func BenchmarkTransformPos(b *testing.B) {
 	a := NewTransform()
 	positions := [2]lmath.Vec3{
@@ -14,6 +14,13 @@ type TexCoord struct {
 	U, V float32
 }
 
+// TexCoords represents a rectangular region of a texture, where Min is the
+// texture coordinate of the upper-left corner and Max is the texture
+// coordinate of the lower-right corner (like an image.Rectangle).
+type TexCoords struct {
+	Min, Max TexCoord
+}
+
 // Mat4 represents a 32-bit floating point 4x4 matrix for compatability with
 // graphics hardware.
 // lmath.Mat4 should be used anywhere that an explicit 32-bit type is not
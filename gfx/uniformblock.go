@@ -0,0 +1,92 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+import "sync"
+
+// UniformBlock represents a block of uniform data that may be shared by many
+// shaders at once. On OpenGL devices it is a uniform buffer object, and the
+// GLSL shader declares it as a named uniform block, for example:
+//
+//	layout(std140) uniform Camera {
+//	    mat4 View;
+//	    mat4 Projection;
+//	};
+//
+// A uniform block is given to a shader by assigning it as a shader input with
+// the same name as the block in the GLSL source:
+//
+//	shader.Inputs["Camera"] = cameraBlock
+//
+// Each block is bound to the binding point specified by its Binding field,
+// and the device binds it only once per frame regardless of how many shaders
+// make use of it. As such, every shader using the same block name should use
+// the same *UniformBlock.
+//
+// Uniform blocks are only supported by some devices, see the UniformBlocks
+// field of DeviceInfo.
+type UniformBlock struct {
+	// The native object of this uniform block. Once the block is used by a
+	// device this field will be initialized by the device. Only device
+	// implementations should assign values to this field.
+	NativeUniformBlock Destroyable
+
+	// The binding point of this uniform block, it should be unique among all
+	// of the blocks in use at once and below the device's maximum number of
+	// binding points.
+	Binding int
+
+	// The raw uniform data, which must follow the memory layout of the block
+	// declared in the shader (e.g. the std140 layout rules).
+	Data []byte
+
+	// Weather or not the Data slice of this block has changed since the last
+	// time the block was used. If set to true the device should take note and
+	// re-upload the data slice to the graphics hardware.
+	Changed bool
+}
+
+// Copy returns a new copy of this uniform block. It makes a deep copy of the
+// Data slice. Explicitly not copied is the native uniform block.
+func (b *UniformBlock) Copy() *UniformBlock {
+	cpy := NewUniformBlock(b.Binding)
+	cpy.Data = append(cpy.Data, b.Data...)
+	cpy.Changed = true
+	return cpy
+}
+
+// Reset resets this uniform block to it's default (NewUniformBlock) state.
+func (b *UniformBlock) Reset() {
+	b.NativeUniformBlock = nil
+	b.Binding = 0
+	b.Data = b.Data[:0]
+	b.Changed = false
+}
+
+// Destroy destroys this uniform block for use by other callees to
+// NewUniformBlock. You must not use it after calling this method. This makes
+// an implicit call to b.NativeUniformBlock.Destroy.
+func (b *UniformBlock) Destroy() {
+	if b.NativeUniformBlock != nil {
+		b.NativeUniformBlock.Destroy()
+	}
+	b.Reset()
+	uniformBlockPool.Put(b)
+}
+
+var uniformBlockPool = sync.Pool{
+	New: func() interface{} {
+		return new(UniformBlock)
+	},
+}
+
+// NewUniformBlock returns a new *UniformBlock at the given binding point, for
+// effeciency it may be a re-used one (see the Destroy method) whose Data slice
+// has a zero-length.
+func NewUniformBlock(binding int) *UniformBlock {
+	b := uniformBlockPool.Get().(*UniformBlock)
+	b.Binding = binding
+	return b
+}
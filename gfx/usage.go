@@ -0,0 +1,24 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gfx
+
+// Usage is a hint (it does not restrict how data may be used) to the graphics
+// device on how often the data of e.g. a mesh will be updated, such that the
+// device may store it in the most appropriate type of memory.
+type Usage uint8
+
+const (
+	// Static is a usage hint for data that is specified once and used many
+	// times.
+	Static Usage = iota
+
+	// Dynamic is a usage hint for data that is updated often (e.g. every few
+	// frames) and used many times between updates.
+	Dynamic
+
+	// Stream is a usage hint for data that is updated (about) every time it
+	// is used, e.g. particle systems updated every frame.
+	Stream
+)
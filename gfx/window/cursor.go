@@ -0,0 +1,53 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package window
+
+// StandardCursor represents a standard cursor shape provided by the operating
+// system.
+type StandardCursor uint8
+
+// String returns a string representation of this cursor shape.
+func (c StandardCursor) String() string {
+	switch c {
+	case DefaultCursor:
+		return "DefaultCursor"
+	case ArrowCursor:
+		return "ArrowCursor"
+	case IBeamCursor:
+		return "IBeamCursor"
+	case CrosshairCursor:
+		return "CrosshairCursor"
+	case HandCursor:
+		return "HandCursor"
+	case HResizeCursor:
+		return "HResizeCursor"
+	case VResizeCursor:
+		return "VResizeCursor"
+	}
+	return "StandardCursor(invalid)"
+}
+
+const (
+	// The default cursor of the operating system.
+	DefaultCursor StandardCursor = iota
+
+	// A regular arrow cursor.
+	ArrowCursor
+
+	// A text input I-beam cursor.
+	IBeamCursor
+
+	// A crosshair cursor.
+	CrosshairCursor
+
+	// A hand cursor, typically used for links.
+	HandCursor
+
+	// A horizontal resize arrow cursor.
+	HResizeCursor
+
+	// A vertical resize arrow cursor.
+	VResizeCursor
+)
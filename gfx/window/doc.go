@@ -172,12 +172,46 @@
 //	    fmt.Println("On the main thread!")
 //	}
 //
+// To also wait for the function to complete, use the Do method of a window
+// instead.
+//
 // More complex situations can be handled as well, by implementing the (small)
 // MainLoop function yourself.
 //
 // Because a channel is used, the main loop is said to be communicative rather
 // than employing a busy-waiting scheme.
 //
+// # Custom Main Loops
+//
+// Programs which already have a main loop of their own (e.g. one embedding
+// another GUI toolkit) can pump the main loop themselves instead of calling
+// MainLoop, using the PollEvents and WaitEvents functions. PollEvents executes
+// pending main loop functions and processes window events without blocking,
+// while WaitEvents sleeps until there is something to do (or a timeout):
+//
+//	func main() {
+//	    go gfxLoop()
+//	    for window.WaitEvents(100 * time.Millisecond) {
+//	        ... other work on the main OS thread ...
+//	    }
+//	}
+//
+// Sleeping in WaitEvents avoids busy-waiting, which reduces the idle CPU and
+// GPU usage of tools and editors that do not need to render continuously.
+//
+// # On-Demand Rendering
+//
+// Windows render continuously by default. Tools and editors, whose contents
+// only change in response to input, can instead render on-demand:
+//
+//	props := window.NewProps()
+//	props.SetContinuousRender(false)
+//	window.Run(gfxLoop, props)
+//
+// The device's Render method then blocks until the window receives an event
+// or it's Invalidate method is called (e.g. once a background task finishes),
+// and MainLoop sleeps until window events arrive.
+//
 // # Build Tags
 //
 // The build tag "gles2" is accepted on 386 and amd64 architectures to choose
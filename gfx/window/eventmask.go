@@ -73,6 +73,14 @@ const (
 	// KeyboardButtonEvents is a event mask matching keyboard.ButtonEvent's.
 	KeyboardButtonEvents
 
+	// KeyboardCompositionEvents is a event mask matching keyboard.Composition
+	// events.
+	KeyboardCompositionEvents
+
+	// ContentScaleChangedEvents is a event mask matching
+	// window.ContentScaleChanged events.
+	ContentScaleChangedEvents
+
 	// NoEvents is a event mask matching no events at all.
 	NoEvents EventMask = 0
 
@@ -100,6 +108,7 @@ const (
 	//
 	//  keyboard.ButtonEvent
 	//  keyboard.Typed
+	//  keyboard.Composition
 	//
-	KeyboardEvents EventMask = KeyboardButtonEvents | KeyboardTypedEvents
+	KeyboardEvents EventMask = KeyboardButtonEvents | KeyboardTypedEvents | KeyboardCompositionEvents
 )
@@ -6,6 +6,7 @@ package window
 
 import (
 	"fmt"
+	"image"
 	"time"
 )
 
@@ -264,20 +265,68 @@ func (ev FramebufferResized) Time() time.Time {
 	return ev.T
 }
 
+// ContentScaleChanged is an event where the content scale (DPI scaling factor)
+// of the window has changed, e.g. because it was moved to a monitor with a
+// different DPI. See Props.ContentScale.
+type ContentScaleChanged struct {
+	// The new content scale of the window.
+	X, Y float32
+
+	T time.Time
+}
+
+// String returns a string representation of this event.
+func (ev ContentScaleChanged) String() string {
+	return fmt.Sprintf("ContentScaleChanged(X=%v, Y=%v, Time=%v)", ev.X, ev.Y, ev.T)
+}
+
+// Time implements the Event interface.
+func (ev ContentScaleChanged) Time() time.Time {
+	return ev.T
+}
+
 // ItemsDropped is an event where the user dropped an item (or multiple items)
 // onto the window.
+//
+// The window cannot reject a drop, but a drop can be ignored based on where
+// it occurred (see the In method, and DroppedIn for use with Subscribe).
 type ItemsDropped struct {
 	Items []string
 
+	// Position of cursor at the time of the drop, relative to the upper-left
+	// corner of the window.
+	X, Y float64
+
 	T time.Time
 }
 
+// In tells if the items were dropped inside of the given rectangle, which is
+// relative to the upper-left corner of the window.
+func (ev ItemsDropped) In(r image.Rectangle) bool {
+	return image.Pt(int(ev.X), int(ev.Y)).In(r)
+}
+
 // String returns a string representation of this event.
 func (ev ItemsDropped) String() string {
-	return fmt.Sprintf("ItemsDropped(Items=%v, Time=%v)", ev.Items, ev.T)
+	return fmt.Sprintf("ItemsDropped(Items=%v, X=%f, Y=%f, Time=%v)", ev.Items, ev.X, ev.Y, ev.T)
 }
 
 // Time implements the Event interface.
 func (ev ItemsDropped) Time() time.Time {
 	return ev.T
 }
+
+// DroppedIn returns a filter for the Subscribe method of a window, which
+// relays only ItemsDropped events whose items were dropped inside of the given
+// rectangle (see the ItemsDropped.In method). For example, to implement a
+// file drop target in a user interface:
+//
+//  drops, cancel := w.Subscribe(window.ItemsDroppedEvents, window.DroppedIn(target))
+//  defer cancel()
+//
+func DroppedIn(r image.Rectangle) func(Event) bool {
+	return func(ev Event) bool {
+		d, ok := ev.(ItemsDropped)
+		return ok && d.In(r)
+	}
+}
@@ -7,6 +7,8 @@
 package window
 
 import (
+	"math"
+
 	"github.com/qmcloud/engine/keyboard"
 	"github.com/qmcloud/engine/mouse"
 
@@ -35,6 +37,46 @@ func convertKeyAction(a glfw.Action) keyboard.State {
 	}
 }
 
+// convertScroll normalizes the scroll offsets reported by GLFW. Non-finite
+// offsets (which some drivers report for e.g. the first horizontal scroll
+// event) are treated as zero. Stepped mouse wheels only ever report whole
+// steps, so any fractional offset means the source was a smooth scrolling
+// device such as a trackpad.
+func convertScroll(x, y float64) (sx, sy float64, smooth bool) {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		x = 0
+	}
+	if math.IsNaN(y) || math.IsInf(y, 0) {
+		y = 0
+	}
+	smooth = x != math.Trunc(x) || y != math.Trunc(y)
+	return x, y, smooth
+}
+
+// convertStandardCursor converts the given standard cursor shape into a GLFW
+// one. The boolean is false for DefaultCursor, for which the GLFW window
+// cursor should simply be unset.
+func convertStandardCursor(c StandardCursor) (glfw.StandardCursor, bool) {
+	switch c {
+	case DefaultCursor:
+		return 0, false
+	case ArrowCursor:
+		return glfw.ArrowCursor, true
+	case IBeamCursor:
+		return glfw.IBeamCursor, true
+	case CrosshairCursor:
+		return glfw.CrosshairCursor, true
+	case HandCursor:
+		return glfw.HandCursor, true
+	case HResizeCursor:
+		return glfw.HResizeCursor, true
+	case VResizeCursor:
+		return glfw.VResizeCursor, true
+	default:
+		panic("unhandled standard cursor")
+	}
+}
+
 func convertMouseButton(b glfw.MouseButton) mouse.Button {
 	switch b {
 	case glfw.MouseButton1:
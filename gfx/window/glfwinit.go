@@ -8,6 +8,7 @@ package window
 import (
 	"os"
 	"runtime"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-gl/glfw/v3.1/glfw"
@@ -35,8 +36,23 @@ var (
 
 	// Signals shutdown to the event poller goroutine.
 	pollerExit chan struct{}
+
+	// Whether or not the program pumps events itself, via PollEvents or
+	// WaitEvents, in which case the event poller goroutine does not.
+	selfPumped atomic.Bool
+
+	// The number of open windows which render on-demand, see
+	// Props.SetContinuousRender.
+	onDemandWindows atomic.Int32
 )
 
+// allOnDemand tells if every open window renders on-demand, in which case
+// MainLoop sleeps until events arrive rather than polling for them.
+func allOnDemand() bool {
+	n := int(onDemandWindows.Load())
+	return n > 0 && n == Num(0)
+}
+
 // assetLoader is the goroutine responsible for running the asset device.
 func assetLoader() {
 	exec := asset.glfwDevice.Exec()
@@ -96,6 +112,12 @@ func pollEvents() {
 		default:
 		}
 
+		// The program is pumping events itself, or the main loop is waiting
+		// for events because every window renders on-demand.
+		if selfPumped.Load() || allOnDemand() {
+			continue
+		}
+
 		// Poll for events in the main loop, or exit.
 		select {
 		case <-pollerExit:
@@ -105,6 +127,66 @@ func pollEvents() {
 	}
 }
 
+// pollNow polls for pending GLFW events, if GLFW is initialized. It may only
+// be called on the main thread.
+func pollNow() {
+	if glfwInit {
+		glfw.PollEvents()
+	}
+}
+
+// waitEvents blocks until GLFW events are available, a function is sent over
+// MainLoopChan, or the timeout (if positive) elapses. It returns the main loop
+// function received, if any. It may only be called on the main thread.
+func waitEvents(timeout time.Duration) (f func(), received bool) {
+	var timer <-chan time.Time
+	if timeout > 0 {
+		t := time.NewTimer(timeout)
+		defer t.Stop()
+		timer = t.C
+	}
+	if !glfwInit {
+		select {
+		case f = <-MainLoopChan:
+			return f, true
+		case <-timer:
+			return nil, false
+		}
+	}
+
+	// GLFW v3.1 has no glfwWaitEventsTimeout, instead we wake the main thread
+	// by posting an empty event once a main loop function arrives, or the
+	// timeout elapses.
+	var (
+		stop   = make(chan struct{})
+		exited = make(chan struct{})
+		got    = make(chan func(), 1)
+	)
+	go func() {
+		defer close(exited)
+		select {
+		case fn := <-MainLoopChan:
+			got <- fn
+			glfw.PostEmptyEvent()
+		case <-timer:
+			glfw.PostEmptyEvent()
+		case <-stop:
+		}
+	}()
+	glfw.WaitEvents()
+
+	// Wait for the goroutine to exit, so that it cannot receive a main loop
+	// function after we return.
+	close(stop)
+	<-exited
+	select {
+	case f = <-got:
+		return f, true
+	default:
+		return nil, false
+	}
+}
+
 // doInit initializes GLFW and the hidden asset window/device, if not already
 // initialized.
 func doInit() error {
@@ -0,0 +1,79 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//go:build 386 || amd64
+// +build 386 amd64
+
+package window
+
+import (
+	"image"
+
+	"github.com/go-gl/glfw/v3.1/glfw"
+)
+
+// convertVideoMode converts a GLFW video mode into a VideoMode.
+func convertVideoMode(vm *glfw.VidMode) VideoMode {
+	return VideoMode{
+		Width:       vm.Width,
+		Height:      vm.Height,
+		RedBits:     vm.RedBits,
+		GreenBits:   vm.GreenBits,
+		BlueBits:    vm.BlueBits,
+		RefreshRate: vm.RefreshRate,
+	}
+}
+
+// convertMonitor converts a GLFW monitor into a Monitor.
+func convertMonitor(m *glfw.Monitor, primary bool) Monitor {
+	x, y := m.GetPos()
+	pw, ph := m.GetPhysicalSize()
+	mon := Monitor{
+		Name:           m.GetName(),
+		Pos:            image.Pt(x, y),
+		PhysicalWidth:  pw,
+		PhysicalHeight: ph,
+		Primary:        primary,
+		VideoMode:      convertVideoMode(m.GetVideoMode()),
+	}
+	for _, vm := range m.GetVideoModes() {
+		mon.modes = append(mon.modes, convertVideoMode(vm))
+	}
+	return mon
+}
+
+// doMonitors is the implementation of Monitors, it may only be called on the
+// main thread.
+func doMonitors() []Monitor {
+	if err := doInit(); err != nil {
+		logError(err)
+		return nil
+	}
+	var monitors []Monitor
+	primary := glfw.GetPrimaryMonitor()
+	if primary != nil {
+		monitors = append(monitors, convertMonitor(primary, true))
+	}
+	for _, m := range glfw.GetMonitors() {
+		if m == primary {
+			continue
+		}
+		monitors = append(monitors, convertMonitor(m, false))
+	}
+	return monitors
+}
+
+// findMonitor returns the GLFW monitor identified by m, or the primary
+// monitor if m is nil or is no longer connected. It may only be called on
+// the main thread.
+func findMonitor(m *Monitor) *glfw.Monitor {
+	if m != nil {
+		for _, gm := range glfw.GetMonitors() {
+			x, y := gm.GetPos()
+			if gm.GetName() == m.Name && image.Pt(x, y) == m.Pos {
+				return gm
+			}
+		}
+	}
+	return glfw.GetPrimaryMonitor()
+}
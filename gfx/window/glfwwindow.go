@@ -7,6 +7,8 @@
 package window
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"image"
 	"io"
@@ -33,6 +35,25 @@ func intBool(b bool) int {
 	return 0
 }
 
+// contentScale returns the content scale of the given window. GLFW only exposes
+// it (glfwGetWindowContentScale) as of version 3.3, so with the 3.1 bindings we
+// use it is derived from the ratio of the framebuffer and window sizes instead.
+// This is correct on OS X, where HiDPI framebuffers are larger than the window,
+// other platforms always report a scale of 1.
+//
+// The scale cannot be determined while the window has a zero size (e.g. when
+// it is minimized on Windows), in which case ok == false.
+//
+// It may only be called on the main thread.
+func contentScale(w *glfw.Window) (x, y float32, ok bool) {
+	width, height := w.GetSize()
+	fbWidth, fbHeight := w.GetFramebufferSize()
+	if width <= 0 || height <= 0 || fbWidth <= 0 || fbHeight <= 0 {
+		return 1, 1, false
+	}
+	return float32(fbWidth) / float32(width), float32(fbHeight) / float32(height), true
+}
+
 // logError simply logs the error.
 func logError(err error) {
 	if err != nil {
@@ -44,6 +65,19 @@ type glfwDevice interface {
 	gfx.Device
 	Exec() chan func() bool
 	UpdateBounds(bounds image.Rectangle)
+	BeginGPUTimer()
+	EndGPUTimer(done chan time.Duration)
+	SetWireframe(enabled bool) bool
+	OnPreRender(f func())
+	OnPostRender(f func())
+	DrawMulti(m *gfx.Mesh, ranges []gfx.MeshRange, s *gfx.State, shader *gfx.Shader, c gfx.Camera)
+	RenderDo(f func())
+	RenderContext(ctx context.Context) error
+	DrawContext(ctx context.Context, rect image.Rectangle, o *gfx.Object, c gfx.Camera) error
+	Flush()
+	SetGarbageInterval(d time.Duration)
+	FreeNow()
+	MemoryUsage() gfx.MemoryStats
 	SetDebugOutput(w io.Writer)
 	RestoreState()
 	Destroy()
@@ -58,6 +92,7 @@ type glfwWindow struct {
 	keyboard                                           *keyboard.Watcher
 	extWGLEXTSwapControlTear, extGLXEXTSwapControlTear bool
 	exit, rebuild, waitNextFrame                       chan struct{}
+	invalidated, closing                               chan struct{}
 
 	// The below variables are read-write after initialization of this struct,
 	// and as such must only be modified under the RWMutex.
@@ -67,9 +102,13 @@ type glfwWindow struct {
 	device                   glfwDevice
 	window                   *glfw.Window
 	monitor                  *glfw.Monitor
+	cursor                   *glfw.Cursor
 	beforeFullscreen         [2]int // Window size before fullscreen.
+	beforeBorderless         [4]int // Window size and position before borderless fullscreen.
 	lastCursorX, lastCursorY float64
+	lastCursorGen            uint64 // Generation of the cursor image in use.
 	closed, runInvoked       bool
+	onDemand                 bool
 }
 
 // Props implements the Window interface.
@@ -134,10 +173,66 @@ func (w *glfwWindow) Close() {
 	w.closed = true
 	w.Unlock()
 
-	// Signal to the window of it's closing.
+	// Wake up a pending on-demand Render call, and signal to the window of
+	// it's closing.
+	close(w.closing)
 	w.exit <- struct{}{}
 }
 
+// Do implements the Window interface.
+func (w *glfwWindow) Do(f func()) {
+	w.waitFor(f)
+}
+
+// Invalidate implements the Window interface.
+func (w *glfwWindow) Invalidate() {
+	select {
+	case w.invalidated <- struct{}{}:
+	default:
+	}
+}
+
+// sendEvent sends the event to the notifier, and then invalidates the window
+// such that on-demand windows render a frame in response to the event.
+func (w *glfwWindow) sendEvent(ev Event, m EventMask) {
+	w.notifier.sendEvent(ev, m)
+	w.Invalidate()
+}
+
+// waitFrame blocks until the next frame should be rendered. For continuously
+// rendering windows that is immediately, for on-demand ones it is once the
+// window is invalidated or closed.
+//
+// It is called by the swapper at the end of each call to Render.
+func (w *glfwWindow) waitFrame() {
+	w.RLock()
+	continuous := w.props.ContinuousRender()
+	w.RUnlock()
+	if continuous {
+		return
+	}
+	select {
+	case <-w.invalidated:
+	case <-w.closing:
+	}
+}
+
+// setOnDemand sets whether or not the window renders on-demand, keeping the
+// number of on-demand windows up to date.
+//
+// It may only be called under the presence of the window's write lock.
+func (w *glfwWindow) setOnDemand(onDemand bool) {
+	if w.onDemand == onDemand {
+		return
+	}
+	w.onDemand = onDemand
+	if onDemand {
+		onDemandWindows.Add(1)
+	} else {
+		onDemandWindows.Add(-1)
+	}
+}
+
 // waitFor runs f on the main thread and waits for the function to complete.
 func (w *glfwWindow) waitFor(f func()) {
 	done := make(chan bool, 1)
@@ -194,12 +289,73 @@ func (w *glfwWindow) useProps(p *Props, force bool) {
 			w.props.SetSize(w.beforeFullscreen[0], w.beforeFullscreen[1])
 		}
 
+		// If we're switching to fullscreen from borderless fullscreen, restore
+		// the window size and position from before we entered borderless
+		// fullscreen (such that exiting fullscreen restores them).
+		if fullscreen && w.last.BorderlessFullscreen() {
+			w.last.SetBorderlessFullscreen(false)
+			w.props.SetSize(w.beforeBorderless[0], w.beforeBorderless[1])
+			w.props.SetPos(w.beforeBorderless[2], w.beforeBorderless[3])
+		}
+
 		// Signal to the window goroutine that we need a window rebuild now, it
 		// will call useProps on it's own to initialize the new window.
 		w.rebuild <- struct{}{}
 		return
 	}
 
+	// Changing the number of multisampling samples also requires a rebuild,
+	// as it can only be chosen at window (and OpenGL context) creation time.
+	if samples := w.props.Samples(); samples != w.last.Samples() {
+		w.last.SetSamples(samples)
+		w.rebuild <- struct{}{}
+		return
+	}
+
+	// Switching the monitor or video mode of a fullscreen window also requires
+	// a rebuild, as GLFW 3.1 has no way to move a fullscreen window to another
+	// monitor or change it's video mode at runtime.
+	monitor := w.props.Monitor()
+	videoMode := w.props.VideoMode()
+	monitorChanged := !sameMonitor(monitor, w.last.Monitor())
+	if monitorChanged || !sameVideoMode(videoMode, w.last.VideoMode()) {
+		w.last.SetMonitor(monitor)
+		w.last.SetVideoMode(videoMode)
+		if fullscreen {
+			w.rebuild <- struct{}{}
+			return
+		}
+		w.monitor = findMonitor(monitor)
+	}
+
+	// Borderless fullscreen mode. Unlike fullscreen mode no rebuild is needed,
+	// we simply cover the monitor with the window. Fullscreen mode takes
+	// priority over it.
+	borderless := w.props.BorderlessFullscreen() && !fullscreen
+	lastBorderless := w.last.BorderlessFullscreen()
+	if force || monitorChanged || borderless != lastBorderless {
+		w.last.SetBorderlessFullscreen(borderless)
+		if borderless {
+			// Save the window size and position for restoration after we've
+			// exited borderless fullscreen later.
+			if !lastBorderless {
+				bw, bh := w.props.Size()
+				bx, by := w.props.Pos()
+				w.beforeBorderless = [4]int{bw, bh, bx, by}
+			}
+
+			// GLFW 3.1 has no way to query the monitor's work area, so we use
+			// the monitor's full video mode instead.
+			vm := w.monitor.GetVideoMode()
+			mx, my := w.monitor.GetPos()
+			w.props.SetSize(vm.Width, vm.Height)
+			w.props.SetPos(mx, my)
+		} else if lastBorderless {
+			w.props.SetSize(w.beforeBorderless[0], w.beforeBorderless[1])
+			w.props.SetPos(w.beforeBorderless[2], w.beforeBorderless[3])
+		}
+	}
+
 	// Set each property, only if it differs from the last known value for that
 	// property.
 
@@ -211,7 +367,7 @@ func (w *glfwWindow) useProps(p *Props, force bool) {
 	if force || width != lastWidth || height != lastHeight {
 		// If we're not switching to fullscreen, save the window size as it was
 		// for restoration after we've exited fullscreen later.
-		if !fullscreen {
+		if !fullscreen && !borderless {
 			w.beforeFullscreen = [2]int{width, height}
 		}
 
@@ -228,8 +384,9 @@ func (w *glfwWindow) useProps(p *Props, force bool) {
 		w.last.SetPos(x, y)
 		if x == -1 && y == -1 {
 			vm := w.monitor.GetVideoMode()
-			x = (vm.Width / 2) - (width / 2)
-			y = (vm.Height / 2) - (height / 2)
+			mx, my := w.monitor.GetPos()
+			x = mx + (vm.Width / 2) - (width / 2)
+			y = my + (vm.Height / 2) - (height / 2)
 		}
 		withoutLock(func() {
 			win.SetPos(x, y)
@@ -276,12 +433,22 @@ func (w *glfwWindow) useProps(p *Props, force bool) {
 
 	// Vertical sync mode.
 	vsync := w.props.VSync()
-	if force || w.last.VSync() != vsync {
+	interval, intervalSet := w.props.SwapInterval()
+	lastInterval, lastIntervalSet := w.last.SwapInterval()
+	if force || w.last.VSync() != vsync || interval != lastInterval || intervalSet != lastIntervalSet {
 		w.last.SetVSync(vsync)
+		if intervalSet {
+			w.last.SetSwapInterval(interval)
+		} else {
+			w.last.ResetSwapInterval()
+		}
 
 		// Determine the swap interval and set it.
 		var swapInterval int
-		if vsync {
+		if intervalSet {
+			// An explicit swap interval takes precedence over vsync.
+			swapInterval = interval
+		} else if vsync {
 			// We want vsync on, we will use adaptive vsync if we have it, if
 			// not we will use standard vsync.
 			if w.extWGLEXTSwapControlTear || w.extGLXEXTSwapControlTear {
@@ -323,6 +490,71 @@ func (w *glfwWindow) useProps(p *Props, force bool) {
 			}
 		})
 	}
+
+	// Cursor image / shape.
+	cursorImg, hotspot, cursorGen := w.props.cursorImageGen()
+	_, lastHotspot := w.last.CursorImage()
+	stdCursor := w.props.StandardCursor()
+	if force || cursorGen != w.lastCursorGen || hotspot != lastHotspot || w.last.StandardCursor() != stdCursor {
+		w.last.SetStandardCursor(stdCursor)
+		w.last.SetCursorImage(cursorImg, hotspot)
+		w.lastCursorGen = cursorGen
+
+		// Create the new cursor, a nil cursor means the default one.
+		var cursor *glfw.Cursor
+		if cursorImg != nil {
+			cursor = glfw.CreateCursor(cursorImg, hotspot.X, hotspot.Y)
+		} else if shape, ok := convertStandardCursor(stdCursor); ok {
+			cursor = glfw.CreateStandardCursor(int(shape))
+		}
+
+		// Swap in the new cursor and destroy the old one, which is no longer
+		// in use.
+		withoutLock(func() {
+			win.SetCursor(cursor)
+		})
+		if w.cursor != nil {
+			w.cursor.Destroy()
+		}
+		w.cursor = cursor
+	}
+
+	// Raw mouse input.
+	raw := w.props.RawMouseInput()
+	if force || w.last.RawMouseInput() != raw {
+		w.last.SetRawMouseInput(raw)
+
+		// GLFW only exposes raw mouse motion (GLFW_RAW_MOUSE_MOTION) as of
+		// version 3.3, the 3.1 bindings we use cannot enable it. Let the user
+		// know instead of silently ignoring the request.
+		if raw {
+			logError(errors.New("raw mouse input is not supported by the GLFW 3.1 backend"))
+		}
+	}
+
+	// Window opacity.
+	opacity := w.props.Opacity()
+	if force || w.last.Opacity() != opacity {
+		w.last.SetOpacity(opacity)
+
+		// GLFW only exposes window opacity (glfwSetWindowOpacity) as of
+		// version 3.3, the 3.1 bindings we use cannot change it. The window
+		// remains opaque.
+		if opacity != 1 {
+			logError(errors.New("window opacity is not supported by the GLFW 3.1 backend"))
+		}
+	}
+
+	// Continuous or on-demand rendering.
+	continuous := w.props.ContinuousRender()
+	if force || w.last.ContinuousRender() != continuous {
+		w.last.SetContinuousRender(continuous)
+		w.setOnDemand(!continuous)
+
+		// Wake up a pending on-demand Render call, as the window may now be
+		// rendering continuously.
+		w.Invalidate()
+	}
 }
 
 // initCallbacks sets a callback handler for each GLFW window event.
@@ -354,8 +586,13 @@ func (w *glfwWindow) initCallbacks() {
 		w.RLock()
 		runInvoked := w.runInvoked
 		resizeRenderSync := w.props.ResizeRenderSync()
+		continuous := w.props.ContinuousRender()
 		w.RUnlock()
-		if runInvoked && resizeRenderSync {
+
+		// On-demand windows render the next frame in response to the Damaged
+		// event above, but the render loop may not be running at all so we
+		// cannot wait for it.
+		if runInvoked && resizeRenderSync && continuous {
 			// TODO(slimsag): there is probably a way to record frame start too, so we
 			// do not need to wait for a secondary frame if the previous one was not
 			// started before the rezise event began.
@@ -386,6 +623,16 @@ func (w *glfwWindow) initCallbacks() {
 		w.RLock()
 		w.last.SetFocused(focused)
 		w.props.SetFocused(focused)
+
+		// Reset both last cursor values so the cursor callback can identify
+		// the large/fake delta caused by the cursor moving while the window
+		// was not focused. Like in the cursor callback, it's safe to modify
+		// them with just w.RLock because they are only modified on the main
+		// thread.
+		if focused {
+			w.lastCursorX = math.Inf(-1)
+			w.lastCursorY = math.Inf(-1)
+		}
 		w.RUnlock()
 
 		// Send the proper event.
@@ -415,7 +662,7 @@ func (w *glfwWindow) initCallbacks() {
 	w.window.SetSizeCallback(func(gw *glfw.Window, width, height int) {
 		// Store the size state.
 		w.Lock()
-		if !w.last.Fullscreen() {
+		if !w.last.Fullscreen() && !w.last.BorderlessFullscreen() {
 			// If we're not currently in fullscreen, save the window size as it
 			// was for restoration after we've exited fullscreen later.
 			w.beforeFullscreen = [2]int{width, height}
@@ -447,11 +694,36 @@ func (w *glfwWindow) initCallbacks() {
 			Height: height,
 			T:      time.Now(),
 		}, FramebufferResizedEvents)
+
+		// The content scale may have changed too, e.g. when the window moved
+		// to a monitor with a different DPI.
+		sx, sy, ok := contentScale(gw)
+		w.RLock()
+		lastX, lastY := w.last.ContentScale()
+		changed := ok && (sx != lastX || sy != lastY)
+		if changed {
+			w.last.SetContentScale(sx, sy)
+			w.props.SetContentScale(sx, sy)
+		}
+		w.RUnlock()
+		if changed {
+			w.sendEvent(ContentScaleChanged{
+				X: sx,
+				Y: sy,
+				T: time.Now(),
+			}, ContentScaleChangedEvents)
+		}
 	})
 
 	// Dropped event.
 	w.window.SetDropCallback(func(gw *glfw.Window, items []string) {
-		w.sendEvent(ItemsDropped{Items: items, T: time.Now()}, ItemsDroppedEvents)
+		x, y := gw.GetCursorPos()
+		w.sendEvent(ItemsDropped{
+			Items: items,
+			X:     x,
+			Y:     y,
+			T:     time.Now(),
+		}, ItemsDroppedEvents)
 	})
 
 	// CursorMoved event.
@@ -505,6 +777,10 @@ func (w *glfwWindow) initCallbacks() {
 	})
 
 	// keyboard.Typed
+	//
+	// GLFW 3.1 does not expose input method (IME) preedit text, so no
+	// keyboard.Composition events are sent; composed text arrives here once
+	// it has been committed.
 	w.window.SetCharCallback(func(gw *glfw.Window, r rune) {
 		w.sendEvent(keyboard.Typed{S: string(r), T: time.Now()}, KeyboardTypedEvents)
 	})
@@ -512,6 +788,21 @@ func (w *glfwWindow) initCallbacks() {
 	// keyboard.ButtonEvent
 	w.window.SetKeyCallback(func(gw *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
 		if action == glfw.Repeat {
+			// Key repeats are only sent when requested, and never change the
+			// keyboard watcher state as the key is already down.
+			w.RLock()
+			keyRepeat := w.props.KeyRepeat()
+			w.RUnlock()
+			if !keyRepeat {
+				return
+			}
+			w.sendEvent(keyboard.ButtonEvent{
+				T:      time.Now(),
+				Key:    convertKey(key),
+				State:  keyboard.Down,
+				Raw:    uint64(scancode),
+				Repeat: true,
+			}, KeyboardButtonEvents)
 			return
 		}
 
@@ -552,10 +843,21 @@ func (w *glfwWindow) initCallbacks() {
 
 	// mouse.Scrolled event.
 	w.window.SetScrollCallback(func(gw *glfw.Window, x, y float64) {
+		// Convert GLFW event.
+		x, y, smooth := convertScroll(x, y)
+		if x == 0 && y == 0 {
+			return
+		}
+
+		// Update mouse watcher.
+		w.mouse.AddScroll(x, y)
+
+		// Send the event.
 		w.sendEvent(mouse.Scrolled{
-			T: time.Now(),
-			X: x,
-			Y: y,
+			T:      time.Now(),
+			X:      x,
+			Y:      y,
+			Smooth: smooth,
 		}, MouseScrolledEvents)
 	})
 }
@@ -592,6 +894,10 @@ func (w *glfwWindow) run() {
 		// Destroy the window on the main thread.
 		MainLoopChan <- func() {
 			w.window.Destroy()
+			if w.cursor != nil {
+				w.cursor.Destroy()
+				w.cursor = nil
+			}
 		}
 	}
 
@@ -621,6 +927,11 @@ func (w *glfwWindow) run() {
 		case <-w.exit:
 			cleanup()
 
+			// The window no longer counts towards the on-demand windows.
+			w.Lock()
+			w.setOnDemand(false)
+			w.Unlock()
+
 			// Decrement the number of open windows by one.
 			windowCount := Num(-1)
 
@@ -643,6 +954,10 @@ func (w *glfwWindow) run() {
 			// swapper that it should yield when it can.
 			w.swapper.Yield <- struct{}{}
 
+			// On-demand windows may be waiting for the next frame inside of
+			// Render, wake them up so that the swapper can yield.
+			w.Invalidate()
+
 			// Execute functions on the existing window until the swapper
 			// yields for us.
 		sr:
@@ -710,16 +1025,24 @@ func (w *glfwWindow) build() error {
 		dstWidth, dstHeight = p.Size()
 	)
 
-	// Specify the primary monitor if we want fullscreen, store the monitor
-	// regardless for centering the window.
-	w.monitor = glfw.GetPrimaryMonitor()
+	// Specify the selected monitor (or the primary one) if we want fullscreen,
+	// store the monitor regardless for centering the window.
+	monitor, videoMode := p.Monitor(), p.VideoMode()
+	w.last.SetMonitor(monitor)
+	w.last.SetVideoMode(videoMode)
+	w.monitor = findMonitor(monitor)
+	refreshRate := glfw.DontCare
 	if p.Fullscreen() {
 		dstMonitor = w.monitor
 		w.beforeFullscreen = [2]int{dstWidth, dstHeight}
 
-		// TODO(slimsag): publish a way to get valid video modes instead of
-		// assuming the monitor's one.
-		vm := w.monitor.GetVideoMode()
+		// Use the requested video mode, or else the monitor's current one. GLFW
+		// chooses the closest video mode the monitor supports.
+		vm := convertVideoMode(w.monitor.GetVideoMode())
+		if videoMode != nil {
+			vm = *videoMode
+			refreshRate = vm.RefreshRate
+		}
 		dstWidth, dstHeight = vm.Width, vm.Height
 		w.props.SetSize(dstWidth, dstHeight)
 		w.last.SetSize(dstWidth, dstHeight)
@@ -730,13 +1053,14 @@ func (w *glfwWindow) build() error {
 	// Hint standard properties (note visibility is always false, we show the
 	// window later after moving it).
 	prec := p.Precision()
+	w.last.SetSamples(prec.Samples)
 	hints := map[glfw.Hint]int{
 		glfw.Visible: 0,
 		// TODO(slimsag): once GLFW 3.1 is released we can use these hints:
 		//glfw.Focused: intBool(p.Focused()),
 		//glfw.Iconified: intBool(p.Minimized()),
 		glfw.Resizable:           intBool(p.Resizable()),
-		glfw.Decorated:           intBool(p.Decorated()),
+		glfw.Decorated:           intBool(p.Decorated() && !p.BorderlessFullscreen()),
 		glfw.AutoIconify:         1,
 		glfw.Floating:            intBool(p.AlwaysOnTop()),
 		glfw.RedBits:             int(prec.RedBits),
@@ -751,6 +1075,7 @@ func (w *glfwWindow) build() error {
 		glfw.ContextVersionMajor: glfwContextVersionMajor,
 		glfw.ContextVersionMinor: glfwContextVersionMinor,
 		glfw.ClientAPI:           glfwClientAPI,
+		glfw.RefreshRate:         refreshRate,
 	}
 	for hint, value := range hints {
 		glfw.WindowHint(hint, value)
@@ -782,6 +1107,12 @@ func (w *glfwWindow) build() error {
 	w.extWGLEXTSwapControlTear = glfw.ExtensionSupported("WGL_EXT_swap_control_tear")
 	w.extGLXEXTSwapControlTear = glfw.ExtensionSupported("GLX_EXT_swap_control_tear")
 
+	// Store the initial content scale.
+	if sx, sy, ok := contentScale(w.window); ok {
+		w.last.SetContentScale(sx, sy)
+		w.props.SetContentScale(sx, sy)
+	}
+
 	// Setup callbacks and the window.
 	w.initCallbacks()
 	w.useProps(p, true)
@@ -807,6 +1138,8 @@ func doNew(p *Props) (Window, gfx.Device, error) {
 		exit:          make(chan struct{}, 1),
 		rebuild:       make(chan struct{}),
 		waitNextFrame: make(chan struct{}),
+		invalidated:   make(chan struct{}, 1),
+		closing:       make(chan struct{}),
 	}
 
 	// Build the actual GLFW window.
@@ -817,6 +1150,7 @@ func doNew(p *Props) (Window, gfx.Device, error) {
 	w.Unlock()
 
 	w.swapper = util.NewSwapper(w.device)
+	w.swapper.Wait = w.waitFrame
 
 	// Spawn the goroutine responsible for running the window.
 	go w.run()
@@ -4,7 +4,10 @@
 
 package window
 
-import "runtime"
+import (
+	"runtime"
+	"time"
+)
 
 // The communicative main loop pattern used by this package is outlined lightly
 // in this blog post:
@@ -42,21 +45,96 @@ var MainLoopChan = make(chan func())
 //
 // By implementing MainLoop yourself, you can run other functions on the main
 // OS thread (for instance Cocoa API's on OS X).
+//
+// While every open window renders on-demand (see Props.SetContinuousRender)
+// MainLoop sleeps until window events arrive, rather than polling for them.
 func MainLoop() {
+	for {
+		var f func()
+		if allOnDemand() {
+			// Every window renders on-demand, so sleep until window events
+			// arrive (which are handled by GLFW directly) or a function is
+			// sent over the main loop channel.
+			var received bool
+			if f, received = waitEvents(0); !received {
+				continue
+			}
+		} else {
+			f = <-MainLoopChan
+		}
+
+		// If the function is nil then a window has closed. We should check
+		// if the number of open windows is zero, and if so, the main loop
+		// can end.
+		if f == nil && Num(0) == 0 {
+			return
+		}
+
+		// If the function is non-nil, execute it.
+		if f != nil {
+			f()
+		}
+	}
+}
+
+// PollEvents executes each function pending on MainLoopChan and processes any
+// pending window events once, without blocking. It returns false if a window
+// was closed and no windows are left open, i.e. when MainLoop would return.
+//
+// PollEvents (and WaitEvents) allow the main loop to be embedded into a
+// custom one, instead of using MainLoop. Like MainLoop, it must be called only
+// from the program's main function:
+//
+//	func main() {
+//	    go gfxLoop()
+//	    for window.PollEvents() {
+//	        ... other work on the main OS thread ...
+//	    }
+//	}
+//
+// Once PollEvents or WaitEvents has been called, the package no longer polls
+// for window events itself (120 times per second), and the program must
+// continue to call them for windows to receive events.
+//
+// It is not to be confused with Poll, which drains a channel of events.
+func PollEvents() bool {
 	for {
 		select {
 		case f := <-MainLoopChan:
-			// If the function is nil then a window has closed. We should check
-			// if the number of open windows is zero, and if so, the main loop
-			// can end.
-			if f == nil && Num(0) == 0 {
-				return
+			if f == nil {
+				if Num(0) == 0 {
+					return false
+				}
+				continue
 			}
+			f()
+		default:
+			selfPumped.Store(true)
+			pollNow()
+			return true
+		}
+	}
+}
 
-			// If the function is non-nil, execute it.
-			if f != nil {
-				f()
-			}
+// WaitEvents is like PollEvents, except that it first blocks until a window
+// event occurs, a function is sent over MainLoopChan, or the given timeout
+// elapses (a timeout of zero or less means no timeout).
+//
+// Applications which only need to redraw in response to input (e.g. tools and
+// editors) can use it to sleep, reducing idle CPU and GPU usage:
+//
+//	for window.WaitEvents(time.Second) {
+//	}
+func WaitEvents(timeout time.Duration) bool {
+	selfPumped.Store(true)
+	f, received := waitEvents(timeout)
+	if received {
+		if f == nil && Num(0) == 0 {
+			return false
+		}
+		if f != nil {
+			f()
 		}
 	}
+	return PollEvents()
 }
@@ -0,0 +1,103 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package window
+
+import (
+	"fmt"
+	"image"
+)
+
+// VideoMode describes a single video mode of a monitor.
+type VideoMode struct {
+	// The resolution of the video mode, in pixels.
+	Width, Height int
+
+	// The bit depth of the red, green, and blue channels of the video mode.
+	RedBits, GreenBits, BlueBits int
+
+	// The refresh rate of the video mode, in Hz.
+	RefreshRate int
+}
+
+// String returns a string like:
+//
+//	"VideoMode(1920x1080, 8/8/8, 60Hz)"
+func (v VideoMode) String() string {
+	return fmt.Sprintf("VideoMode(%dx%d, %d/%d/%d, %dHz)", v.Width, v.Height, v.RedBits, v.GreenBits, v.BlueBits, v.RefreshRate)
+}
+
+// Monitor describes a single monitor (i.e. display) connected to the system.
+type Monitor struct {
+	// The human-readable name of the monitor, as given by the system.
+	Name string
+
+	// The position of the monitor's upper-left corner on the virtual desktop,
+	// in screen coordinates. Together with the name it identifies the monitor.
+	Pos image.Point
+
+	// The physical size of the monitor, in millimetres.
+	PhysicalWidth, PhysicalHeight int
+
+	// Whether or not this is the primary monitor of the system.
+	Primary bool
+
+	// The current video mode of the monitor.
+	VideoMode VideoMode
+
+	// The video modes supported by the monitor.
+	modes []VideoMode
+}
+
+// VideoModes returns the video modes supported by the monitor, sorted in
+// ascending order (first by color bit depth, then by resolution, and then by
+// refresh rate).
+func (m *Monitor) VideoModes() []VideoMode {
+	return append([]VideoMode(nil), m.modes...)
+}
+
+// String returns a string like:
+//
+//	"Monitor(Name="DELL U2412M", Pos=(0,0), Primary=true)"
+func (m *Monitor) String() string {
+	return fmt.Sprintf("Monitor(Name=%q, Pos=%v, Primary=%t)", m.Name, m.Pos, m.Primary)
+}
+
+// sameMonitor tells if the two monitors identify the same one, a nil monitor
+// is only the same as another nil monitor.
+func sameMonitor(a, b *Monitor) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Name == b.Name && a.Pos == b.Pos
+}
+
+// sameVideoMode tells if the two video modes are equal, a nil video mode is
+// only the same as another nil video mode.
+func sameVideoMode(a, b *VideoMode) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Monitors returns a list of the monitors currently connected to the system,
+// the primary monitor is always first in the list. If the monitors cannot be
+// queried then nil is returned.
+//
+// Like New, Monitors requests an operation be run on the main loop internally
+// and as such it cannot be run on the main thread itself (MainLoop must be
+// running for Monitors to complete).
+//
+// This function is safe for access from multiple goroutines concurrently.
+func Monitors() []Monitor {
+	var monitors []Monitor
+	done := make(chan struct{}, 1)
+	MainLoopChan <- func() {
+		monitors = doMonitors()
+		done <- struct{}{}
+	}
+	<-done
+	return monitors
+}
@@ -6,14 +6,21 @@ package window
 
 import "sync"
 
+// subscribeBufferSize is the buffer size of channels returned by Subscribe.
+const subscribeBufferSize = 256
+
 // notifierEntry is a event channel and it's associated event mask. It is the
 // pair passed into the Window interface's Notify method.
 type notifierEntry struct {
 	ch chan<- Event
 	EventMask
+
+	// The filter of the entry, or nil. See the Window interface's Subscribe
+	// method.
+	filter func(Event) bool
 }
 
-// notifier implements the Window interface's Notify method.
+// notifier implements the Window interface's Notify and Subscribe methods.
 type notifier struct {
 	sync.RWMutex
 	entries []notifierEntry
@@ -25,11 +32,31 @@ func (n *notifier) Notify(ch chan<- Event, m EventMask) {
 	if m == NoEvents {
 		n.deleteEntries(ch)
 	} else {
-		n.entries = append(n.entries, notifierEntry{ch, m})
+		n.entries = append(n.entries, notifierEntry{ch, m, nil})
 	}
 	n.Unlock()
 }
 
+// Implements the Window interface.
+func (n *notifier) Subscribe(m EventMask, filter func(Event) bool) (<-chan Event, func()) {
+	ch := make(chan Event, subscribeBufferSize)
+	n.Lock()
+	n.entries = append(n.entries, notifierEntry{ch, m, filter})
+	n.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			// Once deleted no more events are sent, so we can close it.
+			n.Lock()
+			n.deleteEntries(ch)
+			n.Unlock()
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
+
 // findEntry searches for the entry associated with ch and returns it's slice
 // index or -1.
 //
@@ -45,21 +72,19 @@ func (n *notifier) findEntry(ch chan<- Event) int {
 
 // deleteEntries deletes all entries associated with ch.
 func (n *notifier) deleteEntries(ch chan<- Event) {
-	s := n.entries
 	idx := n.findEntry(ch)
 	for idx != -1 {
-		s = append(s[:idx], s[idx+1:]...)
+		n.entries = append(n.entries[:idx], n.entries[idx+1:]...)
 		idx = n.findEntry(ch)
 	}
-	n.entries = s
 }
 
 // sendEvent sends the given event to all of the notifier entries whose bitmask
-// matches with m.
+// matches with m, and whose filter (if any) accepts the event.
 func (n *notifier) sendEvent(ev Event, m EventMask) {
 	n.RLock()
 	for _, nf := range n.entries {
-		if (nf.EventMask & m) != 0 {
+		if (nf.EventMask&m) != 0 && (nf.filter == nil || nf.filter(ev)) {
 			select {
 			case nf.ch <- ev:
 			default:
@@ -6,7 +6,9 @@ package window
 
 import (
 	"fmt"
+	"image"
 	"sync"
+	"sync/atomic"
 
 	"github.com/qmcloud/engine/gfx"
 )
@@ -18,10 +20,21 @@ type Props struct {
 	title                                             string
 	width, height, fbWidth, fbHeight, x, y            int
 	cursorX, cursorY                                  float64
+	contentScaleX, contentScaleY                      float32
 	fullscreen, shouldClose, visible, decorated       bool
 	minimized, focused, vsync, resizable, alwaysOnTop bool
-	cursorGrabbed, resizeRenderSync                   bool
+	cursorGrabbed, resizeRenderSync, rawMouseInput    bool
+	borderlessFullscreen, keyRepeat, swapIntervalSet  bool
+	continuousRender                                  bool
+	swapInterval                                      int
 	precision                                         gfx.Precision
+	cursorImage                                       image.Image
+	cursorHotspot                                     image.Point
+	cursorGen                                         uint64
+	standardCursor                                    StandardCursor
+	opacity                                           float64
+	monitor                                           *Monitor
+	videoMode                                         *VideoMode
 }
 
 // String returns a string like:
@@ -69,6 +82,34 @@ func (p *Props) Fullscreen() bool {
 	return fullscreen
 }
 
+// SetBorderlessFullscreen sets whether or not the window is in borderless
+// fullscreen (i.e. windowed fullscreen) mode. In this mode the window is
+// sized to cover the monitor (see SetMonitor) and positioned at it's origin,
+// unlike SetFullscreen this does not switch the video mode of the monitor or
+// rebuild the window and it's device.
+//
+// If the window is also set to be fullscreen, fullscreen mode takes priority.
+// When leaving borderless fullscreen mode the window's prior size and position
+// are restored.
+//
+// Because some platforms cannot remove the window decorations after the window
+// is created, borderless fullscreen should be set before the window is created
+// (or SetDecorated(false) should be used) for the window to be undecorated.
+func (p *Props) SetBorderlessFullscreen(borderless bool) {
+	p.l.Lock()
+	p.borderlessFullscreen = borderless
+	p.l.Unlock()
+}
+
+// BorderlessFullscreen tells whether or not the window is in borderless
+// fullscreen mode.
+func (p *Props) BorderlessFullscreen() bool {
+	p.l.RLock()
+	borderless := p.borderlessFullscreen
+	p.l.RUnlock()
+	return borderless
+}
+
 // SetFramebufferSize sets the size of the framebuffer in pixels. Each value is
 // clamped to at least a value of 1.
 //
@@ -97,6 +138,35 @@ func (p *Props) FramebufferSize() (width, height int) {
 	return
 }
 
+// SetContentScale sets the content scale of the window, i.e. the ratio between
+// the framebuffer size in pixels and the window size in screen coordinates.
+//
+// Only the Window implementation should set the content scale: clients who are
+// just utilizing the existing implementations defined in this package should
+// not invoke this method.
+func (p *Props) SetContentScale(x, y float32) {
+	p.l.Lock()
+	p.contentScaleX = x
+	p.contentScaleY = y
+	p.l.Unlock()
+}
+
+// ContentScale returns the content scale (DPI scaling factor) of the window,
+// i.e. the ratio between the framebuffer size in pixels and the window size in
+// screen coordinates. For instance it is 2.0 on a HiDPI (e.g. Retina) display.
+//
+// User interfaces laid out in screen coordinates (points) should be scaled by
+// it when rendering, such that they appear at the same physical size on every
+// display. A ContentScaleChanged event is sent when it changes, e.g. when the
+// window is moved to a monitor with a different DPI.
+func (p *Props) ContentScale() (x, y float32) {
+	p.l.RLock()
+	x = p.contentScaleX
+	y = p.contentScaleY
+	p.l.RUnlock()
+	return
+}
+
 // SetSize sets the size of the window in screen coordinates. Each value is
 // clamped to at least a value of 1.
 func (p *Props) SetSize(width, height int) {
@@ -223,6 +293,44 @@ func (p *Props) VSync() bool {
 	return vsync
 }
 
+// SetSwapInterval sets an explicit swap interval, i.e. the number of screen
+// refreshes to wait for before swapping the window's buffers. It takes
+// precedence over the VSync property and is passed straight to the windowing
+// library, for example:
+//
+//	0: No vsync (tearing may occur).
+//	1: Standard vsync.
+//	2: Half the monitor's refresh rate.
+//	-1: Adaptive vsync (tear only when a frame is late), which requires the
+//	    WGL_EXT_swap_control_tear or GLX_EXT_swap_control_tear extension.
+//
+// Use ResetSwapInterval to go back to the swap interval chosen by VSync.
+func (p *Props) SetSwapInterval(interval int) {
+	p.l.Lock()
+	p.swapInterval = interval
+	p.swapIntervalSet = true
+	p.l.Unlock()
+}
+
+// ResetSwapInterval removes the explicit swap interval set via
+// SetSwapInterval, such that the VSync property is used instead.
+func (p *Props) ResetSwapInterval() {
+	p.l.Lock()
+	p.swapInterval = 0
+	p.swapIntervalSet = false
+	p.l.Unlock()
+}
+
+// SwapInterval returns the explicit swap interval set via SetSwapInterval. If
+// no explicit swap interval is set, ok is false and the VSync property is used
+// instead.
+func (p *Props) SwapInterval() (interval int, ok bool) {
+	p.l.RLock()
+	interval, ok = p.swapInterval, p.swapIntervalSet
+	p.l.RUnlock()
+	return
+}
+
 // SetFocused sets whether or not the window has focus.
 func (p *Props) SetFocused(focused bool) {
 	p.l.Lock()
@@ -285,6 +393,93 @@ func (p *Props) AlwaysOnTop() bool {
 	return alwaysOnTop
 }
 
+// SetOpacity sets the opacity of the whole window, including it's decorations,
+// where 0.0 is fully transparent and 1.0 is fully opaque. Values outside of
+// that range are clamped.
+//
+// Window opacity is currently unsupported: the GLFW 3.1 backend cannot change
+// it, so the window always remains opaque and any opacity other than 1.0 has
+// no effect other than logging an error.
+func (p *Props) SetOpacity(opacity float64) {
+	if opacity < 0 {
+		opacity = 0
+	} else if opacity > 1 {
+		opacity = 1
+	}
+	p.l.Lock()
+	p.opacity = opacity
+	p.l.Unlock()
+}
+
+// Opacity returns the opacity of the window.
+func (p *Props) Opacity() float64 {
+	p.l.RLock()
+	opacity := p.opacity
+	p.l.RUnlock()
+	return opacity
+}
+
+// SetMonitor sets the monitor that the window should be displayed on when it
+// is in fullscreen mode, the window will be rebuilt if it is currently
+// fullscreen. A nil monitor indicates the primary monitor. A copy of the
+// monitor is stored.
+//
+// The list of available monitors may be retrieved via the Monitors function.
+func (p *Props) SetMonitor(m *Monitor) {
+	if m != nil {
+		cpy := *m
+		m = &cpy
+	}
+	p.l.Lock()
+	p.monitor = m
+	p.l.Unlock()
+}
+
+// Monitor returns a copy of the monitor that the window should be displayed
+// on when it is in fullscreen mode, or nil if the primary monitor is used.
+func (p *Props) Monitor() *Monitor {
+	p.l.RLock()
+	m := p.monitor
+	p.l.RUnlock()
+	if m != nil {
+		cpy := *m
+		m = &cpy
+	}
+	return m
+}
+
+// SetVideoMode sets the video mode that the monitor should be switched to
+// when the window is in fullscreen mode, the window will be rebuilt if it is
+// currently fullscreen. A nil video mode indicates the monitor's current
+// video mode (i.e. no video mode switch occurs). A copy of the video mode is
+// stored.
+//
+// The video mode should be one of the modes returned by the VideoModes method
+// of the monitor in use, otherwise the closest matching one is chosen.
+func (p *Props) SetVideoMode(vm *VideoMode) {
+	if vm != nil {
+		cpy := *vm
+		vm = &cpy
+	}
+	p.l.Lock()
+	p.videoMode = vm
+	p.l.Unlock()
+}
+
+// VideoMode returns a copy of the video mode that the monitor should be
+// switched to when the window is in fullscreen mode, or nil if the monitor's
+// current video mode is used.
+func (p *Props) VideoMode() *VideoMode {
+	p.l.RLock()
+	vm := p.videoMode
+	p.l.RUnlock()
+	if vm != nil {
+		cpy := *vm
+		vm = &cpy
+	}
+	return vm
+}
+
 // SetCursorGrabbed sets whether or not the cursor should be grabbed. If the
 // cursor is grabbed, it is hidden from sight and cannot leave the window.
 //
@@ -304,6 +499,118 @@ func (p *Props) CursorGrabbed() bool {
 	return grabbed
 }
 
+// SetCursorImage sets the image to use for the cursor when it is over the
+// window. The hotspot is the point, relative to the top-left corner of the
+// image, that acts as the cursor position (e.g. the tip of an arrow).
+//
+// Setting the cursor image overrides any cursor shape previously set via
+// SetStandardCursor. A nil image restores the standard cursor shape.
+//
+// The image is not copied, so it should not be modified after this call.
+func (p *Props) SetCursorImage(img image.Image, hotspot image.Point) {
+	p.l.Lock()
+	p.cursorImage = img
+	p.cursorHotspot = hotspot
+	p.cursorGen = 0
+	if img != nil {
+		p.cursorGen = cursorGens.Add(1)
+	}
+	p.l.Unlock()
+}
+
+// cursorGens is the source of cursor image generations, see cursorImageGen.
+var cursorGens atomic.Uint64
+
+// cursorImageGen is like CursorImage, but also returns the generation of the
+// cursor image. Each call to SetCursorImage (on any Props) with a non-nil image
+// yields a new, unique, generation; the generation of a nil image is zero.
+//
+// Images are not always comparable (e.g. an image struct holding a slice by
+// value panics when compared), so generations are used to detect changes.
+func (p *Props) cursorImageGen() (img image.Image, hotspot image.Point, gen uint64) {
+	p.l.RLock()
+	img = p.cursorImage
+	hotspot = p.cursorHotspot
+	gen = p.cursorGen
+	p.l.RUnlock()
+	return
+}
+
+// CursorImage returns the cursor image and hotspot, as previously set via
+// SetCursorImage. If no image is set, img == nil.
+func (p *Props) CursorImage() (img image.Image, hotspot image.Point) {
+	p.l.RLock()
+	img = p.cursorImage
+	hotspot = p.cursorHotspot
+	p.l.RUnlock()
+	return
+}
+
+// SetStandardCursor sets the standard cursor shape to use when the cursor is
+// over the window. It also clears any cursor image previously set via
+// SetCursorImage.
+func (p *Props) SetStandardCursor(c StandardCursor) {
+	p.l.Lock()
+	p.standardCursor = c
+	p.cursorImage = nil
+	p.cursorHotspot = image.Point{}
+	p.cursorGen = 0
+	p.l.Unlock()
+}
+
+// StandardCursor returns the standard cursor shape, as previously set via
+// SetStandardCursor.
+func (p *Props) StandardCursor() StandardCursor {
+	p.l.RLock()
+	c := p.standardCursor
+	p.l.RUnlock()
+	return c
+}
+
+// SetRawMouseInput sets whether or not raw (unaccelerated and unscaled) mouse
+// motion should be used while the cursor is grabbed, if the platform supports
+// it. Raw motion is better suited for e.g. FPS style cameras, as it is not
+// affected by the desktop's pointer acceleration settings.
+//
+// It has no effect unless the cursor is grabbed (see SetCursorGrabbed).
+//
+// Raw mouse input is currently unsupported: the GLFW 3.1 backend cannot enable
+// it, so requesting it has no effect other than logging an error, and mouse
+// motion remains subject to pointer acceleration.
+func (p *Props) SetRawMouseInput(raw bool) {
+	p.l.Lock()
+	p.rawMouseInput = raw
+	p.l.Unlock()
+}
+
+// RawMouseInput returns whether or not raw mouse input is requested.
+func (p *Props) RawMouseInput() bool {
+	p.l.RLock()
+	raw := p.rawMouseInput
+	p.l.RUnlock()
+	return raw
+}
+
+// SetKeyRepeat sets whether or not key repeat events (generated by the
+// operating system while a key is held down) should be sent as keyboard
+// ButtonEvents, with their Repeat field set to true. This is useful for e.g.
+// text fields, where holding down a key should repeat it's action.
+//
+// Repeat events never change the state of the window's keyboard watcher.
+func (p *Props) SetKeyRepeat(repeat bool) {
+	p.l.Lock()
+	p.keyRepeat = repeat
+	p.l.Unlock()
+}
+
+// KeyRepeat returns whether or not key repeat events are sent.
+func (p *Props) KeyRepeat() bool {
+	p.l.RLock()
+	repeat := p.keyRepeat
+	p.l.RUnlock()
+	return repeat
+}
+
 // SetResizeRenderSync sets whether or not window resize operations should be
 // synchronized with rendering. In general, this controls whether or not
 // resizing the window will be appear "fluid" by halting the user from resizing
@@ -324,6 +631,32 @@ func (p *Props) ResizeRenderSync() bool {
 	return sync
 }
 
+// SetContinuousRender sets whether or not the window should render frames
+// continuously (i.e. as fast as the device's Render method is called), which
+// is what games typically want.
+//
+// When disabled the window renders on-demand instead: each call to the
+// device's Render method blocks until the next frame is needed, that is until
+// the Invalidate method of the window is called or an event (e.g. input) is
+// sent by the window. Additionally, while every open window renders on-demand
+// MainLoop sleeps until window events arrive (see WaitEvents), rather than
+// polling for them. This greatly reduces the power usage of applications such
+// as tools and editors.
+func (p *Props) SetContinuousRender(continuous bool) {
+	p.l.Lock()
+	p.continuousRender = continuous
+	p.l.Unlock()
+}
+
+// ContinuousRender tells whether or not the window renders continuously, as
+// previously set via SetContinuousRender.
+func (p *Props) ContinuousRender() bool {
+	p.l.RLock()
+	continuous := p.continuousRender
+	p.l.RUnlock()
+	return continuous
+}
+
 // SetPrecision sets the framebuffer precision to be requested when the window
 // is created.
 //
@@ -359,6 +692,32 @@ func (p *Props) Precision() gfx.Precision {
 	return precision
 }
 
+// SetSamples sets the number of samples to request for multisampling (MSAA) of
+// the window's framebuffer, i.e. the Samples field of the precision (see
+// SetPrecision). Zero disables multisampling.
+//
+// Unlike the rest of the precision, the number of samples may be changed after
+// the window is created (e.g. from an in-game graphics setting). Because it
+// cannot be changed at runtime by GLFW, doing so rebuilds the window and it's
+// device (just like switching to and from fullscreen mode does). This is a
+// heavyweight operation which should not be performed often, but the window's
+// state is restored afterwards and assets are not lost, as they are stored in
+// a shared context.
+func (p *Props) SetSamples(n int) {
+	p.l.Lock()
+	p.precision.Samples = n
+	p.l.Unlock()
+}
+
+// Samples returns the number of samples to request for multisampling of the
+// window's framebuffer, as previously set via SetSamples or SetPrecision.
+func (p *Props) Samples() int {
+	p.l.RLock()
+	samples := p.precision.Samples
+	p.l.RUnlock()
+	return samples
+}
+
 // NewProps returns a new initialized set of window properties. The default
 // values for each property are as follows:
 //
@@ -370,14 +729,25 @@ func (p *Props) Precision() gfx.Precision {
 //	Visible: true
 //	Minimized: false
 //	Fullscreen: false
+//	BorderlessFullscreen: false
 //	Focused: true
 //	VSync: true
+//	SwapInterval: unset (chosen by VSync)
 //	Resizable: true
 //	Decorated: true
 //	AlwaysOnTop: false
+//	Opacity: 1.0
+//	Monitor: nil (primary monitor)
+//	VideoMode: nil (monitor's current video mode)
 //	CursorGrabbed: false
+//	RawMouseInput: false
+//	KeyRepeat: false
+//	CursorImage: nil, image.Point{}
+//	StandardCursor: DefaultCursor
 //	ResizeRenderSync: true
+//	ContinuousRender: true
 //	FramebufferSize: 1x1 (set via window owner)
+//	ContentScale: 1, 1 (set via window owner)
 //	Precision: gfx.Precision{
 //	    RedBits: 8, GreenBits: 8, BlueBits: 8, AlphaBits: 0,
 //	    DepthBits: 24,
@@ -395,6 +765,8 @@ func NewProps() *Props {
 		y:                -1,
 		cursorX:          -1.0,
 		cursorY:          -1.0,
+		contentScaleX:    1.0,
+		contentScaleY:    1.0,
 		shouldClose:      true,
 		visible:          true,
 		minimized:        false,
@@ -404,8 +776,12 @@ func NewProps() *Props {
 		resizable:        true,
 		decorated:        true,
 		alwaysOnTop:      false,
+		opacity:          1.0,
 		cursorGrabbed:    false,
+		rawMouseInput:    false,
+		standardCursor:   DefaultCursor,
 		resizeRenderSync: true,
+		continuousRender: true,
 		precision: gfx.Precision{
 			RedBits: 8, GreenBits: 8, BlueBits: 8, AlphaBits: 0,
 			DepthBits: 24,
@@ -0,0 +1,44 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package window
+
+import (
+	"image"
+	"testing"
+)
+
+// sliceImage is an image which is not comparable, as it holds a slice by
+// value.
+type sliceImage struct {
+	*image.RGBA
+	extra []byte
+}
+
+func TestCursorImageGen(t *testing.T) {
+	p := NewProps()
+	if _, _, gen := p.cursorImageGen(); gen != 0 {
+		t.Fatal("got generation", gen, "want 0 without an image")
+	}
+
+	img := sliceImage{RGBA: image.NewRGBA(image.Rect(0, 0, 2, 2))}
+	p.SetCursorImage(img, image.Pt(1, 1))
+	_, hotspot, first := p.cursorImageGen()
+	if first == 0 || hotspot != image.Pt(1, 1) {
+		t.Fatal("got generation", first, "hotspot", hotspot)
+	}
+
+	// Setting the same (non-comparable) image again is a new generation, even
+	// on a different Props.
+	q := NewProps()
+	q.SetCursorImage(img, image.Pt(1, 1))
+	if _, _, gen := q.cursorImageGen(); gen == 0 || gen == first {
+		t.Fatal("got generation", gen, "want a new one, not", first)
+	}
+
+	p.SetStandardCursor(HandCursor)
+	if img, _, gen := p.cursorImageGen(); img != nil || gen != 0 {
+		t.Fatal("got image", img, "generation", gen, "want nil and 0")
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package window
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/qmcloud/engine/keyboard"
+	"github.com/qmcloud/engine/mouse"
+)
+
+// ErrReplayUnsupported is returned by ReplayEvents when the window does not
+// support injecting events.
+var ErrReplayUnsupported = errors.New("window does not support replaying events")
+
+// recordBufferSize is the buffer size of channels returned by RecordEvents.
+const recordBufferSize = 1024
+
+// eventSender is implemented by windows which dispatch events through a
+// notifier, and thus can have events injected into them.
+type eventSender interface {
+	sendEvent(ev Event, m EventMask)
+}
+
+// recordings maps the channels returned by RecordEvents to the window and
+// channel that they were registered with.
+var recordings struct {
+	sync.Mutex
+	m map[<-chan Event]recording
+}
+
+type recording struct {
+	w  Window
+	ch chan Event
+}
+
+// RecordEvents begins recording all of the events of the window that match
+// the given event mask, for instance for automated UI testing. The events are
+// sent over the returned channel in the order that they occur, with their
+// original timestamps.
+//
+// The returned channel has a generous buffer, but like any channel given to
+// Notify events are dropped if it is not read from quickly enough.
+//
+// Recording continues until StopRecording is called with the returned
+// channel.
+func RecordEvents(w Window, types EventMask) <-chan Event {
+	ch := make(chan Event, recordBufferSize)
+	w.Notify(ch, types)
+
+	recordings.Lock()
+	if recordings.m == nil {
+		recordings.m = make(map[<-chan Event]recording)
+	}
+	recordings.m[ch] = recording{w: w, ch: ch}
+	recordings.Unlock()
+	return ch
+}
+
+// StopRecording stops a recording previously started by RecordEvents. The
+// channel is not closed, as such events that were already recorded may still
+// be read from it.
+func StopRecording(events <-chan Event) {
+	recordings.Lock()
+	r, ok := recordings.m[events]
+	delete(recordings.m, events)
+	recordings.Unlock()
+	if ok {
+		r.w.Notify(r.ch, NoEvents)
+	}
+}
+
+// ReplayEvents injects the given (e.g. recorded) events back into the window,
+// such that they are dispatched to every channel given to the window's Notify
+// method as if they had actually occurred. The events themselves, including
+// their timestamps, are sent unmodified.
+//
+// Events are only dispatched to Notify channels: the window's properties and
+// it's keyboard and mouse watchers are not affected.
+//
+// The speed controls the delay between dispatching each event: a speed of one
+// preserves the original timing between events, a speed of two replays twice
+// as fast, and so on. A speed of zero (or less) dispatches all of the events
+// immediately. ReplayEvents blocks until all events have been dispatched.
+//
+// If the window does not support injecting events, ErrReplayUnsupported is
+// returned.
+func ReplayEvents(w Window, events []Event, speed float64) error {
+	s, ok := w.(eventSender)
+	if !ok {
+		return ErrReplayUnsupported
+	}
+	for i, ev := range events {
+		if i > 0 && speed > 0 {
+			delta := ev.Time().Sub(events[i-1].Time())
+			if delta > 0 {
+				time.Sleep(time.Duration(float64(delta) / speed))
+			}
+		}
+		s.sendEvent(ev, eventMask(ev))
+	}
+	return nil
+}
+
+// eventMask returns the event mask matching the type of the given event.
+func eventMask(ev Event) EventMask {
+	switch ev.(type) {
+	case Close:
+		return CloseEvents
+	case Damaged:
+		return DamagedEvents
+	case CursorMoved:
+		return CursorMovedEvents
+	case CursorEnter:
+		return CursorEnterEvents
+	case CursorExit:
+		return CursorExitEvents
+	case Minimized:
+		return MinimizedEvents
+	case Restored:
+		return RestoredEvents
+	case GainedFocus:
+		return GainedFocusEvents
+	case LostFocus:
+		return LostFocusEvents
+	case Moved:
+		return MovedEvents
+	case Resized:
+		return ResizedEvents
+	case FramebufferResized:
+		return FramebufferResizedEvents
+	case ContentScaleChanged:
+		return ContentScaleChangedEvents
+	case ItemsDropped:
+		return ItemsDroppedEvents
+	case mouse.ButtonEvent:
+		return MouseButtonEvents
+	case mouse.Scrolled:
+		return MouseScrolledEvents
+	case keyboard.Typed:
+		return KeyboardTypedEvents
+	case keyboard.ButtonEvent:
+		return KeyboardButtonEvents
+	case keyboard.Composition:
+		return KeyboardCompositionEvents
+	}
+	return AllEvents
+}
@@ -0,0 +1,91 @@
+// Copyright 2014 The Azul3D Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package window
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// countingWindow is a window which dispatches events through a notifier, and
+// counts the events sent to it.
+type countingWindow struct {
+	Window
+	n    notifier
+	sent int
+}
+
+func (w *countingWindow) Notify(ch chan<- Event, m EventMask) {
+	w.n.Notify(ch, m)
+}
+
+func (w *countingWindow) sendEvent(ev Event, m EventMask) {
+	w.sent++
+	w.n.sendEvent(ev, m)
+}
+
+// drain returns the events buffered in the channel.
+func drain(ch <-chan Event) []Event {
+	var events []Event
+	for len(ch) > 0 {
+		events = append(events, <-ch)
+	}
+	return events
+}
+
+func TestRecordReplayEvents(t *testing.T) {
+	start := time.Now()
+	src := &countingWindow{}
+	rec := RecordEvents(src, MovedEvents|CloseEvents)
+
+	// Only the events matching the mask are recorded.
+	src.sendEvent(Moved{X: 1, Y: 2, T: start}, MovedEvents)
+	src.sendEvent(Resized{Width: 3, Height: 4, T: start}, ResizedEvents)
+	src.sendEvent(Close{T: start.Add(20 * time.Millisecond)}, CloseEvents)
+	StopRecording(rec)
+	src.sendEvent(Close{T: start}, CloseEvents)
+
+	events := drain(rec)
+	want := []Event{
+		Moved{X: 1, Y: 2, T: start},
+		Close{T: start.Add(20 * time.Millisecond)},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatal("recorded", events, "want", want)
+	}
+
+	// Replaying dispatches the events, unmodified and in order, to each
+	// channel of the other window.
+	dst := &countingWindow{}
+	all := make(chan Event, 8)
+	dst.Notify(all, AllEvents)
+	closed := make(chan Event, 8)
+	dst.Notify(closed, CloseEvents)
+
+	before := time.Now()
+	if err := ReplayEvents(dst, events, 2); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(before); d < 10*time.Millisecond {
+		t.Fatal("replayed at twice the speed in", d, "want at least 10ms")
+	}
+	if dst.sent != 2 {
+		t.Fatal("sent", dst.sent, "events, want 2")
+	}
+	if got := drain(all); !reflect.DeepEqual(got, want) {
+		t.Fatal("replayed", got, "want", want)
+	}
+	if got := drain(closed); !reflect.DeepEqual(got, want[1:]) {
+		t.Fatal("replayed", got, "to the close channel, want", want[1:])
+	}
+}
+
+func TestReplayEventsUnsupported(t *testing.T) {
+	w := struct{ Window }{}
+	if err := ReplayEvents(w, []Event{Close{}}, 0); err != ErrReplayUnsupported {
+		t.Fatal("got", err, "want", ErrReplayUnsupported)
+	}
+}
@@ -101,6 +101,45 @@ type Window interface {
 	// for this.
 	Notify(ch chan<- Event, m EventMask)
 
+	// Subscribe is like Notify, except that the window creates the event
+	// channel itself (with a generous buffer) and only relays the events that
+	// match the event mask and for which filter returns true. A nil filter
+	// relays every event that matches the event mask. For example, to receive
+	// only key presses:
+	//
+	//  events, cancel := w.Subscribe(window.KeyboardButtonEvents, func(ev window.Event) bool {
+	//      e, ok := ev.(keyboard.ButtonEvent)
+	//      return ok && e.State == keyboard.Down
+	//  })
+	//  defer cancel()
+	//
+	// The cancel function stops relaying events and then closes the channel,
+	// such that e.g. a goroutine ranging over the channel exits. It may be
+	// called multiple times, and from any goroutine.
+	//
+	// The filter is called synchronously as each event occurs, so it should
+	// return quickly, and it must not call the Notify or Subscribe methods of
+	// the window (or cancel a subscription).
+	Subscribe(m EventMask, filter func(Event) bool) (events <-chan Event, cancel func())
+
+	// Invalidate marks the window as needing a new frame. It is only useful
+	// for windows which render on-demand (see Props.SetContinuousRender), in
+	// which case the pending call to the device's Render method returns such
+	// that the next frame can be rendered.
+	//
+	// It is safe to call from any goroutine, and multiple calls before the
+	// next frame is rendered result in just a single frame.
+	Invalidate()
+
+	// Do executes f on the main thread (see MainLoopChan) and waits for it to
+	// complete, which is useful for calling platform API's that must be used
+	// from the main thread (e.g. to retrieve native window handles).
+	//
+	// Because it waits for the main loop, Do must not be called from the main
+	// thread itself (e.g. from within a function already running on the main
+	// loop), nor while the main loop is not running, or else it deadlocks.
+	Do(f func())
+
 	// Close closes the window, it must be called or else the main loop (and
 	// inheritely, the application) will not exit.
 	Close()
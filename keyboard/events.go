@@ -20,11 +20,16 @@ import (
 // The Raw member must uniquely identify the keyboard button whose state is
 // changing, and must always be present regardless of whether or not Key ==
 // Invalid. It could (but does not have to be) e.g. the scancode of the key.
+//
+// If Repeat is true then the event was generated by the operating system's key
+// repeat because the key is being held down, the State is always Down and the
+// key was already down prior to the event.
 type ButtonEvent struct {
-	T     time.Time
-	Key   Key
-	State State
-	Raw   uint64
+	T      time.Time
+	Key    Key
+	State  State
+	Raw    uint64
+	Repeat bool
 }
 
 // Time returns the time at which this event occured.
@@ -34,7 +39,7 @@ func (b ButtonEvent) Time() time.Time {
 
 // String returns an string representation of this event.
 func (b ButtonEvent) String() string {
-	return fmt.Sprintf("ButtonEvent(Key=%v, State=%v, Raw=%v, Time=%v)", b.Key, b.State, b.Raw, b.T)
+	return fmt.Sprintf("ButtonEvent(Key=%v, State=%v, Raw=%v, Repeat=%v, Time=%v)", b.Key, b.State, b.Raw, b.Repeat, b.T)
 }
 
 // Typed represents an event where some sort of user input has generated a
@@ -53,3 +58,31 @@ func (t Typed) Time() time.Time {
 func (t Typed) String() string {
 	return t.S
 }
+
+// Composition represents an event where an input method editor (IME) has
+// changed the in-progress (i.e. preedit) text being composed by the user, for
+// instance while typing CJK text. The composed text is not yet user input: once
+// the composition is committed a Typed event is generated with the final text,
+// and the composition ends with an empty S.
+//
+// When no input method is active (or it is not supported by the platform) no
+// Composition events occur and typed text is only delivered via Typed events.
+type Composition struct {
+	T time.Time
+
+	// The in-progress composition text.
+	S string
+
+	// The position of the IME cursor within the composition text, in runes.
+	Cursor int
+}
+
+// Time returns the time at which this event occured.
+func (c Composition) Time() time.Time {
+	return c.T
+}
+
+// String returns an string representation of this event.
+func (c Composition) String() string {
+	return fmt.Sprintf("Composition(S=%q, Cursor=%v, Time=%v)", c.S, c.Cursor, c.T)
+}
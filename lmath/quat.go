@@ -194,6 +194,34 @@ func (a Quat) Lerp(b Quat, t float64) Quat {
 	return a.Mul(b.MulScalar(t))
 }
 
+// Slerp returns a quaternion representing the spherical linear interpolation
+// between the unit quaternions a and b. The t parameter is the amount to
+// interpolate (0.0 - 1.0) between the quaternions.
+//
+// The interpolation always takes the shortest path between the two rotations.
+func (a Quat) Slerp(b Quat, t float64) Quat {
+	cosTheta := a.Dot(b)
+
+	// If the dot product is negative, negate one quaternion such that the
+	// shortest path is taken (q and -q represent the same rotation).
+	if cosTheta < 0 {
+		b = b.MulScalar(-1)
+		cosTheta = -cosTheta
+	}
+
+	// If the quaternions are very close, fall back to normalized linear
+	// interpolation to avoid dividing by a near-zero sine.
+	if cosTheta > 1-EPSILON {
+		return a.Add(b.Sub(a).MulScalar(t)).Normalized()
+	}
+
+	theta := math.Acos(cosTheta)
+	sinTheta := math.Sin(theta)
+	wa := math.Sin((1-t)*theta) / sinTheta
+	wb := math.Sin(t*theta) / sinTheta
+	return a.MulScalar(wa).Add(b.MulScalar(wb))
+}
+
 // Conjugate calculates and returns the conjugate of this quaternion.
 func (a Quat) Conjugate() Quat {
 	return Quat{
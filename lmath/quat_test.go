@@ -30,3 +30,28 @@ func TestQuatToEuler(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestQuatSlerp(t *testing.T) {
+	a := QuatFromAxisAngle(Vec3{0, 0, 1}, Radians(0))
+	b := QuatFromAxisAngle(Vec3{0, 0, 1}, Radians(90))
+	tests := []struct {
+		t    float64
+		want Quat
+	}{
+		{0, a},
+		{1, b},
+		{0.5, QuatFromAxisAngle(Vec3{0, 0, 1}, Radians(45))},
+	}
+	for _, tst := range tests {
+		got := a.Slerp(b, tst.t)
+		if !got.AlmostEquals(tst.want, 1e-6) {
+			t.Errorf("Slerp(%v): got %v, want %v", tst.t, got, tst.want)
+		}
+	}
+
+	// The shortest path must be taken, even if b is negated.
+	got := a.Slerp(b.MulScalar(-1), 0.5)
+	if !got.AlmostEquals(tests[2].want, 1e-6) && !got.AlmostEquals(tests[2].want.MulScalar(-1), 1e-6) {
+		t.Errorf("Slerp(-b, 0.5): got %v, want %v", got, tests[2].want)
+	}
+}
@@ -31,7 +31,14 @@ type Scrolled struct {
 	T time.Time
 
 	// Amount of scrolling in horizontal (X) and vertical (Y) directions.
+	// Positive X values scroll to the right, and positive Y values scroll up.
 	X, Y float64
+
+	// Whether or not the scrolling originated from a smooth scrolling device
+	// (e.g. a trackpad) as opposed to a stepped mouse wheel. Stepped wheels
+	// only ever report whole steps, whereas smooth devices report fractional
+	// amounts.
+	Smooth bool
 }
 
 // Time implements the Event interface.
@@ -41,5 +48,5 @@ func (s Scrolled) Time() time.Time {
 
 // String returns a string representation of this event.
 func (s Scrolled) String() string {
-	return fmt.Sprintf("Scrolled(X=%f, Y=%f, Time=%v)", s.X, s.Y, s.T)
+	return fmt.Sprintf("Scrolled(X=%f, Y=%f, Smooth=%t, Time=%v)", s.X, s.Y, s.Smooth, s.T)
 }
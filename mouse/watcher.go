@@ -17,6 +17,9 @@ type Watcher struct {
 	// states is a (at max 8-bit) lookup table, where the indexes are literally
 	// Button values.
 	states []State
+
+	// The accumulated scroll amount since the last call to ScrollDelta.
+	scrollX, scrollY float64
 }
 
 // String returns a multi-line string representation of this mouse watcher and
@@ -130,6 +133,33 @@ func (w *Watcher) Up(button Button) bool {
 	return w.State(button) == Up
 }
 
+// AddScroll adds the given horizontal (X) and vertical (Y) scroll amounts to
+// the scroll delta accumulated by this watcher.
+func (w *Watcher) AddScroll(x, y float64) {
+	w.access.Lock()
+	defer w.access.Unlock()
+
+	w.scrollX += x
+	w.scrollY += y
+}
+
+// ScrollDelta returns the total horizontal (X) and vertical (Y) scroll amounts
+// accumulated since the last call to this method, and then resets them to
+// zero. It is useful for polling the scroll wheel once per frame instead of
+// handling each individual Scrolled event:
+//
+//  x, y := watcher.ScrollDelta()
+//  zoom += y
+//
+func (w *Watcher) ScrollDelta() (x, y float64) {
+	w.access.Lock()
+	defer w.access.Unlock()
+
+	x, y = w.scrollX, w.scrollY
+	w.scrollX, w.scrollY = 0, 0
+	return
+}
+
 // NewWatcher returns a new, initialized, mouse watcher.
 func NewWatcher() *Watcher {
 	w := new(Watcher)
@@ -55,3 +55,19 @@ func TestWatcher(t *testing.T) {
 		t.Logf("%q\n", m)
 	}
 }
+
+func TestWatcherScrollDelta(t *testing.T) {
+	m := NewWatcher()
+	m.AddScroll(1, -1)
+	m.AddScroll(0.5, -2)
+	x, y := m.ScrollDelta()
+	if x != 1.5 || y != -3 {
+		t.Fatalf("got ScrollDelta() == %v, %v, want 1.5, -3\n", x, y)
+	}
+
+	// The accumulated delta must have been reset.
+	x, y = m.ScrollDelta()
+	if x != 0 || y != 0 {
+		t.Fatalf("got ScrollDelta() == %v, %v, want 0, 0\n", x, y)
+	}
+}